@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"go-tsukamoto/config"
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/rules"
 	"go-tsukamoto/internal/server"
 	"log"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
@@ -46,6 +49,22 @@ func main() {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 
+	// Muat basis aturan fuzzy dari berkas jika dikonfigurasi
+	if path := os.Getenv("FUZZY_RULES_FILE"); path != "" {
+		if err := rules.LoadFile(path); err != nil {
+			log.Fatalf("failed to load fuzzy rules: %v", err)
+		}
+	}
+
+	// Metode inferensi bawaan (tsukamoto, mamdani, larsen atau sugeno)
+	if name := os.Getenv("FUZZY_ENGINE"); name != "" {
+		engine, err := inferensia.ParseEngine(name)
+		if err != nil {
+			log.Fatalf("failed to configure fuzzy engine: %v", err)
+		}
+		inferensia.SetDefaultEngine(engine)
+	}
+
 	server := server.NewServer(db) // Tambahkan argumen db
 
 	// Create a done channel to signal when the shutdown is complete
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-tsukamoto/config"
+	"go-tsukamoto/internal/app/service/fuzzymodel"
+	"go-tsukamoto/internal/modules/analisis"
+	"go-tsukamoto/internal/modules/fcl"
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	_ "github.com/joho/godotenv/autoload"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// propcheck menjalankan masukan acak melalui model dan memeriksa invarian
+// domain: IPK yang lebih tinggi tidak pernah menurunkan predikat, lebih
+// banyak mata kuliah mengulang tidak pernah menaikkannya, dan setiap keluaran
+// berada pada himpunan predikat. Pelanggaran dicetak sebagai contoh
+// penyangkal yang sudah diperkecil dan program keluar dengan status 1.
+//
+//	go run ./cmd/propcheck                                   # model bawaan
+//	go run ./cmd/propcheck -published                        # model yang dipublikasikan di basis data
+//	go run ./cmd/propcheck -model model.fcl -engine mamdani
+//	go run ./cmd/propcheck -range ipk=2:4,studyDuration=7:14 -monotonic ipk+,repeatedCourses-,activity+
+func main() {
+	modelFile := flag.String("model", "", "model definition file (YAML/JSON, or FCL with the .fcl extension)")
+	published := flag.Bool("published", false, "check the model published in the database")
+	engineName := flag.String("engine", "", "inference engine (default tsukamoto)")
+	samples := flag.Int("samples", 0, "number of random inputs (default 2000)")
+	seed := flag.Int64("seed", 0, "random seed")
+	maxCounterexamples := flag.Int("max", 0, "maximum number of counterexamples listed (default 10)")
+	ranges := flag.String("range", "", "comma-separated variable=min:max limits for the random inputs")
+	monotonic := flag.String("monotonic", "", "comma-separated monotonic properties, variable+ or variable- (default ipk+,repeatedCourses-)")
+	flag.Parse()
+
+	m, err := load(*modelFile, *published)
+	if err != nil {
+		log.Fatal(err)
+	}
+	engine, err := inferensia.ParseEngine(*engineName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := analisis.PropertyOptions{
+		Samples:            *samples,
+		Seed:               *seed,
+		Engine:             engine,
+		Discrete:           analisis.DiscreteVariables,
+		MaxCounterexamples: *maxCounterexamples,
+	}
+	if opts.Ranges, err = parseRanges(*ranges); err != nil {
+		log.Fatal(err)
+	}
+	if opts.Monotonicity, err = parseMonotonicity(*monotonic); err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := analisis.CheckProperties(m, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("model version %d, %s, %d random inputs, %d checks, %d violations\n", m.Version, engine, report.Samples, report.Checks, report.Violations)
+	for _, counterexample := range report.Counterexamples {
+		fmt.Println(counterexample)
+	}
+	if !report.Valid() {
+		os.Exit(1)
+	}
+	fmt.Println("all properties hold")
+}
+
+// parseRanges membaca "ipk=2:4,studyDuration=7:14"
+func parseRanges(value string) (map[string][2]float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	ranges := map[string][2]float64{}
+	for _, item := range strings.Split(value, ",") {
+		name, bounds, ok := strings.Cut(strings.TrimSpace(item), "=")
+		lo, hi, okBounds := strings.Cut(bounds, ":")
+		if !ok || !okBounds {
+			return nil, fmt.Errorf("invalid range %q, expected variable=min:max", item)
+		}
+		min, errMin := strconv.ParseFloat(lo, 64)
+		max, errMax := strconv.ParseFloat(hi, 64)
+		if errMin != nil || errMax != nil || min > max {
+			return nil, fmt.Errorf("invalid range %q, expected variable=min:max", item)
+		}
+		ranges[name] = [2]float64{min, max}
+	}
+	return ranges, nil
+}
+
+// parseMonotonicity membaca "ipk+,repeatedCourses-"; kosong berarti sifat bawaan
+func parseMonotonicity(value string) ([]analisis.Monotonicity, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var properties []analisis.Monotonicity
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		switch {
+		case strings.HasSuffix(item, "+"):
+			properties = append(properties, analisis.Monotonicity{Variable: strings.TrimSuffix(item, "+"), Direction: analisis.Increasing})
+		case strings.HasSuffix(item, "-"):
+			properties = append(properties, analisis.Monotonicity{Variable: strings.TrimSuffix(item, "-"), Direction: analisis.Decreasing})
+		default:
+			return nil, fmt.Errorf("invalid monotonic property %q, expected variable+ or variable-", item)
+		}
+	}
+	return properties, nil
+}
+
+func load(modelFile string, published bool) (*model.Model, error) {
+	switch {
+	case modelFile != "" && published:
+		return nil, fmt.Errorf("use either -model or -published, not both")
+	case published:
+		db, err := gorm.Open(postgres.Open(config.GetDSN()), &gorm.Config{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect database: %v", err)
+		}
+		return fuzzymodel.NewService(db).GetPublishedModel(context.Background())
+	case modelFile != "":
+		return fcl.LoadFile(modelFile)
+	}
+	return model.Default(), nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go-tsukamoto/internal/modules/analisis"
+	"go-tsukamoto/internal/modules/fcl"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+	"log"
+	"os"
+)
+
+// rulecheck memeriksa basis aturan sebelum dipublikasikan dan keluar dengan
+// status 1 jika ditemukan masalah, sehingga dapat dipakai pada CI.
+//
+//	go run ./cmd/rulecheck                          # model bawaan
+//	go run ./cmd/rulecheck -rules aturan.yaml       # basis aturan dengan variabel bawaan
+//	go run ./cmd/rulecheck -model model.json        # definisi model lengkap
+//	go run ./cmd/rulecheck -model model.fcl         # model dalam format FCL
+func main() {
+	rulesFile := flag.String("rules", "", "rule base file (YAML/JSON) checked against the default variables")
+	modelFile := flag.String("model", "", "full model definition file (YAML/JSON, or FCL with the .fcl extension)")
+	threshold := flag.Float64("threshold", 0, "minimum firing strength for a region to count as covered (default 0.1)")
+	samples := flag.Int("samples", 0, "random inputs used to find reachable predicates (default 5000)")
+	maxGaps := flag.Int("max-gaps", 0, "maximum number of coverage gaps listed (default 20)")
+	flag.Parse()
+
+	m, err := load(*rulesFile, *modelFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report := analisis.CheckRuleBase(m, analisis.RuleCheckOptions{
+		Threshold: *threshold,
+		Samples:   *samples,
+		MaxGaps:   *maxGaps,
+	})
+	fmt.Printf("%d rules, %d regions checked, %d coverage gaps\n", len(m.RuleBase.Rules), report.Regions, report.Gaps)
+	for _, issue := range report.Issues {
+		fmt.Printf("[%s] %s\n", issue.Kind, issue.Message)
+		for _, rule := range issue.Rules {
+			fmt.Printf("    %s\n", rule)
+		}
+	}
+	if !report.Valid() {
+		os.Exit(1)
+	}
+	fmt.Println("no problems found")
+}
+
+func load(rulesFile, modelFile string) (*model.Model, error) {
+	switch {
+	case rulesFile != "" && modelFile != "":
+		return nil, fmt.Errorf("use either -rules or -model, not both")
+	case rulesFile != "":
+		data, err := os.ReadFile(rulesFile)
+		if err != nil {
+			return nil, err
+		}
+		rb, err := rules.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		m := model.Default()
+		m.RuleBase = rb
+		return m, nil
+	case modelFile != "":
+		return fcl.LoadFile(modelFile)
+	}
+	return model.Default(), nil
+}
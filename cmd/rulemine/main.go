@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go-tsukamoto/internal/modules/fcl"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/pelatihan"
+	"log"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// rulemine menyusun basis aturan dari data lulusan berlabel dengan metode
+// Wang-Mendel. Himpunan diambil dari model sehingga berkas aturan yang
+// dihasilkan dapat ditinjau, disunting dan dimuat lewat FUZZY_RULES_FILE.
+//
+//	go run ./cmd/rulemine -data lulusan.csv                        # model bawaan
+//	go run ./cmd/rulemine -data lulusan.csv -model model.fcl       # himpunan dari model lain
+//	go run ./cmd/rulemine -data lulusan.csv -out aturan.yaml -min-support 3
+//
+// CSV berisi kolom nama setiap variabel (nilai tegas), kolom
+// "<variabel>_level" opsional dan kolom "predicate".
+func main() {
+	dataFile := flag.String("data", "", "labeled student records (CSV)")
+	modelFile := flag.String("model", "", "model whose linguistic terms partition the inputs (YAML/JSON, or FCL with the .fcl extension)")
+	outFile := flag.String("out", "", "output rule file (default stdout)")
+	minSupport := flag.Int("min-support", 0, "minimum number of records supporting a mined rule (default 1)")
+	flag.Parse()
+
+	if *dataFile == "" {
+		log.Fatal("-data is required")
+	}
+	m, err := load(*modelFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	file, err := os.Open(*dataFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	samples, err := pelatihan.ReadSamples(file, m)
+	file.Close()
+	if err != nil {
+		log.Fatalf("invalid data file: %v", err)
+	}
+
+	report, err := pelatihan.MineRules(m, samples, pelatihan.MiningOptions{MinSupport: *minSupport})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "# Basis aturan hasil metode Wang-Mendel dari %d data lulusan (%s).\n", report.Samples, filepath.Base(*dataFile))
+	fmt.Fprintln(&out, "# Setiap aturan diberi keterangan derajat, jumlah data pendukung dan")
+	fmt.Fprintln(&out, "# jumlah data dengan anteseden sama tetapi predikat lain. Tinjau sebelum")
+	fmt.Fprintln(&out, "# dipakai.")
+	fmt.Fprintln(&out)
+	doc := &yaml.Node{}
+	if err := doc.Encode(report.Spec); err != nil {
+		log.Fatal(err)
+	}
+	annotate(doc, report.Rules)
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		log.Fatal(err)
+	}
+	if *outFile == "" {
+		os.Stdout.Write(out.Bytes())
+	} else if err := os.WriteFile(*outFile, out.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d records, %d skipped (not covered by any term), %d rules mined\n", report.Samples, report.Skipped, len(report.Rules))
+	fmt.Fprintln(os.Stderr, "existing rules:")
+	for _, comparison := range report.Comparisons {
+		fmt.Fprintf(os.Stderr, "[%s] %d supporting, %d contradicting: %s\n", comparison.Status, comparison.Supporting, comparison.Contradicting, comparison.Rule)
+	}
+}
+
+// annotate menambahkan komentar statistik pada setiap aturan hasil
+// penambangan
+func annotate(doc *yaml.Node, mined []pelatihan.MinedRule) {
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "rules" {
+			continue
+		}
+		for j, item := range doc.Content[i+1].Content {
+			rule := mined[j]
+			item.HeadComment = fmt.Sprintf("derajat %.3f, %d pendukung, %d konflik", rule.Degree, rule.Support, rule.Conflicting)
+		}
+	}
+}
+
+func load(modelFile string) (*model.Model, error) {
+	if modelFile == "" {
+		return model.Default(), nil
+	}
+	return fcl.LoadFile(modelFile)
+}
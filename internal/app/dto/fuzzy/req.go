@@ -1,5 +1,91 @@
 package dto
 
+// FuzzyRequestDTO memilih mahasiswa; engine kosong berarti metode inferensi bawaan
 type FuzzyRequestDTO struct {
-	UserID int `json:"user_id" validate:"required"`
+	UserID     int                   `json:"user_id" validate:"required"`
+	Explain    bool                  `json:"explain"`
+	Engine     string                `json:"engine"`
+	MonteCarlo *MonteCarloRequestDTO `json:"monte_carlo"`
+}
+
+// WhatIfRequestDTO berisi masukan mentah untuk simulasi predikat
+type WhatIfRequestDTO struct {
+	IPK             float64               `json:"ipk"`
+	Semester        int                   `json:"semester"`
+	MataKuliahUlang int                   `json:"mata_kuliah_ulang"`
+	Prestasi        []AchievementInputDTO `json:"prestasi"`
+	Skripsi         *ThesisInputDTO       `json:"skripsi"`
+	JumlahAktivitas int                   `json:"jumlah_aktivitas"`
+	Engine          string                `json:"engine"`
+	MonteCarlo      *MonteCarloRequestDTO `json:"monte_carlo"`
+}
+
+// MonteCarloRequestDTO meminta estimasi ketidakpastian Monte Carlo. noise
+// menimpa derau bawaan per variabel (ipk seragam ±0.005, achievement normal
+// σ=1); spread 0 mematikan derau variabel tersebut. Nilai kosong lainnya
+// memakai bawaan (2000 sampel, batas tinjauan 0.8, seed 0).
+type MonteCarloRequestDTO struct {
+	Samples         int                 `json:"samples"`
+	Noise           map[string]NoiseDTO `json:"noise"`
+	ReviewThreshold float64             `json:"review_threshold"`
+	Seed            int64               `json:"seed"`
+}
+
+// NoiseDTO adalah distribusi derau satu variabel: "uniform" dengan
+// lebar ±spread atau "normal" dengan simpangan baku spread
+type NoiseDTO struct {
+	Distribution string  `json:"distribution"`
+	Spread       float64 `json:"spread"`
+}
+
+type AchievementInputDTO struct {
+	Level string `json:"level"`
+	Rank  int    `json:"rank"`
+}
+
+type ThesisInputDTO struct {
+	Level string `json:"level"`
+}
+
+// SensitivityRequestDTO memilih mahasiswa tersimpan (user_id) atau masukan mentah (input)
+type SensitivityRequestDTO struct {
+	UserID int               `json:"user_id"`
+	Input  *WhatIfRequestDTO `json:"input"`
+	Steps  int               `json:"steps"`
+}
+
+// CounterfactualRequestDTO memilih mahasiswa tersimpan (user_id) atau masukan
+// mentah (input); limit membatasi jumlah alternatif perubahan
+type CounterfactualRequestDTO struct {
+	UserID int               `json:"user_id"`
+	Input  *WhatIfRequestDTO `json:"input"`
+	Limit  int               `json:"limit"`
+}
+
+// ShadowRequestDTO memilih versi model kandidat (biasanya draf) yang
+// dibandingkan dengan model yang sedang dipublikasikan; versi diambil dari path
+type ShadowRequestDTO struct {
+	Version int    `json:"-"`
+	Engine  string `json:"engine"`
+}
+
+// TrainRequestDTO berisi lulusan berlabel untuk menyetel model. base_version
+// kosong berarti model yang sedang dipublikasikan; variables membatasi
+// variabel yang dilatih dan nilai kosong lainnya memakai bawaan (5 fold,
+// 1500 evaluasi per optimasi).
+type TrainRequestDTO struct {
+	BaseVersion    int                `json:"base_version"`
+	Samples        []LabeledSampleDTO `json:"samples"`
+	Folds          int                `json:"folds"`
+	MaxEvaluations int                `json:"max_evaluations"`
+	Variables      []string           `json:"variables"`
+	Seed           int64              `json:"seed"`
+	Engine         string             `json:"engine"`
+	Description    string             `json:"description"`
+}
+
+// LabeledSampleDTO adalah masukan mentah satu lulusan beserta predikat resminya
+type LabeledSampleDTO struct {
+	WhatIfRequestDTO
+	Predicate string `json:"predicate"`
 }
@@ -1,14 +1,212 @@
 package dto
 
+import fuzzyModelDto "go-tsukamoto/internal/app/dto/fuzzymodel"
+
 type FuzzyResponseDTO struct {
-	StudentID       int     `json:"student_id"`
-	IPK             float64 `json:"ipk"`
-	Semester        int     `json:"semester"`
-	MataKuliahUlang int     `json:"mata_kuliah_ulang"`
-	PrestasiLevel   string  `json:"prestasi_level"`
-	PrestasiRank    int     `json:"prestasi_rank"`
-	SkripsiLevel    string  `json:"skripsi_level"`
-	SkripsiImpact   float64 `json:"skripsi_impact"`
-	JumlahAktivitas int     `json:"jumlah_aktivitas"`
-	HasilPredicate  string  `json:"hasil_predicate"`
+	StudentID       int             `json:"student_id"`
+	IPK             float64         `json:"ipk"`
+	Semester        int             `json:"semester"`
+	MataKuliahUlang int             `json:"mata_kuliah_ulang"`
+	PrestasiLevel   string          `json:"prestasi_level"`
+	PrestasiRank    int             `json:"prestasi_rank"`
+	SkripsiLevel    string          `json:"skripsi_level"`
+	SkripsiImpact   float64         `json:"skripsi_impact"`
+	JumlahAktivitas int             `json:"jumlah_aktivitas"`
+	PrestasiIndex   int             `json:"-"` // indeks prestasi terbaik pada data masukan
+	HasilPredicate  string          `json:"hasil_predicate"`
+	Engine          string          `json:"engine,omitempty"`
+	Uncertainty     *UncertaintyDTO `json:"uncertainty,omitempty"`
+	MonteCarlo      *MonteCarloDTO  `json:"monte_carlo,omitempty"`
+	Explanation     *ExplanationDTO `json:"explanation,omitempty"`
+}
+
+// InputProblemDTO adalah satu pelanggaran domain pada masukan fuzzy. min dan
+// max terisi untuk nilai di luar semesta variabel, levels untuk level yang
+// tidak dikenal.
+type InputProblemDTO struct {
+	Field    string   `json:"field"`
+	Variable string   `json:"variable"`
+	Message  string   `json:"message"`
+	Value    *float64 `json:"value,omitempty"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	Level    string   `json:"level,omitempty"`
+	Levels   []string `json:"levels,omitempty"`
+}
+
+// MonteCarloDTO adalah peluang setiap predikat dari masukan berderau.
+// needs_review menandai mahasiswa yang peluang predikat terkuatnya di bawah
+// review_threshold sehingga perlu ditinjau manual oleh komisi.
+type MonteCarloDTO struct {
+	Samples         int                `json:"samples"`
+	Probabilities   map[string]float64 `json:"probabilities"`
+	MostLikely      string             `json:"most_likely"`
+	Probability     float64            `json:"probability"`
+	ScoreMean       float64            `json:"score_mean"`
+	ScoreStdDev     float64            `json:"score_std_dev"`
+	ReviewThreshold float64            `json:"review_threshold"`
+	NeedsReview     bool               `json:"needs_review"`
+}
+
+// UncertaintyDTO adalah interval skor hasil reduksi tipe Karnik-Mendel pada
+// model interval tipe-2 beserta predikat di kedua ujungnya
+type UncertaintyDTO struct {
+	Score          float64 `json:"score"`
+	Lower          float64 `json:"lower"`
+	Upper          float64 `json:"upper"`
+	LowerPredicate string  `json:"lower_predicate"`
+	UpperPredicate string  `json:"upper_predicate"`
+}
+
+// ExplanationDTO adalah jejak perhitungan yang menjelaskan predikat. Pada
+// model interval tipe-2 memberships berisi derajat fungsi atas,
+// lower_memberships derajat fungsi bawah dan firing setiap aturan berisi
+// interval penyulutan [bawah, atas].
+type ExplanationDTO struct {
+	ModelVersion     int                           `json:"model_version"`
+	Engine           string                        `json:"engine"`
+	Defuzzifier      string                        `json:"defuzzifier"`
+	Inputs           map[string]float64            `json:"inputs"`
+	Memberships      map[string]map[string]float64 `json:"memberships"`
+	LowerMemberships map[string]map[string]float64 `json:"lower_memberships,omitempty"`
+	Rules            []RuleTraceDTO                `json:"rules"`
+	Score            float64                       `json:"score"`
+	Band             BandDTO                       `json:"band"`
+}
+
+type RuleTraceDTO struct {
+	Rule       string      `json:"rule"`
+	Predicate  string      `json:"predicate"`
+	Strength   float64     `json:"strength"`
+	Output     float64     `json:"output"`
+	Normalized float64     `json:"normalized"`
+	Firing     *[2]float64 `json:"firing,omitempty"`
+}
+
+// BandDTO adalah rentang skor [min_score, max_score) yang memetakan skor ke
+// predikat; max_score kosong untuk predikat tertinggi
+type BandDTO struct {
+	Predicate string   `json:"predicate"`
+	MinScore  float64  `json:"min_score"`
+	MaxScore  *float64 `json:"max_score,omitempty"`
+}
+
+type SensitivityResponseDTO struct {
+	StudentID      int        `json:"student_id,omitempty"`
+	HasilPredicate string     `json:"hasil_predicate"`
+	Score          float64    `json:"score"`
+	Variables      []SweepDTO `json:"variables"`
+}
+
+// SweepDTO adalah kurva skor satu variabel; impact adalah selisih skor
+// tertinggi dan terendah sepanjang kurva
+type SweepDTO struct {
+	Variable    string          `json:"variable"`
+	Current     float64         `json:"current"`
+	Min         float64         `json:"min"`
+	Max         float64         `json:"max"`
+	Impact      float64         `json:"impact"`
+	Points      []PointDTO      `json:"points"`
+	Breakpoints []BreakpointDTO `json:"breakpoints"`
+}
+
+type PointDTO struct {
+	Value     float64 `json:"value"`
+	Score     float64 `json:"score"`
+	Predicate string  `json:"predicate"`
+}
+
+type BreakpointDTO struct {
+	Value       float64 `json:"value"`
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+	Description string  `json:"description"`
+}
+
+// CounterfactualResponseDTO memuat alternatif perubahan termurah untuk naik
+// ke target_predicate; options kosong jika sudah berada pada predikat tertinggi
+type CounterfactualResponseDTO struct {
+	StudentID       int                 `json:"student_id,omitempty"`
+	HasilPredicate  string              `json:"hasil_predicate"`
+	Score           float64             `json:"score"`
+	TargetPredicate string              `json:"target_predicate,omitempty"`
+	Options         []CounterfactualDTO `json:"options"`
+}
+
+type CounterfactualDTO struct {
+	Effort    float64     `json:"effort"`
+	Score     float64     `json:"score"`
+	Predicate string      `json:"predicate"`
+	Changes   []ChangeDTO `json:"changes"`
+}
+
+type ChangeDTO struct {
+	Variable    string  `json:"variable"`
+	From        float64 `json:"from"`
+	To          float64 `json:"to"`
+	FromLevel   string  `json:"from_level,omitempty"`
+	ToLevel     string  `json:"to_level,omitempty"`
+	Description string  `json:"description"`
+}
+
+// ShadowResponseDTO adalah dampak model kandidat terhadap seluruh mahasiswa.
+// matrix[lama][baru] adalah jumlah mahasiswa untuk setiap pasangan predikat
+// model yang sedang dipakai dan model kandidat; predicates memberi urutannya.
+// Mahasiswa pada invalid tidak dihitung pada total maupun matriks.
+type ShadowResponseDTO struct {
+	CurrentVersion   int                       `json:"current_version"`
+	CandidateVersion int                       `json:"candidate_version"`
+	Engine           string                    `json:"engine"`
+	Total            int                       `json:"total"`
+	Changed          int                       `json:"changed"`
+	Upgraded         int                       `json:"upgraded"`
+	Downgraded       int                       `json:"downgraded"`
+	Predicates       []string                  `json:"predicates"`
+	Matrix           map[string]map[string]int `json:"matrix"`
+	Affected         []AffectedStudentDTO      `json:"affected"`
+	Invalid          []InvalidStudentDTO       `json:"invalid"`
+}
+
+// InvalidStudentDTO adalah mahasiswa yang dilewati evaluasi bayangan karena
+// datanya berada di luar domain salah satu model
+type InvalidStudentDTO struct {
+	StudentID int               `json:"student_id"`
+	Problems  []InputProblemDTO `json:"problems"`
+}
+
+type AffectedStudentDTO struct {
+	StudentID int     `json:"student_id"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	FromScore float64 `json:"from_score"`
+	ToScore   float64 `json:"to_score"`
+}
+
+// TrainResponseDTO memuat draf hasil pelatihan beserta akurasinya. Akurasi
+// baseline adalah akurasi model awal pada data yang sama.
+type TrainResponseDTO struct {
+	Draft            *fuzzyModelDto.FuzzyModelResponse `json:"draft"`
+	BaseVersion      int                               `json:"base_version"`
+	Samples          int                               `json:"samples"`
+	Parameters       int                               `json:"parameters"`
+	Evaluations      int                               `json:"evaluations"`
+	BaselineAccuracy float64                           `json:"baseline_accuracy"`
+	TrainAccuracy    float64                           `json:"train_accuracy"`
+	CrossValidation  CrossValidationDTO                `json:"cross_validation"`
+}
+
+// CrossValidationDTO adalah hasil k-fold cross-validation; accuracy dan
+// baseline_accuracy adalah rata-rata akurasi uji seluruh fold
+type CrossValidationDTO struct {
+	Accuracy         float64   `json:"accuracy"`
+	BaselineAccuracy float64   `json:"baseline_accuracy"`
+	Folds            []FoldDTO `json:"folds"`
+}
+
+type FoldDTO struct {
+	Train            int     `json:"train"`
+	Test             int     `json:"test"`
+	TrainAccuracy    float64 `json:"train_accuracy"`
+	TestAccuracy     float64 `json:"test_accuracy"`
+	BaselineAccuracy float64 `json:"baseline_accuracy"`
 }
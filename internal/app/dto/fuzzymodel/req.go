@@ -0,0 +1,18 @@
+package fuzzymodel
+
+import "go-tsukamoto/internal/modules/model"
+
+// FuzzyModelRequest membuat atau mengubah draf model. Definition kosong pada
+// pembuatan draf berarti menyalin model yang sedang dipublikasikan.
+type FuzzyModelRequest struct {
+	Description string            `json:"description"`
+	Definition  *model.Definition `json:"definition"`
+}
+
+// RuleCheckRequest mengatur pemeriksaan basis aturan; nilai kosong memakai
+// bawaan (ambang 0.1, 5000 sampel acak, 20 contoh celah)
+type RuleCheckRequest struct {
+	Threshold float64 `json:"threshold"`
+	Samples   int     `json:"samples"`
+	MaxGaps   int     `json:"max_gaps"`
+}
@@ -0,0 +1,45 @@
+package fuzzymodel
+
+import (
+	"go-tsukamoto/internal/modules/model"
+	"time"
+)
+
+type FuzzyModelResponse struct {
+	Version     int               `json:"version"`
+	Status      string            `json:"status"`
+	Description string            `json:"description"`
+	PublishedAt *time.Time        `json:"published_at,omitempty"`
+	Definition  *model.Definition `json:"definition,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type ValidationResponse struct {
+	Version int      `json:"version"`
+	Valid   bool     `json:"valid"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+type RuleCheckResponse struct {
+	Version int            `json:"version"`
+	Valid   bool           `json:"valid"`
+	Regions int            `json:"regions"`
+	Gaps    int            `json:"gaps"`
+	Issues  []RuleIssueDTO `json:"issues"`
+}
+
+// RuleIssueDTO adalah satu temuan pemeriksaan basis aturan. Kind berisi
+// coverage_gap, conflict, unreachable_predicate atau unused_term.
+type RuleIssueDTO struct {
+	Kind      string             `json:"kind"`
+	Message   string             `json:"message"`
+	Region    map[string]string  `json:"region,omitempty"`
+	Input     map[string]float64 `json:"input,omitempty"`
+	Levels    map[string]string  `json:"levels,omitempty"`
+	Strength  float64            `json:"strength,omitempty"`
+	Rules     []string           `json:"rules,omitempty"`
+	Predicate string             `json:"predicate,omitempty"`
+	Variable  string             `json:"variable,omitempty"`
+	Term      string             `json:"term,omitempty"`
+}
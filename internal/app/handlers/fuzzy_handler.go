@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 
 	dto "go-tsukamoto/internal/app/dto/fuzzy"
 	service "go-tsukamoto/internal/app/service/fuzzy"
+	"go-tsukamoto/internal/modules/analisis"
+	"go-tsukamoto/internal/modules/inferensia"
 	"go-tsukamoto/utils"
 	"net/http"
+	"strconv"
 )
 
 type FuzzyHandler struct {
@@ -24,11 +28,167 @@ func (h *FuzzyHandler) CalculateFuzzy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.service.CalculateFuzzy(r.Context(), req.UserID)
+	// Jejak perhitungan, metode inferensi dan estimasi Monte Carlo dapat
+	// dipilih lewat body atau query ?explain=true&engine=mamdani&monte_carlo=true
+	if value := r.URL.Query().Get("explain"); value != "" {
+		req.Explain, _ = strconv.ParseBool(value)
+	}
+	if value := r.URL.Query().Get("engine"); value != "" {
+		req.Engine = value
+	}
+	if enabled, _ := strconv.ParseBool(r.URL.Query().Get("monte_carlo")); enabled && req.MonteCarlo == nil {
+		req.MonteCarlo = &dto.MonteCarloRequestDTO{}
+	}
+
+	resp, err := h.service.CalculateFuzzy(r.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(w, http.StatusInternalServerError, err.Error(), nil)
+		fuzzyError(w, err)
 		return
 	}
 
 	utils.SuccessResponse(w, http.StatusOK, "Fuzzy calculation successful", resp)
 }
+
+func (h *FuzzyHandler) WhatIf(w http.ResponseWriter, r *http.Request) {
+	var req dto.WhatIfRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	if value := r.URL.Query().Get("engine"); value != "" {
+		req.Engine = value
+	}
+	if enabled, _ := strconv.ParseBool(r.URL.Query().Get("monte_carlo")); enabled && req.MonteCarlo == nil {
+		req.MonteCarlo = &dto.MonteCarloRequestDTO{}
+	}
+
+	resp, err := h.service.WhatIf(r.Context(), &req)
+	if err != nil {
+		fuzzyError(w, err)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "Fuzzy simulation successful", resp)
+}
+
+func (h *FuzzyHandler) Sensitivity(w http.ResponseWriter, r *http.Request) {
+	var req dto.SensitivityRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	resp, err := h.service.Sensitivity(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, service.ErrMissingInput) {
+			utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if invalidInputResponse(w, err) {
+			return
+		}
+		utils.ErrorResponse(w, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "Sensitivity analysis successful", resp)
+}
+
+func (h *FuzzyHandler) Counterfactuals(w http.ResponseWriter, r *http.Request) {
+	var req dto.CounterfactualRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	resp, err := h.service.Counterfactuals(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, service.ErrMissingInput) {
+			utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if invalidInputResponse(w, err) {
+			return
+		}
+		utils.ErrorResponse(w, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "Counterfactual search successful", resp)
+}
+
+// Shadow membandingkan versi model pada path dengan model yang sedang
+// dipublikasikan atas seluruh mahasiswa tanpa menyimpan hasil
+func (h *FuzzyHandler) Shadow(w http.ResponseWriter, r *http.Request) {
+	var req dto.ShadowRequestDTO
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+	}
+	if value := r.URL.Query().Get("engine"); value != "" {
+		req.Engine = value
+	}
+	version, ok := modelVersion(w, r)
+	if !ok {
+		return
+	}
+	req.Version = version
+
+	resp, err := h.service.Shadow(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, inferensia.ErrUnknownEngine) {
+			utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		fuzzyModelError(w, err)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusOK, "Shadow evaluation successful", resp)
+}
+
+// Train menyetel model dari lulusan berlabel dan menyimpan hasilnya sebagai draf
+func (h *FuzzyHandler) Train(w http.ResponseWriter, r *http.Request) {
+	var req dto.TrainRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	resp, err := h.service.Train(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, inferensia.ErrUnknownEngine) || errors.Is(err, service.ErrInvalidTrainingData) {
+			utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		fuzzyModelError(w, err)
+		return
+	}
+
+	utils.SuccessResponse(w, http.StatusCreated, "Fuzzy model trained successfully", resp)
+}
+
+func fuzzyError(w http.ResponseWriter, err error) {
+	if invalidInputResponse(w, err) {
+		return
+	}
+	if errors.Is(err, inferensia.ErrUnknownEngine) || errors.Is(err, analisis.ErrInvalidNoise) {
+		utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	utils.ErrorResponse(w, http.StatusInternalServerError, err.Error(), nil)
+}
+
+// invalidInputResponse mengirim 422 beserta rincian per field jika masukan
+// fuzzy berada di luar domain model
+func invalidInputResponse(w http.ResponseWriter, err error) bool {
+	var invalid *service.InvalidInputError
+	if !errors.As(err, &invalid) {
+		return false
+	}
+	utils.ValidationErrorResponse(w, "Fuzzy input is invalid", invalid.Problems)
+	return true
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	dto "go-tsukamoto/internal/app/dto/fuzzymodel"
+	service "go-tsukamoto/internal/app/service/fuzzymodel"
+	"go-tsukamoto/utils"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type FuzzyModelHandler struct {
+	service service.FuzzyModelServiceInterface
+}
+
+func NewFuzzyModelHandler(service service.FuzzyModelServiceInterface) *FuzzyModelHandler {
+	return &FuzzyModelHandler{service: service}
+}
+
+func (h *FuzzyModelHandler) GetModels(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetModels(r.Context())
+	if err != nil {
+		utils.ServerErrorResponse(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusOK, "Fuzzy models retrieved successfully", resp)
+}
+
+func (h *FuzzyModelHandler) GetModel(w http.ResponseWriter, r *http.Request) {
+	version, ok := modelVersion(w, r)
+	if !ok {
+		return
+	}
+	resp, err := h.service.GetModel(r.Context(), version)
+	if err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusOK, "Fuzzy model retrieved successfully", resp)
+}
+
+func (h *FuzzyModelHandler) CreateDraft(w http.ResponseWriter, r *http.Request) {
+	var req dto.FuzzyModelRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+	}
+	resp, err := h.service.CreateDraft(r.Context(), &req)
+	if err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusCreated, "Fuzzy model draft created successfully", resp)
+}
+
+func (h *FuzzyModelHandler) UpdateDraft(w http.ResponseWriter, r *http.Request) {
+	var req dto.FuzzyModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	version, ok := modelVersion(w, r)
+	if !ok {
+		return
+	}
+	resp, err := h.service.UpdateDraft(r.Context(), version, &req)
+	if err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusOK, "Fuzzy model draft updated successfully", resp)
+}
+
+func (h *FuzzyModelHandler) DeleteDraft(w http.ResponseWriter, r *http.Request) {
+	version, ok := modelVersion(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteDraft(r.Context(), version); err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusNoContent, "Fuzzy model draft deleted successfully", nil)
+}
+
+func (h *FuzzyModelHandler) ValidateModel(w http.ResponseWriter, r *http.Request) {
+	version, ok := modelVersion(w, r)
+	if !ok {
+		return
+	}
+	resp, err := h.service.ValidateModel(r.Context(), version)
+	if err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusOK, "Fuzzy model validated", resp)
+}
+
+func (h *FuzzyModelHandler) CheckRules(w http.ResponseWriter, r *http.Request) {
+	var req dto.RuleCheckRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+	}
+	version, ok := modelVersion(w, r)
+	if !ok {
+		return
+	}
+	resp, err := h.service.CheckRules(r.Context(), version, &req)
+	if err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusOK, "Fuzzy rule base checked", resp)
+}
+
+// ExportFCL mengunduh sebuah versi model sebagai berkas FCL
+func (h *FuzzyModelHandler) ExportFCL(w http.ResponseWriter, r *http.Request) {
+	version, ok := modelVersion(w, r)
+	if !ok {
+		return
+	}
+	data, err := h.service.ExportFCL(r.Context(), version)
+	if err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=model-v%d.fcl", version))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// ImportFCL membuat draf baru dari berkas FCL pada body permintaan;
+// keterangan draf diambil dari parameter query description
+func (h *FuzzyModelHandler) ImportFCL(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	resp, err := h.service.ImportFCL(r.Context(), data, r.URL.Query().Get("description"))
+	if err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusCreated, "Fuzzy model draft imported successfully", resp)
+}
+
+func (h *FuzzyModelHandler) PublishModel(w http.ResponseWriter, r *http.Request) {
+	version, ok := modelVersion(w, r)
+	if !ok {
+		return
+	}
+	resp, err := h.service.PublishModel(r.Context(), version)
+	if err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusOK, "Fuzzy model published successfully", resp)
+}
+
+func (h *FuzzyModelHandler) RollbackModel(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.RollbackModel(r.Context())
+	if err != nil {
+		fuzzyModelError(w, err)
+		return
+	}
+	utils.SuccessResponse(w, http.StatusOK, "Fuzzy model rolled back successfully", resp)
+}
+
+func modelVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	version, err := strconv.Atoi(mux.Vars(r)["version"])
+	if err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid fuzzy model version", nil)
+		return 0, false
+	}
+	return version, true
+}
+
+func fuzzyModelError(w http.ResponseWriter, err error) {
+	var invalid *service.InvalidModelError
+	switch {
+	case errors.Is(err, service.ErrModelNotFound):
+		utils.NotFoundResponse(w, "Fuzzy model not found")
+	case errors.Is(err, service.ErrModelNotDraft), errors.Is(err, service.ErrNoPreviousVersion), errors.Is(err, service.ErrVersionConflict):
+		utils.ErrorResponse(w, http.StatusConflict, err.Error(), nil)
+	case errors.As(err, &invalid):
+		utils.ValidationErrorResponse(w, "Fuzzy model is invalid", invalid.Problems)
+	default:
+		utils.ServerErrorResponse(w, err)
+	}
+}
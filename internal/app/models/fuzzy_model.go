@@ -0,0 +1,171 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type FuzzyModelStatus string
+
+const (
+	FuzzyModelDraft     FuzzyModelStatus = "draft"
+	FuzzyModelPublished FuzzyModelStatus = "published"
+	FuzzyModelArchived  FuzzyModelStatus = "archived"
+)
+
+func (s *FuzzyModelStatus) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		*s = FuzzyModelStatus(v)
+	case string:
+		*s = FuzzyModelStatus(v)
+	default:
+		return errors.New("invalid type for FuzzyModelStatus")
+	}
+	return nil
+}
+
+func (s FuzzyModelStatus) Value() (driver.Value, error) {
+	return string(s), nil
+}
+
+// Float64List disimpan sebagai array JSON
+type Float64List []float64
+
+func (l *Float64List) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
+
+func (l Float64List) Value() (driver.Value, error) {
+	return valueJSON(l)
+}
+
+// StringList disimpan sebagai array JSON
+type StringList []string
+
+func (l *StringList) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
+
+func (l StringList) Value() (driver.Value, error) {
+	return valueJSON(l)
+}
+
+// SugenoOutput adalah konsekuen Sugeno sebuah aturan, disimpan sebagai objek JSON
+type SugenoOutput struct {
+	Constant     float64            `json:"constant"`
+	Coefficients map[string]float64 `json:"coefficients,omitempty"`
+}
+
+func (o *SugenoOutput) Scan(value interface{}) error {
+	return scanJSON(value, o)
+}
+
+func (o SugenoOutput) Value() (driver.Value, error) {
+	return valueJSON(o)
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return errors.New("invalid type for JSON list")
+	}
+}
+
+func valueJSON(v interface{}) (driver.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// FuzzyModel adalah satu versi model fuzzy. Versi yang sudah dipublikasikan
+// tidak diubah lagi; perubahan selalu dibuat sebagai draf versi baru.
+type FuzzyModel struct {
+	ID                int              `gorm:"primaryKey;autoIncrement;uniqueIndex;not null"`
+	Version           int              `gorm:"not null;uniqueIndex"`
+	Status            FuzzyModelStatus `gorm:"not null;type:text;index"`
+	Description       string           `gorm:"size:255"`
+	FallbackPredicate string           `gorm:"size:50"`
+	FallbackStrength  float64
+	FallbackOutput    *SugenoOutput `gorm:"type:text"`
+	Defuzzifier       string        `gorm:"size:20"`
+	AndOperator       string        `gorm:"size:30"`
+	OrOperator        string        `gorm:"size:30"`
+	PublishedAt       *time.Time
+	Variables         []FuzzyVariable `gorm:"foreignKey:FuzzyModelID;constraint:OnDelete:CASCADE"`
+	Rules             []FuzzyRule     `gorm:"foreignKey:FuzzyModelID;constraint:OnDelete:CASCADE"`
+	Outputs           []FuzzyOutput   `gorm:"foreignKey:FuzzyModelID;constraint:OnDelete:CASCADE"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (m *FuzzyModel) BeforeSave(tx *gorm.DB) (err error) {
+	switch m.Status {
+	case FuzzyModelDraft, FuzzyModelPublished, FuzzyModelArchived:
+		// valid status
+	default:
+		err = errors.New("invalid fuzzy model status")
+	}
+	return
+}
+
+// FuzzyVariable adalah satu variabel masukan; OpenMax menandai batas atas
+// semesta yang terbuka
+type FuzzyVariable struct {
+	ID           int         `gorm:"primaryKey;autoIncrement;uniqueIndex;not null"`
+	FuzzyModelID int         `gorm:"not null;index"`
+	Position     int         `gorm:"not null"`
+	Name         string      `gorm:"size:50;not null"`
+	Min          float64     `gorm:"not null"`
+	Max          float64     `gorm:"not null"`
+	OpenMax      bool        `gorm:"not null;default:false"`
+	Weight       float64     `gorm:"not null"`
+	Terms        []FuzzyTerm `gorm:"foreignKey:FuzzyVariableID;constraint:OnDelete:CASCADE"`
+}
+
+// FuzzyTerm adalah satu himpunan fuzzy. LowerType dan LowerParams berisi
+// fungsi keanggotaan bawah himpunan interval tipe-2; kosong untuk tipe-1.
+type FuzzyTerm struct {
+	ID              int         `gorm:"primaryKey;autoIncrement;uniqueIndex;not null"`
+	FuzzyVariableID int         `gorm:"not null;index"`
+	Position        int         `gorm:"not null"`
+	Name            string      `gorm:"size:50;not null"`
+	Type            string      `gorm:"size:20;not null"`
+	Params          Float64List `gorm:"type:text;not null"`
+	LowerType       string      `gorm:"size:20"`
+	LowerParams     Float64List `gorm:"type:text"`
+	Levels          StringList  `gorm:"type:text"`
+}
+
+type FuzzyRule struct {
+	ID           int           `gorm:"primaryKey;autoIncrement;uniqueIndex;not null"`
+	FuzzyModelID int           `gorm:"not null;index"`
+	Position     int           `gorm:"not null"`
+	Rule         string        `gorm:"type:text;not null"`
+	When         string        `gorm:"type:text"`
+	Output       *SugenoOutput `gorm:"type:text"`
+}
+
+type FuzzyOutput struct {
+	ID           int     `gorm:"primaryKey;autoIncrement;uniqueIndex;not null"`
+	FuzzyModelID int     `gorm:"not null;index"`
+	Position     int     `gorm:"not null"`
+	Predicate    string  `gorm:"size:50;not null"`
+	Low          float64 `gorm:"not null"`
+	High         float64 `gorm:"not null"`
+	Increasing   bool    `gorm:"not null"`
+	MinScore     float64 `gorm:"not null"`
+	MaxScore     float64
+}
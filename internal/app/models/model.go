@@ -9,5 +9,10 @@ func GetModelsToMigrate() []interface{} {
 		&Thesis{},
 		&Predicate{},
 		&Course{},
+		&FuzzyModel{},
+		&FuzzyVariable{},
+		&FuzzyTerm{},
+		&FuzzyRule{},
+		&FuzzyOutput{},
 	}
 }
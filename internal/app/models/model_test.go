@@ -14,6 +14,11 @@ func TestGetModelsToMigrate(t *testing.T) {
 		&Thesis{},
 		&Predicate{},
 		&Course{},
+		&FuzzyModel{},
+		&FuzzyVariable{},
+		&FuzzyTerm{},
+		&FuzzyRule{},
+		&FuzzyOutput{},
 	}
 
 	models := GetModelsToMigrate()
@@ -0,0 +1,135 @@
+package fuzzymodel
+
+import (
+	"context"
+	"errors"
+	"go-tsukamoto/internal/app/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// uniqueViolation adalah kode galat Postgres untuk pelanggaran indeks unik
+const uniqueViolation = "23505"
+
+// ErrVersionExists dikembalikan CreateModel jika nomor versi sudah dipakai,
+// misalnya oleh draf lain yang dibuat bersamaan
+var ErrVersionExists = errors.New("fuzzy model version already exists")
+
+type fuzzyModelRepository struct {
+	db *gorm.DB
+}
+
+func NewFuzzyModelRepository(db *gorm.DB) FuzzyModelRepositoryInterface {
+	return &fuzzyModelRepository{db: db}
+}
+
+// withDefinition memuat variabel, himpunan, aturan dan keluaran sesuai urutannya
+func withDefinition(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Variables", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Variables.Terms", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Rules", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Outputs", func(db *gorm.DB) *gorm.DB { return db.Order("position") })
+}
+
+func (r *fuzzyModelRepository) CreateModel(ctx context.Context, model *models.FuzzyModel) error {
+	err := r.db.WithContext(ctx).Create(model).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrVersionExists
+	}
+	return err
+}
+
+func (r *fuzzyModelRepository) GetModelByVersion(ctx context.Context, version int) (*models.FuzzyModel, error) {
+	var model models.FuzzyModel
+	if err := withDefinition(r.db.WithContext(ctx)).Where("version = ?", version).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &model, nil
+}
+
+func (r *fuzzyModelRepository) GetModels(ctx context.Context) ([]*models.FuzzyModel, error) {
+	var fuzzyModels []*models.FuzzyModel
+	if err := r.db.WithContext(ctx).Order("version").Find(&fuzzyModels).Error; err != nil {
+		return nil, err
+	}
+	return fuzzyModels, nil
+}
+
+func (r *fuzzyModelRepository) GetPublishedModel(ctx context.Context) (*models.FuzzyModel, error) {
+	var model models.FuzzyModel
+	if err := withDefinition(r.db.WithContext(ctx)).Where("status = ?", models.FuzzyModelPublished).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &model, nil
+}
+
+func (r *fuzzyModelRepository) GetLatestVersion(ctx context.Context) (int, error) {
+	var version int
+	if err := r.db.WithContext(ctx).Model(&models.FuzzyModel{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// ReplaceModel menyimpan model beserta seluruh definisinya, menggantikan
+// variabel, himpunan, aturan dan keluaran yang lama
+func (r *fuzzyModelRepository) ReplaceModel(ctx context.Context, model *models.FuzzyModel) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deleteDefinition(tx, model.ID); err != nil {
+			return err
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(model).Error
+	})
+}
+
+func (r *fuzzyModelRepository) DeleteModel(ctx context.Context, version int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model models.FuzzyModel
+		if err := tx.Where("version = ?", version).First(&model).Error; err != nil {
+			return err
+		}
+		if err := deleteDefinition(tx, model.ID); err != nil {
+			return err
+		}
+		return tx.Delete(&model).Error
+	})
+}
+
+// PublishModel mempublikasikan versi tertentu dan mengarsipkan versi yang
+// sebelumnya dipublikasikan dalam satu transaksi
+func (r *fuzzyModelRepository) PublishModel(ctx context.Context, version int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.FuzzyModel{}).
+			Where("status = ? AND version <> ?", models.FuzzyModelPublished, version).
+			Update("status", models.FuzzyModelArchived).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		return tx.Model(&models.FuzzyModel{}).
+			Where("version = ?", version).
+			Updates(map[string]interface{}{"status": models.FuzzyModelPublished, "published_at": now, "updated_at": now}).Error
+	})
+}
+
+func deleteDefinition(tx *gorm.DB, modelID int) error {
+	variableIDs := tx.Model(&models.FuzzyVariable{}).Select("id").Where("fuzzy_model_id = ?", modelID)
+	if err := tx.Where("fuzzy_variable_id IN (?)", variableIDs).Delete(&models.FuzzyTerm{}).Error; err != nil {
+		return err
+	}
+	for _, child := range []interface{}{&models.FuzzyVariable{}, &models.FuzzyRule{}, &models.FuzzyOutput{}} {
+		if err := tx.Where("fuzzy_model_id = ?", modelID).Delete(child).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
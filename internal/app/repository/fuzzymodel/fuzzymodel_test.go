@@ -0,0 +1,73 @@
+package fuzzymodel_test
+
+import (
+	"context"
+	"errors"
+	"go-tsukamoto/internal/app/models"
+	"go-tsukamoto/internal/app/repository/fuzzymodel"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := fuzzymodel.NewMockFuzzyModelRepositoryInterface(ctrl)
+	model := &models.FuzzyModel{Version: 1, Status: models.FuzzyModelDraft}
+	mockRepo.EXPECT().CreateModel(gomock.Any(), model).Return(nil)
+
+	err := mockRepo.CreateModel(context.Background(), model)
+	assert.NoError(t, err)
+}
+
+func TestGetModelByVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := fuzzymodel.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetModelByVersion(gomock.Any(), 2).Return(&models.FuzzyModel{ID: 1, Version: 2}, nil)
+
+	model, err := mockRepo.GetModelByVersion(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.NotNil(t, model)
+	assert.Equal(t, 2, model.Version)
+}
+
+func TestGetModelByVersion_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := fuzzymodel.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetModelByVersion(gomock.Any(), 2).Return(nil, nil)
+
+	model, err := mockRepo.GetModelByVersion(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Nil(t, model)
+}
+
+func TestGetPublishedModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := fuzzymodel.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetPublishedModel(gomock.Any()).Return(&models.FuzzyModel{Version: 3, Status: models.FuzzyModelPublished}, nil)
+
+	model, err := mockRepo.GetPublishedModel(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, models.FuzzyModelPublished, model.Status)
+}
+
+func TestPublishModel_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := fuzzymodel.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockRepo.EXPECT().PublishModel(gomock.Any(), 1).Return(errors.New("unexpected error"))
+
+	err := mockRepo.PublishModel(context.Background(), 1)
+	assert.Error(t, err)
+	assert.Equal(t, "unexpected error", err.Error())
+}
@@ -0,0 +1,17 @@
+package fuzzymodel
+
+import (
+	"context"
+	"go-tsukamoto/internal/app/models"
+)
+
+type FuzzyModelRepositoryInterface interface {
+	CreateModel(ctx context.Context, model *models.FuzzyModel) error
+	GetModelByVersion(ctx context.Context, version int) (*models.FuzzyModel, error)
+	GetModels(ctx context.Context) ([]*models.FuzzyModel, error)
+	GetPublishedModel(ctx context.Context) (*models.FuzzyModel, error)
+	GetLatestVersion(ctx context.Context) (int, error)
+	ReplaceModel(ctx context.Context, model *models.FuzzyModel) error
+	DeleteModel(ctx context.Context, version int) error
+	PublishModel(ctx context.Context, version int) error
+}
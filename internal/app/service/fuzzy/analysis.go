@@ -0,0 +1,216 @@
+package fuzzy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	dto "go-tsukamoto/internal/app/dto/fuzzy"
+	"go-tsukamoto/internal/modules/analisis"
+	"go-tsukamoto/internal/modules/inferensia"
+)
+
+var ErrMissingInput = errors.New("either user_id or input is required")
+
+func (s *FuzzyService) Sensitivity(ctx context.Context, req *dto.SensitivityRequestDTO) (*dto.SensitivityResponseDTO, error) {
+	input, err := s.resolveInput(ctx, req.UserID, req.Input)
+	if err != nil {
+		return nil, err
+	}
+	fuzzyModel, err := s.modelService.GetPublishedModel(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading fuzzy model: %v", err)
+	}
+	if err := validateInput(fuzzyModel, input); err != nil {
+		return nil, err
+	}
+
+	in := analysisInput(input)
+	trace := inferensia.Evaluate(fuzzyModel, in.Crisp, in.Levels)
+	sweeps := analisis.Sensitivity(fuzzyModel, in, analisis.SensitivityOptions{
+		Steps:    req.Steps,
+		Discrete: analisis.DiscreteVariables,
+	})
+
+	response := &dto.SensitivityResponseDTO{
+		StudentID:      input.StudentID,
+		HasilPredicate: trace.Predicate,
+		Score:          trace.Score,
+	}
+	for _, sweep := range sweeps {
+		sweepDTO := dto.SweepDTO{
+			Variable:    sweep.Variable,
+			Current:     sweep.Current,
+			Min:         sweep.Min,
+			Max:         sweep.Max,
+			Impact:      sweep.Impact(),
+			Points:      []dto.PointDTO{},
+			Breakpoints: []dto.BreakpointDTO{},
+		}
+		for _, point := range sweep.Points {
+			sweepDTO.Points = append(sweepDTO.Points, dto.PointDTO{Value: point.Value, Score: point.Score, Predicate: point.Predicate})
+		}
+		for _, bp := range sweep.Breakpoints {
+			sweepDTO.Breakpoints = append(sweepDTO.Breakpoints, dto.BreakpointDTO{
+				Value:       bp.Value,
+				From:        bp.From,
+				To:          bp.To,
+				Description: fmt.Sprintf("%s ≥ %s flips to %s", sweep.Variable, strconv.FormatFloat(bp.Value, 'f', -1, 64), bp.To),
+			})
+		}
+		response.Variables = append(response.Variables, sweepDTO)
+	}
+
+	// Variabel paling berpengaruh ditampilkan lebih dulu
+	sort.SliceStable(response.Variables, func(i, j int) bool {
+		return response.Variables[i].Impact > response.Variables[j].Impact
+	})
+	return response, nil
+}
+
+func (s *FuzzyService) Counterfactuals(ctx context.Context, req *dto.CounterfactualRequestDTO) (*dto.CounterfactualResponseDTO, error) {
+	input, err := s.resolveInput(ctx, req.UserID, req.Input)
+	if err != nil {
+		return nil, err
+	}
+	fuzzyModel, err := s.modelService.GetPublishedModel(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading fuzzy model: %v", err)
+	}
+	if err := validateInput(fuzzyModel, input); err != nil {
+		return nil, err
+	}
+
+	in := analysisInput(input)
+	trace := inferensia.Evaluate(fuzzyModel, in.Crisp, in.Levels)
+	target, results := analisis.Counterfactuals(fuzzyModel, in, analisis.CounterfactualOptions{Limit: req.Limit})
+
+	response := &dto.CounterfactualResponseDTO{
+		StudentID:       input.StudentID,
+		HasilPredicate:  trace.Predicate,
+		Score:           trace.Score,
+		TargetPredicate: target,
+		Options:         []dto.CounterfactualDTO{},
+	}
+	for _, result := range results {
+		option := dto.CounterfactualDTO{
+			Effort:    result.Effort,
+			Score:     result.Score,
+			Predicate: result.Predicate,
+		}
+		for _, change := range result.Changes {
+			option.Changes = append(option.Changes, dto.ChangeDTO{
+				Variable:    change.Variable,
+				From:        change.From,
+				To:          change.To,
+				FromLevel:   change.FromLevel,
+				ToLevel:     change.ToLevel,
+				Description: describeChange(change),
+			})
+		}
+		response.Options = append(response.Options, option)
+	}
+	return response, nil
+}
+
+// Shadow menjalankan model kandidat dan model yang sedang dipublikasikan pada
+// setiap mahasiswa yang memiliki data akademik tanpa menyimpan predikat,
+// lalu melaporkan matriks perpindahan predikat dan mahasiswa yang terdampak
+func (s *FuzzyService) Shadow(ctx context.Context, req *dto.ShadowRequestDTO) (*dto.ShadowResponseDTO, error) {
+	engine, err := inferensia.ParseEngine(req.Engine)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.modelService.GetPublishedModel(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading fuzzy model: %v", err)
+	}
+	candidate, err := s.modelService.CompileModel(ctx, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	academics, err := s.academicRepo.GetAllAcademics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting academic data: %v", err)
+	}
+	// Seperti CalculateFuzzy, setiap mahasiswa dihitung dari data akademik
+	// pertamanya. Data di luar domain salah satu model dilaporkan terpisah.
+	var subjects []analisis.Subject
+	inputs := map[int]*dto.FuzzyResponseDTO{}
+	for _, academic := range academics {
+		if _, ok := inputs[academic.UserID]; ok {
+			continue
+		}
+		input := s.studentInput(ctx, academic.UserID, academic)
+		inputs[academic.UserID] = input
+		subjects = append(subjects, analisis.Subject{ID: academic.UserID, Input: analysisInput(input)})
+	}
+
+	report := analisis.Shadow(current, candidate, engine, subjects)
+	response := &dto.ShadowResponseDTO{
+		CurrentVersion:   current.Version,
+		CandidateVersion: candidate.Version,
+		Engine:           string(engine),
+		Total:            report.Total,
+		Changed:          report.Changed(),
+		Upgraded:         report.Upgraded,
+		Downgraded:       report.Downgraded,
+		Predicates:       report.Predicates,
+		Matrix:           report.Matrix,
+		Affected:         []dto.AffectedStudentDTO{},
+		Invalid:          []dto.InvalidStudentDTO{},
+	}
+	for _, rejection := range report.Invalid {
+		student := dto.InvalidStudentDTO{StudentID: rejection.ID}
+		var invalid *InvalidInputError
+		if errors.As(invalidInput(inputs[rejection.ID], rejection.Err), &invalid) {
+			student.Problems = invalid.Problems
+		}
+		response.Invalid = append(response.Invalid, student)
+	}
+	for _, transition := range report.Affected {
+		response.Affected = append(response.Affected, dto.AffectedStudentDTO{
+			StudentID: transition.ID,
+			From:      transition.From,
+			To:        transition.To,
+			FromScore: transition.FromScore,
+			ToScore:   transition.ToScore,
+		})
+	}
+	return response, nil
+}
+
+// describeChange menuliskan perubahan, misalnya "ipk 3.6 → 3.75" atau
+// "achievement level nasional → internasional"
+func describeChange(change analisis.Change) string {
+	if change.FromLevel != change.ToLevel {
+		from := change.FromLevel
+		if from == "" {
+			from = "none"
+		}
+		return fmt.Sprintf("%s level %s → %s", change.Variable, from, change.ToLevel)
+	}
+	return fmt.Sprintf("%s %s → %s", change.Variable, strconv.FormatFloat(change.From, 'f', -1, 64), strconv.FormatFloat(change.To, 'f', -1, 64))
+}
+
+// resolveInput membaca data mahasiswa tersimpan atau memakai masukan mentah
+func (s *FuzzyService) resolveInput(ctx context.Context, studentID int, input *dto.WhatIfRequestDTO) (*dto.FuzzyResponseDTO, error) {
+	switch {
+	case input != nil:
+		return whatIfResponse(input), nil
+	case studentID != 0:
+		_, response, err := s.loadStudent(ctx, studentID)
+		return response, err
+	default:
+		return nil, ErrMissingInput
+	}
+}
+
+// analysisInput memetakan masukan fuzzy ke nilai tegas setiap variabel
+func analysisInput(input *dto.FuzzyResponseDTO) analisis.Input {
+	crisp, levels := toProfile(input).Inputs()
+	return analisis.Input{Crisp: crisp, Levels: levels}
+}
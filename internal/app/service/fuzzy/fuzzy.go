@@ -2,6 +2,7 @@ package fuzzy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	dto "go-tsukamoto/internal/app/dto/fuzzy"
 	"go-tsukamoto/internal/app/models"
@@ -10,7 +11,11 @@ import (
 	activityRepo "go-tsukamoto/internal/app/repository/activity"
 	predicateRepo "go-tsukamoto/internal/app/repository/predicate"
 	thesisRepo "go-tsukamoto/internal/app/repository/thesis"
+	fuzzyModelService "go-tsukamoto/internal/app/service/fuzzymodel"
+	"go-tsukamoto/internal/modules/analisis"
 	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -21,18 +26,85 @@ type FuzzyService struct {
 	achievementRepo achievementRepo.AchievementRepositoryInterface
 	activityRepo    activityRepo.ActivityRepositoryInterface
 	predicateRepo   predicateRepo.PredicateRepositoryInterface
+	modelService    fuzzyModelService.FuzzyModelServiceInterface
 }
 
-func (s *FuzzyService) CalculateFuzzy(ctx context.Context, studentID int) (*dto.FuzzyResponseDTO, error) {
+// InvalidInputError dikembalikan jika masukan fuzzy berada di luar domain
+// model; Problems memetakan setiap pelanggaran ke field request
+type InvalidInputError struct {
+	Problems []dto.InputProblemDTO
+	err      error
+}
+
+func (e *InvalidInputError) Error() string {
+	return e.err.Error()
+}
+
+func (e *InvalidInputError) Unwrap() error {
+	return e.err
+}
+
+func (s *FuzzyService) CalculateFuzzy(ctx context.Context, req *dto.FuzzyRequestDTO) (*dto.FuzzyResponseDTO, error) {
+	engine, err := inferensia.ParseEngine(req.Engine)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Ambil dan persiapkan data mahasiswa
+	academic, response, err := s.loadStudent(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Jalankan proses fuzzy dengan model yang sedang dipublikasikan
+	fuzzyModel, result, err := s.infer(ctx, response, engine, req.Explain)
+	if err != nil {
+		return nil, err
+	}
+	hasilPredicate := result.Predicate
+	monteCarlo, err := runMonteCarlo(fuzzyModel, response, engine, req.MonteCarlo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Update predicateID di tabel academic. Hanya metode bawaan yang
+	// menentukan predikat resmi; metode lain dihitung untuk perbandingan saja.
+	if engine == inferensia.DefaultEngine() {
+		predicate, err := s.predicateRepo.GetByName(ctx, hasilPredicate)
+		if err != nil {
+			return nil, fmt.Errorf("error getting predicate: %v", err)
+		}
+
+		// Update academic dengan predicate baru
+		academic.PredicateID = predicate.ID
+		if err := s.academicRepo.UpdateAcademic(ctx, academic); err != nil {
+			return nil, fmt.Errorf("error updating academic predicate: %v", err)
+		}
+	}
+
+	// 4. Buat response
+	applyResult(response, fuzzyModel, result)
+	response.MonteCarlo = monteCarlo
+
+	return response, nil
+}
+
+// loadStudent membaca data mahasiswa dan menyiapkan masukan fuzzy
+func (s *FuzzyService) loadStudent(ctx context.Context, studentID int) (*models.Academic, *dto.FuzzyResponseDTO, error) {
 	academics, err := s.academicRepo.GetAcademicsByUserID(ctx, studentID)
 	if err != nil {
-		return nil, fmt.Errorf("error getting academic data: %v", err)
+		return nil, nil, fmt.Errorf("error getting academic data: %v", err)
 	}
 	if len(academics) == 0 {
-		return nil, fmt.Errorf("academic data not found for student ID: %d", studentID)
+		return nil, nil, fmt.Errorf("academic data not found for student ID: %d", studentID)
 	}
 	academic := academics[0]
+	return academic, s.studentInput(ctx, studentID, academic), nil
+}
 
+// studentInput melengkapi data akademik dengan skripsi, prestasi dan
+// aktivitas mahasiswa lalu menyiapkan masukan fuzzy
+func (s *FuzzyService) studentInput(ctx context.Context, studentID int, academic *models.Academic) *dto.FuzzyResponseDTO {
 	theses, err := s.thesisRepo.GetThesesByUserID(ctx, studentID)
 	if err != nil {
 		log.Warnf("error getting thesis data: %v", err)
@@ -55,45 +127,65 @@ func (s *FuzzyService) CalculateFuzzy(ctx context.Context, studentID int) (*dto.
 		log.Warnf("error getting activity data: %v", err)
 	}
 
-	// 2. Persiapkan data untuk fuzzy
-	bestAchievement := getBestAchievement(achievements)
-	activityCount := len(activities)
+	// Persiapkan data untuk fuzzy
+	return newResponse(studentID, academic, thesis, achievements, len(activities))
+}
 
-	// Default values if bestAchievement is nil
-	bestAchievementLevel := ""
-	bestAchievementRank := 0
-	if bestAchievement != nil {
-		bestAchievementLevel = string(bestAchievement.Level)
-		bestAchievementRank = bestAchievement.Rank
-	}
-
-	thesisImpactFactor := calculateThesisImpact(*thesis)
-
-	// 3. Jalankan proses fuzzy menggunakan package yang sudah ada
-	hasilPredicate := inferensia.TsukamotoInference(
-		academic.Ipk,             // IPK mahasiswa
-		academic.Semester,        // Semester yang telah ditempuh
-		academic.RepeatedCourses, // Jumlah mata kuliah mengulang
-		bestAchievementRank,      // Ranking prestasi terbaik
-		bestAchievementLevel,     // Level prestasi (internasional/nasional/internal)
-		thesisImpactFactor,       // Impact factor skripsi
-		thesis.Level,             // Level publikasi skripsi
-		activityCount,            // Jumlah aktivitas organisasi
-	)
-	// 4. Update predicateID di tabel academic
-	predicate, err := s.predicateRepo.GetByName(ctx, hasilPredicate)
+// WhatIf menghitung predikat dari masukan mentah tanpa membaca maupun
+// mengubah data mahasiswa; hanya definisi model terbit yang dimuat
+func (s *FuzzyService) WhatIf(ctx context.Context, req *dto.WhatIfRequestDTO) (*dto.FuzzyResponseDTO, error) {
+	engine, err := inferensia.ParseEngine(req.Engine)
 	if err != nil {
-		return nil, fmt.Errorf("error getting predicate: %v", err)
+		return nil, err
+	}
+	response := whatIfResponse(req)
+	fuzzyModel, result, err := s.infer(ctx, response, engine, true)
+	if err != nil {
+		return nil, err
+	}
+	response.MonteCarlo, err = runMonteCarlo(fuzzyModel, response, engine, req.MonteCarlo)
+	if err != nil {
+		return nil, err
+	}
+	applyResult(response, fuzzyModel, result)
+	return response, nil
+}
+
+// whatIfResponse menyiapkan masukan fuzzy dari masukan mentah
+func whatIfResponse(req *dto.WhatIfRequestDTO) *dto.FuzzyResponseDTO {
+	academic := &models.Academic{
+		Ipk:             req.IPK,
+		Semester:        req.Semester,
+		RepeatedCourses: req.MataKuliahUlang,
+	}
+	thesis := &models.Thesis{}
+	if req.Skripsi != nil {
+		thesis.Level = req.Skripsi.Level
 	}
+	achievements := make([]*models.Achievement, 0, len(req.Prestasi))
+	for _, achievement := range req.Prestasi {
+		achievements = append(achievements, &models.Achievement{
+			Level: models.Level(achievement.Level),
+			Rank:  achievement.Rank,
+		})
+	}
+
+	return newResponse(0, academic, thesis, achievements, req.JumlahAktivitas)
+}
 
-	// Update academic dengan predicate baru
-	academic.PredicateID = predicate.ID
-	if err := s.academicRepo.UpdateAcademic(ctx, academic); err != nil {
-		return nil, fmt.Errorf("error updating academic predicate: %v", err)
+// newResponse menyiapkan masukan fuzzy dari data mahasiswa
+func newResponse(studentID int, academic *models.Academic, thesis *models.Thesis, achievements []*models.Achievement, activityCount int) *dto.FuzzyResponseDTO {
+	bestIndex := bestAchievementIndex(achievements)
+
+	// Default values if there is no achievement
+	bestAchievementLevel := ""
+	bestAchievementRank := 0
+	if bestIndex >= 0 {
+		bestAchievementLevel = string(achievements[bestIndex].Level)
+		bestAchievementRank = achievements[bestIndex].Rank
 	}
 
-	// 5. Buat response
-	response := &dto.FuzzyResponseDTO{
+	return &dto.FuzzyResponseDTO{
 		StudentID:       studentID,
 		IPK:             academic.Ipk,
 		Semester:        academic.Semester,
@@ -101,30 +193,238 @@ func (s *FuzzyService) CalculateFuzzy(ctx context.Context, studentID int) (*dto.
 		PrestasiLevel:   bestAchievementLevel,
 		PrestasiRank:    bestAchievementRank,
 		SkripsiLevel:    thesis.Level,
-		SkripsiImpact:   thesisImpactFactor,
+		SkripsiImpact:   calculateThesisImpact(*thesis),
 		JumlahAktivitas: activityCount,
-		HasilPredicate:  hasilPredicate,
+		PrestasiIndex:   bestIndex,
 	}
+}
 
-	return response, nil
+// infer menjalankan inferensi dengan model yang sedang dipublikasikan; jejak
+// lengkap hanya disertakan pada mode penjelasan
+func (s *FuzzyService) infer(ctx context.Context, input *dto.FuzzyResponseDTO, engine inferensia.Engine, explain bool) (*model.Model, *inferensia.Result, error) {
+	fuzzyModel, err := s.modelService.GetPublishedModel(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading fuzzy model: %v", err)
+	}
+	result, err := inferensia.Infer(ctx, toProfile(input),
+		inferensia.WithModel(fuzzyModel),
+		inferensia.WithEngine(engine),
+		inferensia.WithExplain(explain),
+	)
+	if err != nil {
+		return nil, nil, invalidInput(input, err)
+	}
+	return fuzzyModel, result, nil
+}
+
+// toProfile memetakan masukan fuzzy ke profil mahasiswa mesin inferensi
+func toProfile(input *dto.FuzzyResponseDTO) inferensia.StudentProfile {
+	return inferensia.StudentProfile{
+		IPK:                input.IPK,             // IPK mahasiswa
+		CompletedSemester:  input.Semester,        // Semester yang telah ditempuh
+		RepeatedCourses:    input.MataKuliahUlang, // Jumlah mata kuliah mengulang
+		AchievementRank:    input.PrestasiRank,    // Ranking prestasi terbaik
+		AchievementLevel:   input.PrestasiLevel,   // Level prestasi (internasional/nasional/internal)
+		ThesisImpactFactor: input.SkripsiImpact,   // Impact factor skripsi
+		ThesisLevel:        input.SkripsiLevel,    // Level publikasi skripsi
+		ActivityCount:      input.JumlahAktivitas, // Jumlah aktivitas organisasi
+	}
+}
+
+// validateInput memeriksa masukan fuzzy terhadap domain model
+func validateInput(fuzzyModel *model.Model, input *dto.FuzzyResponseDTO) error {
+	crisp, levels := toProfile(input).Inputs()
+	return invalidInput(input, inferensia.Validate(fuzzyModel, crisp, levels))
+}
+
+// invalidInput memetakan pelanggaran domain dari mesin inferensi ke field
+// request; galat lain dikembalikan apa adanya
+func invalidInput(input *dto.FuzzyResponseDTO, err error) error {
+	var invalid *inferensia.ValidationError
+	if !errors.As(err, &invalid) {
+		return err
+	}
+	problems := make([]dto.InputProblemDTO, 0, len(invalid.Errors))
+	for _, violation := range invalid.Errors {
+		problem := dto.InputProblemDTO{Message: violation.Error()}
+		var outOfUniverse *inferensia.ErrOutOfUniverse
+		var invalidLevel *inferensia.ErrInvalidLevel
+		switch {
+		case errors.As(violation, &outOfUniverse):
+			problem.Variable = outOfUniverse.Variable
+			problem.Field = inputField(input, outOfUniverse.Variable, false)
+			problem.Value = &outOfUniverse.Value
+			problem.Min = &outOfUniverse.Min
+			problem.Max = &outOfUniverse.Max
+		case errors.As(violation, &invalidLevel):
+			problem.Variable = invalidLevel.Variable
+			problem.Field = inputField(input, invalidLevel.Variable, true)
+			problem.Level = invalidLevel.Level
+			problem.Levels = invalidLevel.Levels
+		}
+		problems = append(problems, problem)
+	}
+	return &InvalidInputError{Problems: problems, err: err}
+}
+
+// inputField memetakan variabel model ke field request, baik nilainya maupun
+// levelnya; prestasi merujuk elemen yang terpilih sebagai prestasi terbaik.
+// Impact factor skripsi diturunkan dari levelnya, sehingga keduanya dilaporkan
+// pada skripsi.level.
+func inputField(input *dto.FuzzyResponseDTO, variable string, level bool) string {
+	switch variable {
+	case "ipk":
+		return "ipk"
+	case "studyDuration":
+		return "semester"
+	case "repeatedCourses":
+		return "mata_kuliah_ulang"
+	case "activity":
+		return "jumlah_aktivitas"
+	case "achievement":
+		if level {
+			return fmt.Sprintf("prestasi[%d].level", input.PrestasiIndex)
+		}
+		return fmt.Sprintf("prestasi[%d].rank", input.PrestasiIndex)
+	case "thesis":
+		return "skripsi.level"
+	}
+	return variable
+}
+
+// applyResult memetakan hasil inferensi ke response; penjelasan hanya diisi
+// jika hasil membawa jejak lengkap
+func applyResult(response *dto.FuzzyResponseDTO, fuzzyModel *model.Model, result *inferensia.Result) {
+	response.HasilPredicate = result.Predicate
+	response.Engine = string(result.Engine)
+	response.Uncertainty = toUncertainty(result)
+	if result.Trace != nil {
+		response.Explanation = toExplanation(fuzzyModel, result.Trace)
+	}
+}
+
+// toExplanation memetakan jejak inferensi ke DTO penjelasan
+func toExplanation(fuzzyModel *model.Model, trace *inferensia.Trace) *dto.ExplanationDTO {
+	explanation := &dto.ExplanationDTO{
+		ModelVersion: trace.ModelVersion,
+		Engine:       string(trace.Engine),
+		Defuzzifier:  trace.Defuzzifier,
+		Inputs:       trace.Inputs,
+		Memberships:  make(map[string]map[string]float64, len(trace.Memberships)),
+		Score:        trace.Score,
+		Band: dto.BandDTO{
+			Predicate: trace.Band.Predicate,
+			MinScore:  trace.Band.MinScore,
+		},
+	}
+	for variable, memberships := range trace.Memberships {
+		explanation.Memberships[variable] = memberships
+	}
+	if trace.LowerMemberships != nil {
+		explanation.LowerMemberships = make(map[string]map[string]float64, len(trace.LowerMemberships))
+		for variable, memberships := range trace.LowerMemberships {
+			explanation.LowerMemberships[variable] = memberships
+		}
+	}
+	for _, rule := range trace.Rules {
+		ruleTrace := dto.RuleTraceDTO{
+			Rule:       rule.Rule,
+			Predicate:  rule.Predicate,
+			Strength:   rule.Strength,
+			Output:     rule.Output,
+			Normalized: rule.Normalized,
+		}
+		if trace.Uncertainty != nil {
+			ruleTrace.Firing = &[2]float64{rule.Lower, rule.Upper}
+		}
+		explanation.Rules = append(explanation.Rules, ruleTrace)
+	}
+	if upper, ok := fuzzyModel.Output.Upper(trace.Band.Predicate); ok {
+		explanation.Band.MaxScore = &upper
+	}
+	return explanation
+}
+
+// toUncertainty memetakan interval skor model interval tipe-2; nil untuk
+// model tipe-1
+func toUncertainty(result *inferensia.Result) *dto.UncertaintyDTO {
+	if result.Uncertainty == nil {
+		return nil
+	}
+	return &dto.UncertaintyDTO{
+		Score:          result.Score,
+		Lower:          result.Uncertainty.Lower,
+		Upper:          result.Uncertainty.Upper,
+		LowerPredicate: result.Uncertainty.LowerPredicate,
+		UpperPredicate: result.Uncertainty.UpperPredicate,
+	}
+}
+
+// runMonteCarlo menjalankan estimasi ketidakpastian Monte Carlo jika diminta;
+// derau pada permintaan menimpa derau bawaan per variabel
+func runMonteCarlo(fuzzyModel *model.Model, input *dto.FuzzyResponseDTO, engine inferensia.Engine, req *dto.MonteCarloRequestDTO) (*dto.MonteCarloDTO, error) {
+	if req == nil {
+		return nil, nil
+	}
+	noise := make(map[string]analisis.Noise, len(analisis.DefaultNoise)+len(req.Noise))
+	for variable, n := range analisis.DefaultNoise {
+		noise[variable] = n
+	}
+	for variable, n := range req.Noise {
+		noise[variable] = analisis.Noise{Distribution: n.Distribution, Spread: n.Spread}
+	}
+
+	result, err := analisis.MonteCarlo(fuzzyModel, analysisInput(input), analisis.MonteCarloOptions{
+		Samples:         req.Samples,
+		Noise:           noise,
+		Discrete:        analisis.DiscreteVariables,
+		Engine:          engine,
+		Seed:            req.Seed,
+		ReviewThreshold: req.ReviewThreshold,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.NeedsReview {
+		log.Infof("student %d needs committee review: %s with probability %.3f", input.StudentID, result.MostLikely, result.Probability)
+	}
+	return &dto.MonteCarloDTO{
+		Samples:         result.Samples,
+		Probabilities:   result.Probabilities,
+		MostLikely:      result.MostLikely,
+		Probability:     result.Probability,
+		ScoreMean:       result.ScoreMean,
+		ScoreStdDev:     result.ScoreStdDev,
+		ReviewThreshold: result.ReviewThreshold,
+		NeedsReview:     result.NeedsReview,
+	}, nil
 }
 
 func getBestAchievement(achievements []*models.Achievement) *models.Achievement {
+	if i := bestAchievementIndex(achievements); i >= 0 {
+		return achievements[i]
+	}
+	return nil
+}
+
+// bestAchievementIndex mengembalikan indeks prestasi terbaik, atau -1 jika
+// tidak ada prestasi
+func bestAchievementIndex(achievements []*models.Achievement) int {
 	if len(achievements) == 0 {
-		return nil
+		return -1
 	}
 
-	best := achievements[0]
-	for _, achievement := range achievements[1:] {
+	best := 0
+	for i, achievement := range achievements[1:] {
 		// Prioritaskan level yang lebih tinggi
-		if getLevelPriority(achievement.Level) > getLevelPriority(best.Level) {
-			best = achievement
+		if getLevelPriority(achievement.Level) > getLevelPriority(achievements[best].Level) {
+			best = i + 1
 			continue
 		}
 
 		// Jika level sama, pilih ranking yang lebih kecil (lebih baik)
-		if achievement.Level == best.Level && achievement.Rank < best.Rank {
-			best = achievement
+		if strings.EqualFold(string(achievement.Level), string(achievements[best].Level)) && achievement.Rank < achievements[best].Rank {
+			best = i + 1
 		}
 	}
 	return best
@@ -132,7 +432,7 @@ func getBestAchievement(achievements []*models.Achievement) *models.Achievement
 
 // Fungsi helper untuk menentukan prioritas level
 func getLevelPriority(level models.Level) int {
-	switch level {
+	switch models.Level(strings.ToLower(string(level))) {
 	case models.LevelInternasional:
 		return 3
 	case models.LevelNasional:
@@ -148,7 +448,7 @@ func getLevelPriority(level models.Level) int {
 func calculateThesisImpact(thesis models.Thesis) float64 {
 	// Implementasi sesuai dengan kriteria penilaian skripsi
 	// Contoh sederhana:
-	switch thesis.Level {
+	switch strings.ToLower(thesis.Level) {
 	case "internasional":
 		return 5.0
 	case "nasional":
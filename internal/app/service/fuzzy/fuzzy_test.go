@@ -8,12 +8,19 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	dto "go-tsukamoto/internal/app/dto/fuzzy"
+	fuzzyModelDto "go-tsukamoto/internal/app/dto/fuzzymodel"
 	"go-tsukamoto/internal/app/models"
 	mockAcademicRepo "go-tsukamoto/internal/app/repository/academic"
 	mockAchievementRepo "go-tsukamoto/internal/app/repository/achievement"
 	mockActivityRepo "go-tsukamoto/internal/app/repository/activity"
+	mockFuzzyModelRepo "go-tsukamoto/internal/app/repository/fuzzymodel"
 	mockPredicateRepo "go-tsukamoto/internal/app/repository/predicate"
 	mockThesisRepo "go-tsukamoto/internal/app/repository/thesis"
+	mockFuzzyModelService "go-tsukamoto/internal/app/service/fuzzymodel"
+	"go-tsukamoto/internal/modules/analisis"
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
 )
 
 func TestCalculateFuzzy(t *testing.T) {
@@ -25,6 +32,7 @@ func TestCalculateFuzzy(t *testing.T) {
 	mockAchievementRepo := mockAchievementRepo.NewMockAchievementRepositoryInterface(ctrl)
 	mockActivityRepo := mockActivityRepo.NewMockActivityRepositoryInterface(ctrl)
 	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	mockModelService := mockFuzzyModelService.NewMockFuzzyModelServiceInterface(ctrl)
 
 	fuzzyService := &FuzzyService{
 		academicRepo:    mockAcademicRepo,
@@ -32,6 +40,7 @@ func TestCalculateFuzzy(t *testing.T) {
 		achievementRepo: mockAchievementRepo,
 		activityRepo:    mockActivityRepo,
 		predicateRepo:   mockPredicateRepo,
+		modelService:    mockModelService,
 	}
 
 	ctx := context.Background()
@@ -88,11 +97,12 @@ func TestCalculateFuzzy(t *testing.T) {
 		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return(theses, nil)
 		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return(achievements, nil)
 		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return(activities, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
 		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(predicate, nil)
 		mockAcademicRepo.EXPECT().UpdateAcademic(ctx, gomock.Any()).Return(nil)
 
 		// Call the service
-		result, err := fuzzyService.CalculateFuzzy(ctx, studentID)
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID})
 
 		// Assert results
 		assert.NoError(t, err)
@@ -109,12 +119,104 @@ func TestCalculateFuzzy(t *testing.T) {
 		assert.NotEmpty(t, result.HasilPredicate)
 	})
 
+	t.Run("Explain", func(t *testing.T) {
+		academics := []*models.Academic{
+			{
+				ID:              1,
+				UserID:          studentID,
+				Ipk:             3.75,
+				Semester:        8,
+				RepeatedCourses: 1,
+			},
+		}
+
+		predicate := &models.Predicate{
+			ID:   1,
+			Name: "Sangat Memuaskan",
+		}
+
+		// Set expectations
+		mockAcademicRepo.EXPECT().GetAcademicsByUserID(ctx, studentID).Return(academics, nil)
+		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return([]*models.Thesis{}, nil)
+		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return([]*models.Achievement{}, nil)
+		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return([]*models.Activity{}, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(predicate, nil)
+		mockAcademicRepo.EXPECT().UpdateAcademic(ctx, gomock.Any()).Return(nil)
+
+		// Call the service
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID, Explain: true})
+
+		// Assert results
+		assert.NoError(t, err)
+		assert.NotNil(t, result.Explanation)
+		assert.Equal(t, result.HasilPredicate, result.Explanation.Band.Predicate)
+		assert.Contains(t, result.Explanation.Memberships, "ipk")
+		assert.Len(t, result.Explanation.Rules, len(model.Default().RuleBase.Rules)+1)
+		assert.GreaterOrEqual(t, result.Explanation.Score, result.Explanation.Band.MinScore)
+		if result.Explanation.Band.MaxScore != nil {
+			assert.Less(t, result.Explanation.Score, *result.Explanation.Band.MaxScore)
+		}
+	})
+
+	t.Run("Alternative Engine", func(t *testing.T) {
+		academics := []*models.Academic{
+			{
+				ID:              1,
+				UserID:          studentID,
+				Ipk:             3.6,
+				Semester:        8,
+				RepeatedCourses: 1,
+			},
+		}
+
+		// Tanpa GetByName/UpdateAcademic: metode selain bawaan tidak mengubah predikat resmi
+		mockAcademicRepo.EXPECT().GetAcademicsByUserID(ctx, studentID).Return(academics, nil)
+		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return([]*models.Thesis{}, nil)
+		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return([]*models.Achievement{}, nil)
+		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return([]*models.Activity{}, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID, Explain: true, Engine: "mamdani"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "mamdani", result.Engine)
+		assert.Equal(t, "mamdani", result.Explanation.Engine)
+		assert.Equal(t, result.HasilPredicate, result.Explanation.Band.Predicate)
+	})
+
+	t.Run("Invalid Monte Carlo Noise", func(t *testing.T) {
+		academics := []*models.Academic{{ID: 1, UserID: studentID, Ipk: 3.6, Semester: 8}}
+
+		// Tanpa GetByName/UpdateAcademic: predikat tidak disimpan jika permintaan tidak valid
+		mockAcademicRepo.EXPECT().GetAcademicsByUserID(ctx, studentID).Return(academics, nil)
+		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return([]*models.Thesis{}, nil)
+		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return([]*models.Achievement{}, nil)
+		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return([]*models.Activity{}, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{
+			UserID:     studentID,
+			MonteCarlo: &dto.MonteCarloRequestDTO{Noise: map[string]dto.NoiseDTO{"gpa": {Distribution: "normal", Spread: 0.1}}},
+		})
+
+		assert.ErrorIs(t, err, analisis.ErrInvalidNoise)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Unknown Engine", func(t *testing.T) {
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID, Engine: "sugeno-x"})
+
+		assert.ErrorIs(t, err, inferensia.ErrUnknownEngine)
+		assert.Nil(t, result)
+	})
+
 	t.Run("No Academic Data", func(t *testing.T) {
 		// Empty academics array
 		mockAcademicRepo.EXPECT().GetAcademicsByUserID(ctx, studentID).Return([]*models.Academic{}, nil)
 
 		// Call the service
-		result, err := fuzzyService.CalculateFuzzy(ctx, studentID)
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID})
 
 		// Assert results
 		assert.Error(t, err)
@@ -127,7 +229,7 @@ func TestCalculateFuzzy(t *testing.T) {
 		mockAcademicRepo.EXPECT().GetAcademicsByUserID(ctx, studentID).Return(nil, errors.New("database error"))
 
 		// Call the service
-		result, err := fuzzyService.CalculateFuzzy(ctx, studentID)
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID})
 
 		// Assert results
 		assert.Error(t, err)
@@ -174,11 +276,12 @@ func TestCalculateFuzzy(t *testing.T) {
 		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return([]*models.Thesis{}, nil) // Empty thesis
 		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return(achievements, nil)
 		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return(activities, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
 		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(predicate, nil)
 		mockAcademicRepo.EXPECT().UpdateAcademic(ctx, gomock.Any()).Return(nil)
 
 		// Call the service
-		result, err := fuzzyService.CalculateFuzzy(ctx, studentID)
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID})
 
 		// Assert results
 		assert.NoError(t, err)
@@ -225,11 +328,12 @@ func TestCalculateFuzzy(t *testing.T) {
 		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return(theses, nil)
 		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return([]*models.Achievement{}, nil) // Empty achievements
 		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return(activities, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
 		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(predicate, nil)
 		mockAcademicRepo.EXPECT().UpdateAcademic(ctx, gomock.Any()).Return(nil)
 
 		// Call the service
-		result, err := fuzzyService.CalculateFuzzy(ctx, studentID)
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID})
 
 		// Assert results
 		assert.NoError(t, err)
@@ -238,6 +342,33 @@ func TestCalculateFuzzy(t *testing.T) {
 		assert.Equal(t, 0, result.PrestasiRank)   // Default achievement rank
 	})
 
+	t.Run("Fuzzy Model Error", func(t *testing.T) {
+		academics := []*models.Academic{
+			{
+				ID:              1,
+				UserID:          studentID,
+				Ipk:             3.75,
+				Semester:        8,
+				RepeatedCourses: 1,
+			},
+		}
+
+		// Set expectations
+		mockAcademicRepo.EXPECT().GetAcademicsByUserID(ctx, studentID).Return(academics, nil)
+		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return([]*models.Thesis{}, nil)
+		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return([]*models.Achievement{}, nil)
+		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return([]*models.Activity{}, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(nil, errors.New("database error"))
+
+		// Call the service
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID})
+
+		// Assert results
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "error loading fuzzy model")
+	})
+
 	t.Run("Predicate Repository Error", func(t *testing.T) {
 		// Mock data
 		academics := []*models.Academic{
@@ -280,10 +411,11 @@ func TestCalculateFuzzy(t *testing.T) {
 		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return(theses, nil)
 		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return(achievements, nil)
 		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return(activities, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
 		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(nil, errors.New("predicate not found"))
 
 		// Call the service
-		result, err := fuzzyService.CalculateFuzzy(ctx, studentID)
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID})
 
 		// Assert results
 		assert.Error(t, err)
@@ -338,11 +470,12 @@ func TestCalculateFuzzy(t *testing.T) {
 		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return(theses, nil)
 		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return(achievements, nil)
 		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return(activities, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
 		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(predicate, nil)
 		mockAcademicRepo.EXPECT().UpdateAcademic(ctx, gomock.Any()).Return(errors.New("update error"))
 
 		// Call the service
-		result, err := fuzzyService.CalculateFuzzy(ctx, studentID)
+		result, err := fuzzyService.CalculateFuzzy(ctx, &dto.FuzzyRequestDTO{UserID: studentID})
 
 		// Assert results
 		assert.Error(t, err)
@@ -351,6 +484,614 @@ func TestCalculateFuzzy(t *testing.T) {
 	})
 }
 
+func TestWhatIf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Tidak ada ekspektasi repositori: simulasi tidak boleh menyentuh data mahasiswa
+	mockModelService := mockFuzzyModelService.NewMockFuzzyModelServiceInterface(ctrl)
+	fuzzyService := &FuzzyService{
+		academicRepo:    mockAcademicRepo.NewMockAcademicRepositoryInterface(ctrl),
+		thesisRepo:      mockThesisRepo.NewMockThesisRepositoryInterface(ctrl),
+		achievementRepo: mockAchievementRepo.NewMockAchievementRepositoryInterface(ctrl),
+		activityRepo:    mockActivityRepo.NewMockActivityRepositoryInterface(ctrl),
+		predicateRepo:   mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl),
+		modelService:    mockModelService,
+	}
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{
+			IPK:             3.6,
+			Semester:        8,
+			MataKuliahUlang: 1,
+			Prestasi: []dto.AchievementInputDTO{
+				{Level: "internal", Rank: 1},
+				{Level: "nasional", Rank: 3},
+			},
+			Skripsi:         &dto.ThesisInputDTO{Level: "nasional"},
+			JumlahAktivitas: 3,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.StudentID)
+		assert.Equal(t, "nasional", result.PrestasiLevel)
+		assert.Equal(t, 3, result.PrestasiRank)
+		assert.Equal(t, 3.0, result.SkripsiImpact)
+		assert.NotEmpty(t, result.HasilPredicate)
+		assert.NotNil(t, result.Explanation)
+		assert.Equal(t, result.HasilPredicate, result.Explanation.Band.Predicate)
+		assert.Nil(t, result.Uncertainty)
+	})
+
+	t.Run("Engine", func(t *testing.T) {
+		for _, engine := range inferensia.Engines {
+			mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+			result, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{IPK: 3.6, Semester: 8, Engine: string(engine)})
+
+			assert.NoError(t, err)
+			assert.Equal(t, string(engine), result.Engine)
+			assert.NotEmpty(t, result.HasilPredicate)
+		}
+	})
+
+	t.Run("Type Two", func(t *testing.T) {
+		def := model.Default().Definition()
+		def.Variables[0].Terms[4].Lower = &model.FunctionSpec{Type: "piecewise", Params: []float64{3.75, 0, 4, 1}}
+		typeTwo, err := model.Compile(def)
+		assert.NoError(t, err)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(typeTwo, nil)
+
+		result, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{
+			IPK:             3.9,
+			Semester:        7,
+			Prestasi:        []dto.AchievementInputDTO{{Level: "internasional", Rank: 1}},
+			Skripsi:         &dto.ThesisInputDTO{Level: "internasional"},
+			JumlahAktivitas: 8,
+		})
+
+		assert.NoError(t, err)
+		if !assert.NotNil(t, result.Uncertainty) {
+			return
+		}
+		assert.Less(t, result.Uncertainty.Lower, result.Uncertainty.Upper)
+		assert.Equal(t, result.Explanation.Score, result.Uncertainty.Score)
+		assert.Contains(t, result.Explanation.LowerMemberships, "ipk")
+		for _, rule := range result.Explanation.Rules {
+			if assert.NotNil(t, rule.Firing) {
+				assert.LessOrEqual(t, rule.Firing[0], rule.Firing[1])
+			}
+		}
+	})
+
+	t.Run("Monte Carlo", func(t *testing.T) {
+		// IPK 3.75 hasil pembulatan berada tepat pada syarat Cum Laude
+		input := dto.WhatIfRequestDTO{
+			IPK:             3.75,
+			Semester:        8,
+			Prestasi:        []dto.AchievementInputDTO{{Level: "nasional", Rank: 3}},
+			Skripsi:         &dto.ThesisInputDTO{Level: "nasional"},
+			JumlahAktivitas: 3,
+			MonteCarlo:      &dto.MonteCarloRequestDTO{Samples: 500, Seed: 1},
+		}
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err := fuzzyService.WhatIf(ctx, &input)
+
+		assert.NoError(t, err)
+		if !assert.NotNil(t, result.MonteCarlo) {
+			return
+		}
+		assert.Equal(t, 500, result.MonteCarlo.Samples)
+		assert.Len(t, result.MonteCarlo.Probabilities, 6)
+		assert.True(t, result.MonteCarlo.NeedsReview)
+		assert.Equal(t, 0.8, result.MonteCarlo.ReviewThreshold)
+
+		// Tanpa derau IPK predikat pasti sama dengan hasil tanpa Monte Carlo
+		input.MonteCarlo = &dto.MonteCarloRequestDTO{Samples: 500, Noise: map[string]dto.NoiseDTO{"ipk": {Distribution: "uniform"}}}
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err = fuzzyService.WhatIf(ctx, &input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, result.HasilPredicate, result.MonteCarlo.MostLikely)
+		assert.False(t, result.MonteCarlo.NeedsReview)
+
+		input.MonteCarlo = &dto.MonteCarloRequestDTO{Noise: map[string]dto.NoiseDTO{"ipk": {Distribution: "poisson", Spread: 0.1}}}
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err = fuzzyService.WhatIf(ctx, &input)
+
+		assert.ErrorIs(t, err, analisis.ErrInvalidNoise)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{
+			IPK:      5.2,
+			Semester: 0,
+			Prestasi: []dto.AchievementInputDTO{{Level: "regional", Rank: 2}},
+			Skripsi:  &dto.ThesisInputDTO{Level: "regional"},
+		})
+
+		assert.Nil(t, result)
+		var invalid *InvalidInputError
+		if assert.ErrorAs(t, err, &invalid) {
+			assert.Len(t, invalid.Problems, 4)
+			assert.Equal(t, "ipk", invalid.Problems[0].Field)
+			assert.Equal(t, 5.2, *invalid.Problems[0].Value)
+			assert.Equal(t, 0.0, *invalid.Problems[0].Min)
+			assert.Equal(t, 4.0, *invalid.Problems[0].Max)
+			assert.Equal(t, "semester", invalid.Problems[1].Field)
+			assert.Equal(t, "prestasi[0].level", invalid.Problems[2].Field)
+			assert.Equal(t, "regional", invalid.Problems[2].Level)
+			assert.Equal(t, []string{"internasional", "nasional", "internal"}, invalid.Problems[2].Levels)
+			assert.Equal(t, "skripsi.level", invalid.Problems[3].Field)
+		}
+		var outOfUniverse *inferensia.ErrOutOfUniverse
+		if assert.ErrorAs(t, err, &outOfUniverse) {
+			assert.Equal(t, inferensia.ErrOutOfUniverse{Variable: "ipk", Value: 5.2, Min: 0, Max: 4}, *outOfUniverse)
+		}
+	})
+
+	t.Run("Open Counts", func(t *testing.T) {
+		// Jumlah di atas semesta variabel tidak ditolak, melainkan jenuh
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil).Times(2)
+
+		result, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{
+			IPK:             3.6,
+			Semester:        8,
+			Prestasi:        []dto.AchievementInputDTO{{Level: "internal", Rank: 30}},
+			JumlahAktivitas: 25,
+		})
+		assert.NoError(t, err)
+		saturated, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{
+			IPK:             3.6,
+			Semester:        8,
+			Prestasi:        []dto.AchievementInputDTO{{Level: "internal", Rank: 20}},
+			JumlahAktivitas: 20,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 25, result.JumlahAktivitas)
+		assert.Equal(t, saturated.HasilPredicate, result.HasilPredicate)
+		assert.Equal(t, saturated.Explanation.Score, result.Explanation.Score)
+	})
+
+	t.Run("Level Case", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil).Times(2)
+
+		lower, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{
+			IPK:      3.6,
+			Semester: 8,
+			Prestasi: []dto.AchievementInputDTO{{Level: "internal", Rank: 1}, {Level: "nasional", Rank: 3}},
+			Skripsi:  &dto.ThesisInputDTO{Level: "nasional"},
+		})
+		assert.NoError(t, err)
+		mixed, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{
+			IPK:      3.6,
+			Semester: 8,
+			Prestasi: []dto.AchievementInputDTO{{Level: "Internal", Rank: 1}, {Level: "Nasional", Rank: 3}},
+			Skripsi:  &dto.ThesisInputDTO{Level: "NASIONAL"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Nasional", mixed.PrestasiLevel)
+		assert.Equal(t, 3.0, mixed.SkripsiImpact)
+		assert.Equal(t, lower.HasilPredicate, mixed.HasilPredicate)
+		assert.Equal(t, lower.Explanation.Score, mixed.Explanation.Score)
+	})
+
+	t.Run("Fuzzy Model Error", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(nil, errors.New("database error"))
+
+		result, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{IPK: 3.6})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestWhatIf_PublishedModelCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockModelRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	fuzzyService := &FuzzyService{
+		academicRepo:    mockAcademicRepo.NewMockAcademicRepositoryInterface(ctrl),
+		thesisRepo:      mockThesisRepo.NewMockThesisRepositoryInterface(ctrl),
+		achievementRepo: mockAchievementRepo.NewMockAchievementRepositoryInterface(ctrl),
+		activityRepo:    mockActivityRepo.NewMockActivityRepositoryInterface(ctrl),
+		predicateRepo:   mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl),
+		modelService:    mockFuzzyModelService.NewFuzzyModelService(mockModelRepo, mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)),
+	}
+	ctx := context.Background()
+
+	// Model terbit dimuat sekali; simulasi berikutnya tidak boleh membaca tabel model
+	mockModelRepo.EXPECT().GetPublishedModel(ctx).Return(nil, nil).Times(1)
+	_, err := fuzzyService.modelService.GetPublishedModel(ctx)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		result, err := fuzzyService.WhatIf(ctx, &dto.WhatIfRequestDTO{
+			IPK:             3.6,
+			Semester:        8,
+			MataKuliahUlang: 1,
+			Skripsi:         &dto.ThesisInputDTO{Level: "nasional"},
+			JumlahAktivitas: 3,
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.HasilPredicate)
+	}
+}
+
+func TestSensitivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAcademicRepo := mockAcademicRepo.NewMockAcademicRepositoryInterface(ctrl)
+	mockThesisRepo := mockThesisRepo.NewMockThesisRepositoryInterface(ctrl)
+	mockAchievementRepo := mockAchievementRepo.NewMockAchievementRepositoryInterface(ctrl)
+	mockActivityRepo := mockActivityRepo.NewMockActivityRepositoryInterface(ctrl)
+	mockModelService := mockFuzzyModelService.NewMockFuzzyModelServiceInterface(ctrl)
+
+	fuzzyService := &FuzzyService{
+		academicRepo:    mockAcademicRepo,
+		thesisRepo:      mockThesisRepo,
+		achievementRepo: mockAchievementRepo,
+		activityRepo:    mockActivityRepo,
+		predicateRepo:   mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl),
+		modelService:    mockModelService,
+	}
+	ctx := context.Background()
+	studentID := 1
+
+	t.Run("Stored Student", func(t *testing.T) {
+		academics := []*models.Academic{
+			{
+				ID:              1,
+				UserID:          studentID,
+				Ipk:             3.6,
+				Semester:        8,
+				RepeatedCourses: 1,
+			},
+		}
+
+		// Tanpa UpdateAcademic: analisis tidak mengubah predikat tersimpan
+		mockAcademicRepo.EXPECT().GetAcademicsByUserID(ctx, studentID).Return(academics, nil)
+		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return([]*models.Thesis{}, nil)
+		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return([]*models.Achievement{}, nil)
+		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return([]*models.Activity{}, nil)
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err := fuzzyService.Sensitivity(ctx, &dto.SensitivityRequestDTO{UserID: studentID, Steps: 20})
+
+		assert.NoError(t, err)
+		assert.Equal(t, studentID, result.StudentID)
+		assert.Len(t, result.Variables, len(model.Default().Variables))
+		for i := 1; i < len(result.Variables); i++ {
+			assert.GreaterOrEqual(t, result.Variables[i-1].Impact, result.Variables[i].Impact)
+		}
+	})
+
+	t.Run("Raw Input", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err := fuzzyService.Sensitivity(ctx, &dto.SensitivityRequestDTO{
+			Input: &dto.WhatIfRequestDTO{IPK: 3.6, Semester: 8, MataKuliahUlang: 1},
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.HasilPredicate)
+		for _, variable := range result.Variables {
+			if variable.Variable != "ipk" {
+				continue
+			}
+			assert.Equal(t, 3.6, variable.Current)
+			assert.NotEmpty(t, variable.Breakpoints)
+			assert.Contains(t, variable.Breakpoints[0].Description, "ipk ≥")
+		}
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err := fuzzyService.Sensitivity(ctx, &dto.SensitivityRequestDTO{
+			Input: &dto.WhatIfRequestDTO{IPK: -1, Semester: 8},
+		})
+
+		assert.Nil(t, result)
+		var invalid *InvalidInputError
+		if assert.ErrorAs(t, err, &invalid) {
+			assert.Len(t, invalid.Problems, 1)
+			assert.Equal(t, "ipk", invalid.Problems[0].Field)
+		}
+	})
+
+	t.Run("Missing Input", func(t *testing.T) {
+		result, err := fuzzyService.Sensitivity(ctx, &dto.SensitivityRequestDTO{})
+
+		assert.ErrorIs(t, err, ErrMissingInput)
+		assert.Nil(t, result)
+	})
+}
+
+func TestCounterfactuals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockModelService := mockFuzzyModelService.NewMockFuzzyModelServiceInterface(ctrl)
+
+	// Tanpa ekspektasi repository: masukan mentah tidak menyentuh database
+	fuzzyService := &FuzzyService{
+		academicRepo:    mockAcademicRepo.NewMockAcademicRepositoryInterface(ctrl),
+		thesisRepo:      mockThesisRepo.NewMockThesisRepositoryInterface(ctrl),
+		achievementRepo: mockAchievementRepo.NewMockAchievementRepositoryInterface(ctrl),
+		activityRepo:    mockActivityRepo.NewMockActivityRepositoryInterface(ctrl),
+		predicateRepo:   mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl),
+		modelService:    mockModelService,
+	}
+	ctx := context.Background()
+
+	t.Run("Raw Input", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+
+		result, err := fuzzyService.Counterfactuals(ctx, &dto.CounterfactualRequestDTO{
+			Input: &dto.WhatIfRequestDTO{IPK: 3.6, Semester: 8, MataKuliahUlang: 1},
+			Limit: 3,
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.TargetPredicate)
+		assert.NotEqual(t, result.HasilPredicate, result.TargetPredicate)
+		assert.NotEmpty(t, result.Options)
+		assert.LessOrEqual(t, len(result.Options), 3)
+		for _, option := range result.Options {
+			assert.NotEmpty(t, option.Changes)
+			for _, change := range option.Changes {
+				assert.Contains(t, change.Description, change.Variable)
+			}
+		}
+	})
+
+	t.Run("Fuzzy Model Error", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(nil, errors.New("database error"))
+
+		result, err := fuzzyService.Counterfactuals(ctx, &dto.CounterfactualRequestDTO{
+			Input: &dto.WhatIfRequestDTO{IPK: 3.6, Semester: 8},
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Missing Input", func(t *testing.T) {
+		result, err := fuzzyService.Counterfactuals(ctx, &dto.CounterfactualRequestDTO{})
+
+		assert.ErrorIs(t, err, ErrMissingInput)
+		assert.Nil(t, result)
+	})
+}
+
+func TestShadow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAcademicRepo := mockAcademicRepo.NewMockAcademicRepositoryInterface(ctrl)
+	mockThesisRepo := mockThesisRepo.NewMockThesisRepositoryInterface(ctrl)
+	mockAchievementRepo := mockAchievementRepo.NewMockAchievementRepositoryInterface(ctrl)
+	mockActivityRepo := mockActivityRepo.NewMockActivityRepositoryInterface(ctrl)
+	mockModelService := mockFuzzyModelService.NewMockFuzzyModelServiceInterface(ctrl)
+
+	// Tanpa ekspektasi predicateRepo: evaluasi bayangan tidak menyimpan predikat
+	fuzzyService := &FuzzyService{
+		academicRepo:    mockAcademicRepo,
+		thesisRepo:      mockThesisRepo,
+		achievementRepo: mockAchievementRepo,
+		activityRepo:    mockActivityRepo,
+		predicateRepo:   mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl),
+		modelService:    mockModelService,
+	}
+	ctx := context.Background()
+
+	// Pita Magna Cum Laude diperlebar ke bawah hingga 2.9
+	def := model.Default().Definition()
+	for i := range def.Outputs {
+		switch def.Outputs[i].Predicate {
+		case "Magna Cum Laude":
+			def.Outputs[i].MinScore = 2.9
+		case "Cum Laude":
+			def.Outputs[i].MaxScore = 2.9
+		}
+	}
+	candidate, err := model.Compile(def)
+	assert.NoError(t, err)
+	candidate.Version = 2
+
+	t.Run("Success", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+		mockModelService.EXPECT().CompileModel(ctx, 2).Return(candidate, nil)
+		mockAcademicRepo.EXPECT().GetAllAcademics(ctx).Return([]*models.Academic{
+			{ID: 1, UserID: 1, Ipk: 3.75, Semester: 8, RepeatedCourses: 1},
+			{ID: 2, UserID: 2, Ipk: 3.2, Semester: 9, RepeatedCourses: 2},
+			{ID: 3, UserID: 1, Ipk: 2.0, Semester: 14, RepeatedCourses: 5},
+		}, nil)
+		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, 1).Return([]*models.Thesis{{UserID: 1, Level: "nasional"}}, nil)
+		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, 1).Return([]*models.Achievement{{UserID: 1, Level: models.LevelNasional, Rank: 2}}, nil)
+		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, 1).Return([]*models.Activity{{UserID: 1}, {UserID: 1}}, nil)
+		mockThesisRepo.EXPECT().GetThesesByUserID(ctx, 2).Return(nil, nil)
+		mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, 2).Return(nil, nil)
+		mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, 2).Return(nil, nil)
+
+		result, err := fuzzyService.Shadow(ctx, &dto.ShadowRequestDTO{Version: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.CurrentVersion)
+		assert.Equal(t, 2, result.CandidateVersion)
+		assert.Equal(t, "tsukamoto", result.Engine)
+		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, 1, result.Changed)
+		assert.Equal(t, 1, result.Upgraded)
+		assert.Equal(t, 1, result.Matrix["Cum Laude"]["Magna Cum Laude"])
+		if assert.Len(t, result.Affected, 1) {
+			assert.Equal(t, 1, result.Affected[0].StudentID)
+			assert.Equal(t, "Cum Laude", result.Affected[0].From)
+			assert.Equal(t, "Magna Cum Laude", result.Affected[0].To)
+		}
+	})
+
+	t.Run("Invalid Student", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+		mockModelService.EXPECT().CompileModel(ctx, 2).Return(candidate, nil)
+		mockAcademicRepo.EXPECT().GetAllAcademics(ctx).Return([]*models.Academic{
+			{ID: 1, UserID: 1, Ipk: 3.75, Semester: 8, RepeatedCourses: 1},
+			{ID: 2, UserID: 2, Ipk: 4.5, Semester: 9, RepeatedCourses: 2},
+		}, nil)
+		for _, studentID := range []int{1, 2} {
+			mockThesisRepo.EXPECT().GetThesesByUserID(ctx, studentID).Return(nil, nil)
+			mockAchievementRepo.EXPECT().GetAchievementsByUserID(ctx, studentID).Return(nil, nil)
+			mockActivityRepo.EXPECT().GetActivitiesByUserID(ctx, studentID).Return(nil, nil)
+		}
+
+		result, err := fuzzyService.Shadow(ctx, &dto.ShadowRequestDTO{Version: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Total)
+		if assert.Len(t, result.Invalid, 1) {
+			assert.Equal(t, 2, result.Invalid[0].StudentID)
+			assert.Equal(t, "ipk", result.Invalid[0].Problems[0].Field)
+		}
+	})
+
+	t.Run("Candidate Not Found", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+		mockModelService.EXPECT().CompileModel(ctx, 9).Return(nil, mockFuzzyModelService.ErrModelNotFound)
+
+		result, err := fuzzyService.Shadow(ctx, &dto.ShadowRequestDTO{Version: 9})
+
+		assert.ErrorIs(t, err, mockFuzzyModelService.ErrModelNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Unknown Engine", func(t *testing.T) {
+		result, err := fuzzyService.Shadow(ctx, &dto.ShadowRequestDTO{Version: 2, Engine: "unknown"})
+
+		assert.ErrorIs(t, err, inferensia.ErrUnknownEngine)
+		assert.Nil(t, result)
+	})
+}
+
+func TestTrain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockModelService := mockFuzzyModelService.NewMockFuzzyModelServiceInterface(ctrl)
+
+	// Tanpa ekspektasi repository: sampel pelatihan berupa masukan mentah
+	fuzzyService := &FuzzyService{
+		academicRepo:    mockAcademicRepo.NewMockAcademicRepositoryInterface(ctrl),
+		thesisRepo:      mockThesisRepo.NewMockThesisRepositoryInterface(ctrl),
+		achievementRepo: mockAchievementRepo.NewMockAchievementRepositoryInterface(ctrl),
+		activityRepo:    mockActivityRepo.NewMockActivityRepositoryInterface(ctrl),
+		predicateRepo:   mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl),
+		modelService:    mockModelService,
+	}
+	ctx := context.Background()
+
+	// Label diambil dari model bawaan sehingga model awal sudah tepat
+	var samples []dto.LabeledSampleDTO
+	for _, input := range []dto.WhatIfRequestDTO{
+		{IPK: 3.75, Semester: 8, MataKuliahUlang: 1, Prestasi: []dto.AchievementInputDTO{{Level: "nasional", Rank: 2}}, Skripsi: &dto.ThesisInputDTO{Level: "nasional"}, JumlahAktivitas: 2},
+		{IPK: 3.2, Semester: 9, MataKuliahUlang: 2},
+		{IPK: 2.8, Semester: 10, MataKuliahUlang: 3},
+		{IPK: 3.5, Semester: 8, Skripsi: &dto.ThesisInputDTO{Level: "internasional"}, JumlahAktivitas: 4},
+	} {
+		in := analysisInput(whatIfResponse(&input))
+		predicate := inferensia.Evaluate(model.Default(), in.Crisp, in.Levels).Predicate
+		samples = append(samples, dto.LabeledSampleDTO{WhatIfRequestDTO: input, Predicate: predicate})
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+		mockModelService.EXPECT().CreateDraft(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, req *fuzzyModelDto.FuzzyModelRequest) (*fuzzyModelDto.FuzzyModelResponse, error) {
+			assert.Empty(t, model.Validate(*req.Definition))
+			assert.Equal(t, "Trained on 4 graduates from version 0 (cross-validated accuracy 100.0%)", req.Description)
+			return &fuzzyModelDto.FuzzyModelResponse{Version: 2, Status: "draft", Definition: req.Definition}, nil
+		})
+
+		result, err := fuzzyService.Train(ctx, &dto.TrainRequestDTO{
+			Samples:        samples,
+			Folds:          2,
+			MaxEvaluations: 50,
+			Variables:      []string{"ipk"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Draft.Version)
+		assert.Equal(t, 4, result.Samples)
+		assert.Equal(t, 1.0, result.BaselineAccuracy)
+		assert.Equal(t, 1.0, result.TrainAccuracy)
+		assert.Len(t, result.CrossValidation.Folds, 2)
+	})
+
+	t.Run("Unknown Predicate", func(t *testing.T) {
+		mockModelService.EXPECT().CompileModel(ctx, 3).Return(model.Default(), nil)
+
+		result, err := fuzzyService.Train(ctx, &dto.TrainRequestDTO{
+			BaseVersion: 3,
+			Samples:     append([]dto.LabeledSampleDTO{{WhatIfRequestDTO: samples[0].WhatIfRequestDTO, Predicate: "Istimewa"}}, samples...),
+			Folds:       2,
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidTrainingData)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Invalid Sample", func(t *testing.T) {
+		mockModelService.EXPECT().GetPublishedModel(ctx).Return(model.Default(), nil)
+		invalidSample := dto.LabeledSampleDTO{WhatIfRequestDTO: dto.WhatIfRequestDTO{IPK: 4.2, Semester: 8}, Predicate: "Cum Laude"}
+
+		result, err := fuzzyService.Train(ctx, &dto.TrainRequestDTO{
+			Samples: []dto.LabeledSampleDTO{samples[0], invalidSample},
+			Folds:   2,
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidTrainingData)
+		assert.ErrorContains(t, err, "samples[1]: invalid fuzzy input: ipk 4.2 is outside the universe [0, 4]")
+		var outOfUniverse *inferensia.ErrOutOfUniverse
+		assert.ErrorAs(t, err, &outOfUniverse)
+		assert.Nil(t, result)
+	})
+
+	t.Run("No Samples", func(t *testing.T) {
+		result, err := fuzzyService.Train(ctx, &dto.TrainRequestDTO{})
+
+		assert.ErrorIs(t, err, ErrInvalidTrainingData)
+		assert.Nil(t, result)
+	})
+}
+
+func TestDescribeChange(t *testing.T) {
+	assert.Equal(t, "ipk 3.6 → 3.75", describeChange(analisis.Change{Variable: "ipk", From: 3.6, To: 3.75}))
+	assert.Equal(t, "achievement level none → internal", describeChange(analisis.Change{Variable: "achievement", To: 3, ToLevel: "internal"}))
+}
+
+func TestInputField(t *testing.T) {
+	input := &dto.FuzzyResponseDTO{PrestasiIndex: 2}
+	assert.Equal(t, "semester", inputField(input, "studyDuration", false))
+	assert.Equal(t, "prestasi[2].rank", inputField(input, "achievement", false))
+	assert.Equal(t, "prestasi[2].level", inputField(input, "achievement", true))
+	assert.Equal(t, "skripsi.level", inputField(input, "thesis", false))
+	assert.Equal(t, "skripsi.level", inputField(input, "thesis", true))
+	assert.Equal(t, "custom", inputField(input, "custom", false))
+}
+
 func TestGetBestAchievement(t *testing.T) {
 	t.Run("Empty Achievements", func(t *testing.T) {
 		achievements := []*models.Achievement{}
@@ -393,6 +1134,7 @@ func TestGetBestAchievement(t *testing.T) {
 		best := getBestAchievement(achievements)
 		assert.Equal(t, 2, best.ID) // International level has highest priority
 		assert.Equal(t, models.LevelInternasional, best.Level)
+		assert.Equal(t, 1, bestAchievementIndex(achievements))
 	})
 
 	t.Run("Multiple Achievements - Same Level", func(t *testing.T) {
@@ -429,6 +1171,7 @@ func TestGetLevelPriority(t *testing.T) {
 		{"International", models.LevelInternasional, 3},
 		{"National", models.LevelNasional, 2},
 		{"Internal", models.LevelInternal, 1},
+		{"Mixed Case", "Nasional", 2},
 		{"Unknown", "unknown", 0},
 	}
 
@@ -449,6 +1192,7 @@ func TestCalculateThesisImpact(t *testing.T) {
 		{"International", "internasional", 5.0},
 		{"National", "nasional", 3.0},
 		{"Internal", "internal", 1.0},
+		{"Mixed Case", "Internasional", 5.0},
 		{"Default", "", 1.0},
 	}
 
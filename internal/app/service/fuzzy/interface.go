@@ -8,20 +8,29 @@ import (
 	activityRepo "go-tsukamoto/internal/app/repository/activity"
 	predicateRepo "go-tsukamoto/internal/app/repository/predicate"
 	thesisRepo "go-tsukamoto/internal/app/repository/thesis"
+	fuzzyModelService "go-tsukamoto/internal/app/service/fuzzymodel"
 
 	"gorm.io/gorm"
 )
 
-func NewService(db *gorm.DB) FuzzyServiceInterface {
+// NewService memakai modelService yang sama dengan handler model agar model
+// terbit yang tersimpan ikut diganti saat publikasi atau rollback
+func NewService(db *gorm.DB, modelService fuzzyModelService.FuzzyModelServiceInterface) FuzzyServiceInterface {
 	return &FuzzyService{
 		academicRepo:    academicRepo.NewAcademicRepository(db),
 		thesisRepo:      thesisRepo.NewThesisRepository(db),
 		achievementRepo: achievementRepo.NewAchievementRepository(db),
 		activityRepo:    activityRepo.NewActivityRepository(db),
 		predicateRepo:   predicateRepo.NewPredicateRepository(db),
+		modelService:    modelService,
 	}
 }
 
 type FuzzyServiceInterface interface {
-	CalculateFuzzy(ctx context.Context, studentID int) (*dto.FuzzyResponseDTO, error)
+	CalculateFuzzy(ctx context.Context, req *dto.FuzzyRequestDTO) (*dto.FuzzyResponseDTO, error)
+	WhatIf(ctx context.Context, req *dto.WhatIfRequestDTO) (*dto.FuzzyResponseDTO, error)
+	Sensitivity(ctx context.Context, req *dto.SensitivityRequestDTO) (*dto.SensitivityResponseDTO, error)
+	Counterfactuals(ctx context.Context, req *dto.CounterfactualRequestDTO) (*dto.CounterfactualResponseDTO, error)
+	Shadow(ctx context.Context, req *dto.ShadowRequestDTO) (*dto.ShadowResponseDTO, error)
+	Train(ctx context.Context, req *dto.TrainRequestDTO) (*dto.TrainResponseDTO, error)
 }
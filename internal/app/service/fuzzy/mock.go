@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/service/fuzzy/interface.go
+// Source: interface.go
 
 // Package fuzzy is a generated GoMock package.
 package fuzzy
@@ -36,16 +36,91 @@ func (m *MockFuzzyServiceInterface) EXPECT() *MockFuzzyServiceInterfaceMockRecor
 }
 
 // CalculateFuzzy mocks base method.
-func (m *MockFuzzyServiceInterface) CalculateFuzzy(ctx context.Context, studentID int) (*dto.FuzzyResponseDTO, error) {
+func (m *MockFuzzyServiceInterface) CalculateFuzzy(ctx context.Context, req *dto.FuzzyRequestDTO) (*dto.FuzzyResponseDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalculateFuzzy", ctx, studentID)
+	ret := m.ctrl.Call(m, "CalculateFuzzy", ctx, req)
 	ret0, _ := ret[0].(*dto.FuzzyResponseDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CalculateFuzzy indicates an expected call of CalculateFuzzy.
-func (mr *MockFuzzyServiceInterfaceMockRecorder) CalculateFuzzy(ctx, studentID interface{}) *gomock.Call {
+func (mr *MockFuzzyServiceInterfaceMockRecorder) CalculateFuzzy(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateFuzzy", reflect.TypeOf((*MockFuzzyServiceInterface)(nil).CalculateFuzzy), ctx, studentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateFuzzy", reflect.TypeOf((*MockFuzzyServiceInterface)(nil).CalculateFuzzy), ctx, req)
+}
+
+// Counterfactuals mocks base method.
+func (m *MockFuzzyServiceInterface) Counterfactuals(ctx context.Context, req *dto.CounterfactualRequestDTO) (*dto.CounterfactualResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Counterfactuals", ctx, req)
+	ret0, _ := ret[0].(*dto.CounterfactualResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Counterfactuals indicates an expected call of Counterfactuals.
+func (mr *MockFuzzyServiceInterfaceMockRecorder) Counterfactuals(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counterfactuals", reflect.TypeOf((*MockFuzzyServiceInterface)(nil).Counterfactuals), ctx, req)
+}
+
+// Sensitivity mocks base method.
+func (m *MockFuzzyServiceInterface) Sensitivity(ctx context.Context, req *dto.SensitivityRequestDTO) (*dto.SensitivityResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sensitivity", ctx, req)
+	ret0, _ := ret[0].(*dto.SensitivityResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sensitivity indicates an expected call of Sensitivity.
+func (mr *MockFuzzyServiceInterfaceMockRecorder) Sensitivity(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sensitivity", reflect.TypeOf((*MockFuzzyServiceInterface)(nil).Sensitivity), ctx, req)
+}
+
+// Shadow mocks base method.
+func (m *MockFuzzyServiceInterface) Shadow(ctx context.Context, req *dto.ShadowRequestDTO) (*dto.ShadowResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shadow", ctx, req)
+	ret0, _ := ret[0].(*dto.ShadowResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Shadow indicates an expected call of Shadow.
+func (mr *MockFuzzyServiceInterfaceMockRecorder) Shadow(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shadow", reflect.TypeOf((*MockFuzzyServiceInterface)(nil).Shadow), ctx, req)
+}
+
+// Train mocks base method.
+func (m *MockFuzzyServiceInterface) Train(ctx context.Context, req *dto.TrainRequestDTO) (*dto.TrainResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Train", ctx, req)
+	ret0, _ := ret[0].(*dto.TrainResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Train indicates an expected call of Train.
+func (mr *MockFuzzyServiceInterfaceMockRecorder) Train(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Train", reflect.TypeOf((*MockFuzzyServiceInterface)(nil).Train), ctx, req)
+}
+
+// WhatIf mocks base method.
+func (m *MockFuzzyServiceInterface) WhatIf(ctx context.Context, req *dto.WhatIfRequestDTO) (*dto.FuzzyResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WhatIf", ctx, req)
+	ret0, _ := ret[0].(*dto.FuzzyResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WhatIf indicates an expected call of WhatIf.
+func (mr *MockFuzzyServiceInterfaceMockRecorder) WhatIf(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WhatIf", reflect.TypeOf((*MockFuzzyServiceInterface)(nil).WhatIf), ctx, req)
 }
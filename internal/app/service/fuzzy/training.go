@@ -0,0 +1,95 @@
+package fuzzy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	dto "go-tsukamoto/internal/app/dto/fuzzy"
+	fuzzyModelDto "go-tsukamoto/internal/app/dto/fuzzymodel"
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/pelatihan"
+)
+
+var ErrInvalidTrainingData = errors.New("invalid training data")
+
+// Train menyetel titik potong fungsi keanggotaan dan bobot faktor dari
+// lulusan berlabel lalu menyimpan hasilnya sebagai draf baru. Masukan mentah
+// dipetakan seperti /fuzzy/what-if sehingga draf dapat langsung dipakai.
+func (s *FuzzyService) Train(ctx context.Context, req *dto.TrainRequestDTO) (*dto.TrainResponseDTO, error) {
+	engine, err := inferensia.ParseEngine(req.Engine)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Samples) == 0 {
+		return nil, fmt.Errorf("%w: samples are required", ErrInvalidTrainingData)
+	}
+
+	var base *model.Model
+	if req.BaseVersion == 0 {
+		base, err = s.modelService.GetPublishedModel(ctx)
+	} else {
+		base, err = s.modelService.CompileModel(ctx, req.BaseVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]pelatihan.Sample, 0, len(req.Samples))
+	for i, labeled := range req.Samples {
+		input := whatIfResponse(&labeled.WhatIfRequestDTO)
+		if err := validateInput(base, input); err != nil {
+			return nil, fmt.Errorf("%w: samples[%d]: %w", ErrInvalidTrainingData, i, err)
+		}
+		in := analysisInput(input)
+		samples = append(samples, pelatihan.Sample{Crisp: in.Crisp, Levels: in.Levels, Predicate: labeled.Predicate})
+	}
+
+	result, err := pelatihan.Train(base.Definition(), samples, pelatihan.Options{
+		Folds:          req.Folds,
+		MaxEvaluations: req.MaxEvaluations,
+		Variables:      req.Variables,
+		Engine:         engine,
+		Seed:           req.Seed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTrainingData, err)
+	}
+
+	description := req.Description
+	if description == "" {
+		description = fmt.Sprintf("Trained on %d graduates from version %d (cross-validated accuracy %.1f%%)", len(samples), base.Version, 100*result.CrossValidatedAccuracy)
+	}
+	draft, err := s.modelService.CreateDraft(ctx, &fuzzyModelDto.FuzzyModelRequest{
+		Description: description,
+		Definition:  &result.Definition,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.TrainResponseDTO{
+		Draft:            draft,
+		BaseVersion:      base.Version,
+		Samples:          len(samples),
+		Parameters:       result.Parameters,
+		Evaluations:      result.Evaluations,
+		BaselineAccuracy: result.BaselineAccuracy,
+		TrainAccuracy:    result.TrainAccuracy,
+		CrossValidation: dto.CrossValidationDTO{
+			Accuracy:         result.CrossValidatedAccuracy,
+			BaselineAccuracy: result.BaselineCrossValidated,
+		},
+	}
+	for _, fold := range result.Folds {
+		response.CrossValidation.Folds = append(response.CrossValidation.Folds, dto.FoldDTO{
+			Train:            fold.Train,
+			Test:             fold.Test,
+			TrainAccuracy:    fold.TrainAccuracy,
+			TestAccuracy:     fold.TestAccuracy,
+			BaselineAccuracy: fold.BaselineAccuracy,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,505 @@
+package fuzzymodel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	dto "go-tsukamoto/internal/app/dto/fuzzymodel"
+	"go-tsukamoto/internal/app/models"
+	repo "go-tsukamoto/internal/app/repository/fuzzymodel"
+	"go-tsukamoto/internal/modules/analisis"
+	"go-tsukamoto/internal/modules/fcl"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+)
+
+var (
+	ErrModelNotFound     = errors.New("fuzzy model not found")
+	ErrModelNotDraft     = errors.New("only draft models can be changed")
+	ErrNoPreviousVersion = errors.New("no previous published version to roll back to")
+	ErrVersionConflict   = errors.New("fuzzy model version was taken by a concurrent draft, please retry")
+)
+
+// draftAttempts adalah jumlah percobaan membuat draf ketika nomor versinya
+// sudah lebih dulu dipakai draf lain yang dibuat bersamaan
+const draftAttempts = 3
+
+// InvalidModelError dikembalikan saat draf yang tidak valid akan dipublikasikan
+type InvalidModelError struct {
+	Problems []string
+}
+
+func (e *InvalidModelError) Error() string {
+	return fmt.Sprintf("invalid fuzzy model: %s", strings.Join(e.Problems, "; "))
+}
+
+func (s *fuzzyModelService) GetModels(ctx context.Context) ([]*dto.FuzzyModelResponse, error) {
+	fuzzyModels, err := s.repo.GetModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []*dto.FuzzyModelResponse{}
+	for _, fuzzyModel := range fuzzyModels {
+		responses = append(responses, toResponse(fuzzyModel, false))
+	}
+	return responses, nil
+}
+
+func (s *fuzzyModelService) GetModel(ctx context.Context, version int) (*dto.FuzzyModelResponse, error) {
+	fuzzyModel, err := s.getModel(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(fuzzyModel, true), nil
+}
+
+func (s *fuzzyModelService) CreateDraft(ctx context.Context, req *dto.FuzzyModelRequest) (*dto.FuzzyModelResponse, error) {
+	var def model.Definition
+	if req.Definition != nil {
+		def = *req.Definition
+	} else {
+		// Draf baru tanpa definisi dimulai dari model yang sedang dipakai
+		published, err := s.GetPublishedModel(ctx)
+		if err != nil {
+			return nil, err
+		}
+		def = published.Definition()
+	}
+
+	// Versi baru dibaca dari versi terakhir lalu dijaga indeks unik; jika
+	// draf lain yang dibuat bersamaan lebih dulu memakainya, versi dibaca ulang
+	for attempt := 0; attempt < draftAttempts; attempt++ {
+		latest, err := s.repo.GetLatestVersion(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		fuzzyModel := toRecord(def)
+		fuzzyModel.Version = latest + 1
+		fuzzyModel.Status = models.FuzzyModelDraft
+		fuzzyModel.Description = req.Description
+		err = s.repo.CreateModel(ctx, fuzzyModel)
+		if errors.Is(err, repo.ErrVersionExists) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return toResponse(fuzzyModel, true), nil
+	}
+	return nil, ErrVersionConflict
+}
+
+func (s *fuzzyModelService) UpdateDraft(ctx context.Context, version int, req *dto.FuzzyModelRequest) (*dto.FuzzyModelResponse, error) {
+	fuzzyModel, err := s.getDraft(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Definition != nil {
+		applyDefinition(fuzzyModel, *req.Definition)
+	}
+	if req.Description != "" {
+		fuzzyModel.Description = req.Description
+	}
+
+	if err := s.repo.ReplaceModel(ctx, fuzzyModel); err != nil {
+		return nil, err
+	}
+	return toResponse(fuzzyModel, true), nil
+}
+
+func (s *fuzzyModelService) DeleteDraft(ctx context.Context, version int) error {
+	if _, err := s.getDraft(ctx, version); err != nil {
+		return err
+	}
+	return s.repo.DeleteModel(ctx, version)
+}
+
+func (s *fuzzyModelService) ValidateModel(ctx context.Context, version int) (*dto.ValidationResponse, error) {
+	fuzzyModel, err := s.getModel(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	problems, err := s.validate(ctx, toDefinition(fuzzyModel))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ValidationResponse{
+		Version: version,
+		Valid:   len(problems) == 0,
+		Errors:  problems,
+	}, nil
+}
+
+// CheckRules menjalankan pemeriksaan statis basis aturan sebuah versi model:
+// celah cakupan, konflik, predikat yang tidak tercapai dan himpunan yang
+// tidak dirujuk. Definisi yang tidak valid tidak dapat diperiksa.
+func (s *fuzzyModelService) CheckRules(ctx context.Context, version int, req *dto.RuleCheckRequest) (*dto.RuleCheckResponse, error) {
+	compiled, err := s.CompileModel(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	report := analisis.CheckRuleBase(compiled, analisis.RuleCheckOptions{
+		Threshold: req.Threshold,
+		Samples:   req.Samples,
+		MaxGaps:   req.MaxGaps,
+	})
+	response := &dto.RuleCheckResponse{
+		Version: version,
+		Valid:   report.Valid(),
+		Regions: report.Regions,
+		Gaps:    report.Gaps,
+		Issues:  []dto.RuleIssueDTO{},
+	}
+	for _, issue := range report.Issues {
+		issueDTO := dto.RuleIssueDTO{
+			Kind:      issue.Kind,
+			Message:   issue.Message,
+			Region:    issue.Region,
+			Strength:  issue.Strength,
+			Rules:     issue.Rules,
+			Predicate: issue.Predicate,
+			Variable:  issue.Variable,
+			Term:      issue.Term,
+		}
+		if issue.Input != nil {
+			issueDTO.Input = issue.Input.Crisp
+			issueDTO.Levels = issue.Input.Levels
+		}
+		response.Issues = append(response.Issues, issueDTO)
+	}
+	return response, nil
+}
+
+// ExportFCL menuliskan sebuah versi model sebagai berkas FCL (IEC 61131-7)
+func (s *fuzzyModelService) ExportFCL(ctx context.Context, version int) ([]byte, error) {
+	compiled, err := s.CompileModel(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	return fcl.Export(compiled), nil
+}
+
+// ImportFCL membaca berkas FCL dan menyimpannya sebagai draf baru. Berkas
+// yang tidak dapat diurai atau menghasilkan model tidak valid ditolak.
+func (s *fuzzyModelService) ImportFCL(ctx context.Context, data []byte, description string) (*dto.FuzzyModelResponse, error) {
+	def, err := fcl.Import(data)
+	if err != nil {
+		return nil, &InvalidModelError{Problems: []string{fmt.Sprintf("fcl: %v", err)}}
+	}
+	if problems := model.Validate(def); len(problems) > 0 {
+		return nil, &InvalidModelError{Problems: problems}
+	}
+	return s.CreateDraft(ctx, &dto.FuzzyModelRequest{Description: description, Definition: &def})
+}
+
+func (s *fuzzyModelService) PublishModel(ctx context.Context, version int) (*dto.FuzzyModelResponse, error) {
+	fuzzyModel, err := s.getDraft(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	problems, err := s.validate(ctx, toDefinition(fuzzyModel))
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return nil, &InvalidModelError{Problems: problems}
+	}
+	if err := s.repo.PublishModel(ctx, version); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return s.GetModel(ctx, version)
+}
+
+// RollbackModel mempublikasikan ulang versi terakhir sebelum versi yang
+// sedang dipublikasikan
+func (s *fuzzyModelService) RollbackModel(ctx context.Context) (*dto.FuzzyModelResponse, error) {
+	current, err := s.repo.GetPublishedModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, ErrNoPreviousVersion
+	}
+
+	fuzzyModels, err := s.repo.GetModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var previous *models.FuzzyModel
+	for _, fuzzyModel := range fuzzyModels {
+		if fuzzyModel.Status != models.FuzzyModelArchived || fuzzyModel.PublishedAt == nil || fuzzyModel.Version >= current.Version {
+			continue
+		}
+		if previous == nil || fuzzyModel.Version > previous.Version {
+			previous = fuzzyModel
+		}
+	}
+	if previous == nil {
+		return nil, ErrNoPreviousVersion
+	}
+
+	if err := s.repo.PublishModel(ctx, previous.Version); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return s.GetModel(ctx, previous.Version)
+}
+
+// GetPublishedModel mengembalikan model yang dipakai inferensi. Jika belum
+// ada versi yang dipublikasikan, dipakai model bawaan. Model dimuat dari tabel
+// lalu disimpan sampai PublishModel atau RollbackModel mengganti versi terbit
+// atau publishedTTL habis, sehingga inferensi di antaranya tidak membaca
+// tabel model.
+func (s *fuzzyModelService) GetPublishedModel(ctx context.Context) (*model.Model, error) {
+	s.mu.RLock()
+	published, fresh := s.published, time.Since(s.loadedAt) < publishedTTL
+	s.mu.RUnlock()
+	if published != nil && fresh {
+		return published, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.published != nil && time.Since(s.loadedAt) < publishedTTL {
+		return s.published, nil
+	}
+	published, err := s.loadPublishedModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.published = published
+	s.loadedAt = time.Now()
+	return published, nil
+}
+
+func (s *fuzzyModelService) loadPublishedModel(ctx context.Context) (*model.Model, error) {
+	fuzzyModel, err := s.repo.GetPublishedModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if fuzzyModel == nil {
+		return model.Default(), nil
+	}
+
+	compiled, err := model.Compile(toDefinition(fuzzyModel))
+	if err != nil {
+		return nil, fmt.Errorf("published fuzzy model version %d is invalid: %v", fuzzyModel.Version, err)
+	}
+	compiled.Version = fuzzyModel.Version
+	return compiled, nil
+}
+
+// invalidate membuang model terbit yang tersimpan agar pemanggilan
+// GetPublishedModel berikutnya memuat versi terbit yang baru
+func (s *fuzzyModelService) invalidate() {
+	s.mu.Lock()
+	s.published = nil
+	s.mu.Unlock()
+}
+
+// CompileModel menyiapkan sebuah versi model, termasuk draf, agar dapat
+// dijalankan. Definisi yang tidak valid dikembalikan sebagai InvalidModelError.
+func (s *fuzzyModelService) CompileModel(ctx context.Context, version int) (*model.Model, error) {
+	fuzzyModel, err := s.getModel(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	def := toDefinition(fuzzyModel)
+	if problems := model.Validate(def); len(problems) > 0 {
+		return nil, &InvalidModelError{Problems: problems}
+	}
+	compiled, err := model.Compile(def)
+	if err != nil {
+		return nil, err
+	}
+	compiled.Version = fuzzyModel.Version
+	return compiled, nil
+}
+
+// validate memeriksa definisi model dan memastikan setiap predikat keluaran
+// terdaftar pada tabel predikat, karena hasil perhitungan disimpan dengan
+// merujuk baris predikat tersebut
+func (s *fuzzyModelService) validate(ctx context.Context, def model.Definition) ([]string, error) {
+	problems := model.Validate(def)
+	for _, output := range def.Outputs {
+		predicate, err := s.predicateRepo.GetByName(ctx, output.Predicate)
+		if err != nil {
+			return nil, err
+		}
+		if predicate == nil {
+			problems = append(problems, fmt.Sprintf("outputs: predicate %q is not registered", output.Predicate))
+		}
+	}
+	return problems, nil
+}
+
+func (s *fuzzyModelService) getModel(ctx context.Context, version int) (*models.FuzzyModel, error) {
+	fuzzyModel, err := s.repo.GetModelByVersion(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if fuzzyModel == nil {
+		return nil, ErrModelNotFound
+	}
+	return fuzzyModel, nil
+}
+
+func (s *fuzzyModelService) getDraft(ctx context.Context, version int) (*models.FuzzyModel, error) {
+	fuzzyModel, err := s.getModel(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if fuzzyModel.Status != models.FuzzyModelDraft {
+		return nil, ErrModelNotDraft
+	}
+	return fuzzyModel, nil
+}
+
+// toRecord memetakan definisi model ke baris tabel
+func toRecord(def model.Definition) *models.FuzzyModel {
+	fuzzyModel := &models.FuzzyModel{}
+	applyDefinition(fuzzyModel, def)
+	return fuzzyModel
+}
+
+// applyDefinition menimpa seluruh kolom baris yang berasal dari definisi
+// model; versi, status dan deskripsi tidak diubah
+func applyDefinition(fuzzyModel *models.FuzzyModel, def model.Definition) {
+	fuzzyModel.Defuzzifier = def.Defuzzifier
+	fuzzyModel.AndOperator = def.And
+	fuzzyModel.OrOperator = def.Or
+	fuzzyModel.FallbackPredicate = ""
+	fuzzyModel.FallbackStrength = 0
+	fuzzyModel.FallbackOutput = nil
+	fuzzyModel.Variables = nil
+	fuzzyModel.Rules = nil
+	fuzzyModel.Outputs = nil
+	if def.Fallback != nil {
+		fuzzyModel.FallbackPredicate = def.Fallback.Predicate
+		fuzzyModel.FallbackStrength = def.Fallback.Strength
+		fuzzyModel.FallbackOutput = toSugenoOutput(def.Fallback.Output)
+	}
+	for i, vs := range def.Variables {
+		variable := models.FuzzyVariable{
+			Position: i,
+			Name:     vs.Name,
+			Min:      vs.Min,
+			Max:      vs.Max,
+			OpenMax:  vs.OpenMax,
+			Weight:   def.Weights[vs.Name],
+		}
+		for j, ts := range vs.Terms {
+			term := models.FuzzyTerm{
+				Position: j,
+				Name:     ts.Name,
+				Type:     ts.Type,
+				Params:   ts.Params,
+				Levels:   ts.Levels,
+			}
+			if ts.Lower != nil {
+				term.LowerType = ts.Lower.Type
+				term.LowerParams = ts.Lower.Params
+			}
+			variable.Terms = append(variable.Terms, term)
+		}
+		fuzzyModel.Variables = append(fuzzyModel.Variables, variable)
+	}
+	for i, rs := range def.Rules {
+		fuzzyModel.Rules = append(fuzzyModel.Rules, models.FuzzyRule{Position: i, Rule: rs.Rule, When: rs.When, Output: toSugenoOutput(rs.Output)})
+	}
+	for i, os := range def.Outputs {
+		fuzzyModel.Outputs = append(fuzzyModel.Outputs, models.FuzzyOutput{
+			Position:   i,
+			Predicate:  os.Predicate,
+			Low:        os.Low,
+			High:       os.High,
+			Increasing: os.Increasing,
+			MinScore:   os.MinScore,
+			MaxScore:   os.MaxScore,
+		})
+	}
+}
+
+func toSugenoOutput(output *rules.Consequent) *models.SugenoOutput {
+	if output == nil {
+		return nil
+	}
+	return &models.SugenoOutput{Constant: output.Constant, Coefficients: output.Coefficients}
+}
+
+func toConsequent(output *models.SugenoOutput) *rules.Consequent {
+	if output == nil {
+		return nil
+	}
+	return &rules.Consequent{Constant: output.Constant, Coefficients: output.Coefficients}
+}
+
+// toDefinition memetakan baris tabel kembali ke definisi model
+func toDefinition(fuzzyModel *models.FuzzyModel) model.Definition {
+	def := model.Definition{Defuzzifier: fuzzyModel.Defuzzifier}
+	def.And = fuzzyModel.AndOperator
+	def.Or = fuzzyModel.OrOperator
+	def.Weights = map[string]float64{}
+	if fuzzyModel.FallbackPredicate != "" {
+		def.Fallback = &rules.FallbackSpec{
+			Predicate: fuzzyModel.FallbackPredicate,
+			Strength:  fuzzyModel.FallbackStrength,
+			Output:    toConsequent(fuzzyModel.FallbackOutput),
+		}
+	}
+	for _, variable := range fuzzyModel.Variables {
+		vs := model.VariableSpec{Name: variable.Name, Min: variable.Min, Max: variable.Max, OpenMax: variable.OpenMax}
+		for _, term := range variable.Terms {
+			ts := model.TermSpec{
+				Name:   term.Name,
+				Type:   term.Type,
+				Params: term.Params,
+				Levels: term.Levels,
+			}
+			if term.LowerType != "" {
+				ts.Lower = &model.FunctionSpec{Type: term.LowerType, Params: term.LowerParams}
+			}
+			vs.Terms = append(vs.Terms, ts)
+		}
+		def.Variables = append(def.Variables, vs)
+		def.Weights[variable.Name] = variable.Weight
+	}
+	for _, rule := range fuzzyModel.Rules {
+		def.Rules = append(def.Rules, rules.RuleSpec{Rule: rule.Rule, When: rule.When, Output: toConsequent(rule.Output)})
+	}
+	for _, output := range fuzzyModel.Outputs {
+		def.Outputs = append(def.Outputs, model.OutputSpec{
+			Predicate:  output.Predicate,
+			Low:        output.Low,
+			High:       output.High,
+			Increasing: output.Increasing,
+			MinScore:   output.MinScore,
+			MaxScore:   output.MaxScore,
+		})
+	}
+	return def
+}
+
+func toResponse(fuzzyModel *models.FuzzyModel, withDefinition bool) *dto.FuzzyModelResponse {
+	response := &dto.FuzzyModelResponse{
+		Version:     fuzzyModel.Version,
+		Status:      string(fuzzyModel.Status),
+		Description: fuzzyModel.Description,
+		PublishedAt: fuzzyModel.PublishedAt,
+		CreatedAt:   fuzzyModel.CreatedAt,
+		UpdatedAt:   fuzzyModel.UpdatedAt,
+	}
+	if withDefinition {
+		def := toDefinition(fuzzyModel)
+		response.Definition = &def
+	}
+	return response
+}
@@ -0,0 +1,498 @@
+package fuzzymodel_test
+
+import (
+	"context"
+	"errors"
+	dto "go-tsukamoto/internal/app/dto/fuzzymodel"
+	"go-tsukamoto/internal/app/models"
+	mockFuzzyModelRepo "go-tsukamoto/internal/app/repository/fuzzymodel"
+	mockPredicateRepo "go-tsukamoto/internal/app/repository/predicate"
+	fuzzyModelService "go-tsukamoto/internal/app/service/fuzzymodel"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+	ctx := context.Background()
+
+	t.Run("Clone Default Model", func(t *testing.T) {
+		mockRepo.EXPECT().GetPublishedModel(ctx).Return(nil, nil)
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(2, nil)
+		mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+			assert.Equal(t, models.FuzzyModelDraft, fuzzyModel.Status)
+			assert.Len(t, fuzzyModel.Variables, len(model.Default().Variables))
+			return nil
+		})
+
+		response, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Description: "tuning"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, response.Version)
+		assert.Equal(t, "draft", response.Status)
+		assert.Equal(t, model.Default().Definition(), *response.Definition)
+	})
+
+	t.Run("Sugeno Output", func(t *testing.T) {
+		def := model.Default().Definition()
+		def.Rules[0].Output = &rules.Consequent{Constant: 0.5, Coefficients: map[string]float64{"ipk": 0.9}}
+		def.Fallback.Output = &rules.Consequent{Constant: 1}
+
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(3, nil)
+		mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+			assert.Equal(t, &models.SugenoOutput{Constant: 0.5, Coefficients: map[string]float64{"ipk": 0.9}}, fuzzyModel.Rules[0].Output)
+			assert.Nil(t, fuzzyModel.Rules[1].Output)
+			return nil
+		})
+
+		response, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+
+		require.NoError(t, err)
+		assert.Equal(t, def, *response.Definition)
+	})
+
+	t.Run("Concurrent Draft", func(t *testing.T) {
+		// Draf lain memakai versi 4 lebih dulu; versi dibaca ulang lalu dicoba lagi
+		def := model.Default().Definition()
+		gomock.InOrder(
+			mockRepo.EXPECT().GetLatestVersion(ctx).Return(3, nil),
+			mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).Return(mockFuzzyModelRepo.ErrVersionExists),
+			mockRepo.EXPECT().GetLatestVersion(ctx).Return(4, nil),
+			mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).Return(nil),
+		)
+
+		response, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, response.Version)
+	})
+
+	t.Run("Version Conflict", func(t *testing.T) {
+		def := model.Default().Definition()
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(3, nil).Times(3)
+		mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).Return(mockFuzzyModelRepo.ErrVersionExists).Times(3)
+
+		response, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+
+		assert.ErrorIs(t, err, fuzzyModelService.ErrVersionConflict)
+		assert.Nil(t, response)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		def := model.Default().Definition()
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(0, errors.New("database error"))
+
+		response, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+}
+
+func TestUpdateDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+	ctx := context.Background()
+
+	// Simpan definisi bawaan sebagai draf
+	var stored *models.FuzzyModel
+	def := model.Default().Definition()
+	mockRepo.EXPECT().GetLatestVersion(ctx).Return(0, nil)
+	mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+		stored = fuzzyModel
+		return nil
+	})
+	_, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+	require.NoError(t, err)
+
+	updated := model.Default().Definition()
+	updated.Defuzzifier = "bisector"
+	updated.And = "product"
+	updated.Or = "probabilistic_sum"
+	updated.Fallback = &rules.FallbackSpec{Predicate: def.Fallback.Predicate, Strength: def.Fallback.Strength, Output: &rules.Consequent{Constant: 1.5}}
+	mockRepo.EXPECT().GetModelByVersion(ctx, 1).Return(stored, nil).Times(2)
+	mockRepo.EXPECT().ReplaceModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+		stored = fuzzyModel
+		return nil
+	})
+
+	_, err = service.UpdateDraft(ctx, 1, &dto.FuzzyModelRequest{Definition: &updated})
+	require.NoError(t, err)
+	response, err := service.GetModel(ctx, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, updated, *response.Definition)
+}
+
+func TestUpdateDraft_NotDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetModelByVersion(ctx, 1).Return(&models.FuzzyModel{Version: 1, Status: models.FuzzyModelPublished}, nil)
+
+	response, err := service.UpdateDraft(ctx, 1, &dto.FuzzyModelRequest{Description: "changed"})
+
+	assert.ErrorIs(t, err, fuzzyModelService.ErrModelNotDraft)
+	assert.Nil(t, response)
+}
+
+func TestValidateModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+	ctx := context.Background()
+
+	// Simpan definisi bawaan sebagai draf
+	var stored *models.FuzzyModel
+	def := model.Default().Definition()
+	mockRepo.EXPECT().GetLatestVersion(ctx).Return(0, nil)
+	mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+		stored = fuzzyModel
+		return nil
+	})
+	_, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+	require.NoError(t, err)
+
+	t.Run("Registered Predicates", func(t *testing.T) {
+		mockRepo.EXPECT().GetModelByVersion(ctx, 1).Return(stored, nil)
+		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(&models.Predicate{ID: 1}, nil).Times(len(def.Outputs))
+
+		response, err := service.ValidateModel(ctx, 1)
+
+		require.NoError(t, err)
+		assert.True(t, response.Valid)
+		assert.Empty(t, response.Errors)
+	})
+
+	t.Run("Unregistered Predicate", func(t *testing.T) {
+		mockRepo.EXPECT().GetModelByVersion(ctx, 1).Return(stored, nil)
+		mockPredicateRepo.EXPECT().GetByName(ctx, "Summa Cum Laude").Return(nil, nil)
+		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(&models.Predicate{ID: 1}, nil).Times(len(def.Outputs) - 1)
+
+		response, err := service.ValidateModel(ctx, 1)
+
+		require.NoError(t, err)
+		assert.False(t, response.Valid)
+		assert.Equal(t, []string{`outputs: predicate "Summa Cum Laude" is not registered`}, response.Errors)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRepo.EXPECT().GetModelByVersion(ctx, 1).Return(stored, nil)
+		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(nil, errors.New("database error"))
+
+		response, err := service.ValidateModel(ctx, 1)
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+}
+
+func TestCheckRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+	ctx := context.Background()
+
+	t.Run("Report Issues", func(t *testing.T) {
+		var stored *models.FuzzyModel
+		def := model.Default().Definition()
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(0, nil)
+		mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+			stored = fuzzyModel
+			return nil
+		})
+		_, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+		require.NoError(t, err)
+		mockRepo.EXPECT().GetModelByVersion(ctx, 1).Return(stored, nil)
+
+		response, err := service.CheckRules(ctx, 1, &dto.RuleCheckRequest{Samples: 100})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, response.Version)
+		assert.False(t, response.Valid)
+		assert.Equal(t, 15625, response.Regions)
+		assert.Contains(t, response.Issues, dto.RuleIssueDTO{
+			Kind:      "unreachable_predicate",
+			Message:   `predicate "Summa Cum Laude" is not produced by any sampled input`,
+			Predicate: "Summa Cum Laude",
+		})
+	})
+
+	t.Run("Invalid Draft", func(t *testing.T) {
+		mockRepo.EXPECT().GetModelByVersion(ctx, 2).Return(&models.FuzzyModel{Version: 2, Status: models.FuzzyModelDraft}, nil)
+
+		response, err := service.CheckRules(ctx, 2, &dto.RuleCheckRequest{})
+
+		var invalid *fuzzyModelService.InvalidModelError
+		require.ErrorAs(t, err, &invalid)
+		assert.Nil(t, response)
+	})
+}
+
+func TestFCL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+	ctx := context.Background()
+
+	t.Run("Export And Import", func(t *testing.T) {
+		var stored *models.FuzzyModel
+		def := model.Default().Definition()
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(0, nil)
+		mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+			stored = fuzzyModel
+			return nil
+		})
+		_, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+		require.NoError(t, err)
+		mockRepo.EXPECT().GetModelByVersion(ctx, 1).Return(stored, nil)
+
+		data, err := service.ExportFCL(ctx, 1)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "(* go-tsukamoto model version 1 *)")
+		assert.Contains(t, string(data), "FUNCTION_BLOCK kelulusan")
+
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(1, nil)
+		mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).Return(nil)
+
+		response, err := service.ImportFCL(ctx, data, "Impor FCL")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, response.Version)
+		assert.Equal(t, "draft", response.Status)
+		assert.Equal(t, "Impor FCL", response.Description)
+		assert.Equal(t, def.Weights, response.Definition.Weights)
+		assert.Equal(t, def.Rules, response.Definition.Rules)
+	})
+
+	t.Run("Export Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().GetModelByVersion(ctx, 9).Return(nil, nil)
+
+		data, err := service.ExportFCL(ctx, 9)
+
+		assert.ErrorIs(t, err, fuzzyModelService.ErrModelNotFound)
+		assert.Nil(t, data)
+	})
+
+	t.Run("Import Invalid File", func(t *testing.T) {
+		response, err := service.ImportFCL(ctx, []byte("FUNCTION_BLOCK a"), "")
+
+		var invalid *fuzzyModelService.InvalidModelError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, []string{"fcl: line 1: missing END_FUNCTION_BLOCK"}, invalid.Problems)
+		assert.Nil(t, response)
+	})
+}
+
+func TestPublishModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+	ctx := context.Background()
+
+	t.Run("Invalid Draft", func(t *testing.T) {
+		mockRepo.EXPECT().GetModelByVersion(ctx, 2).Return(&models.FuzzyModel{Version: 2, Status: models.FuzzyModelDraft}, nil)
+
+		response, err := service.PublishModel(ctx, 2)
+
+		var invalid *fuzzyModelService.InvalidModelError
+		require.ErrorAs(t, err, &invalid)
+		assert.NotEmpty(t, invalid.Problems)
+		assert.Nil(t, response)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().GetModelByVersion(ctx, 9).Return(nil, nil)
+
+		_, err := service.PublishModel(ctx, 9)
+
+		assert.ErrorIs(t, err, fuzzyModelService.ErrModelNotFound)
+	})
+}
+
+func TestRollbackModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+	ctx := context.Background()
+
+	t.Run("Republish Previous Version", func(t *testing.T) {
+		publishedAt := time.Now()
+		mockRepo.EXPECT().GetPublishedModel(ctx).Return(&models.FuzzyModel{Version: 4, Status: models.FuzzyModelPublished}, nil)
+		mockRepo.EXPECT().GetModels(ctx).Return([]*models.FuzzyModel{
+			{Version: 1, Status: models.FuzzyModelArchived, PublishedAt: &publishedAt},
+			{Version: 2, Status: models.FuzzyModelArchived, PublishedAt: &publishedAt},
+			{Version: 3, Status: models.FuzzyModelDraft},
+			{Version: 4, Status: models.FuzzyModelPublished, PublishedAt: &publishedAt},
+		}, nil)
+		mockRepo.EXPECT().PublishModel(ctx, 2).Return(nil)
+		mockRepo.EXPECT().GetModelByVersion(ctx, 2).Return(&models.FuzzyModel{Version: 2, Status: models.FuzzyModelPublished}, nil)
+
+		response, err := service.RollbackModel(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, response.Version)
+		assert.Equal(t, "published", response.Status)
+	})
+
+	t.Run("Nothing Published", func(t *testing.T) {
+		mockRepo.EXPECT().GetPublishedModel(ctx).Return(nil, nil)
+
+		_, err := service.RollbackModel(ctx)
+
+		assert.ErrorIs(t, err, fuzzyModelService.ErrNoPreviousVersion)
+	})
+}
+
+func TestGetPublishedModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	mockPredicateRepo := mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)
+	service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+	ctx := context.Background()
+
+	t.Run("Default When Nothing Published", func(t *testing.T) {
+		mockRepo.EXPECT().GetPublishedModel(ctx).Return(nil, nil)
+
+		m, err := service.GetPublishedModel(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, m.Version)
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		// Model bawaan dari skenario sebelumnya sudah tersimpan, repositori tidak dibaca lagi
+		m, err := service.GetPublishedModel(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, m.Version)
+	})
+
+	t.Run("Stored Version", func(t *testing.T) {
+		// Simpan definisi bawaan sebagai draf lalu muat kembali sebagai versi terbit;
+		// service baru dipakai karena service di atas sudah menyimpan model bawaan
+		service := fuzzyModelService.NewFuzzyModelService(mockRepo, mockPredicateRepo)
+		var stored *models.FuzzyModel
+		def := model.Default().Definition()
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(0, nil)
+		mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+			stored = fuzzyModel
+			return nil
+		})
+		_, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+		require.NoError(t, err)
+
+		stored.Status = models.FuzzyModelPublished
+		mockRepo.EXPECT().GetPublishedModel(ctx).Return(stored, nil)
+
+		m, err := service.GetPublishedModel(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, m.Version)
+		assert.Equal(t, def, m.Definition())
+	})
+
+	t.Run("Type Two Round Trip", func(t *testing.T) {
+		// Fungsi keanggotaan bawah harus tersimpan agar model terbit tetap tipe-2
+		var stored *models.FuzzyModel
+		def := model.Default().Definition()
+		def.Variables[0].Terms[4].Lower = &model.FunctionSpec{Type: "piecewise", Params: []float64{3.75, 0, 4, 1}}
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(1, nil)
+		mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+			stored = fuzzyModel
+			return nil
+		})
+		_, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+		require.NoError(t, err)
+
+		mockRepo.EXPECT().GetModelByVersion(ctx, 2).DoAndReturn(func(context.Context, int) (*models.FuzzyModel, error) {
+			return stored, nil
+		}).Times(2)
+		mockPredicateRepo.EXPECT().GetByName(ctx, gomock.Any()).Return(&models.Predicate{ID: 1}, nil).Times(len(def.Outputs))
+		mockRepo.EXPECT().PublishModel(ctx, 2).DoAndReturn(func(context.Context, int) error {
+			stored.Status = models.FuzzyModelPublished
+			return nil
+		})
+		_, err = service.PublishModel(ctx, 2)
+		require.NoError(t, err)
+
+		mockRepo.EXPECT().GetPublishedModel(ctx).Return(stored, nil)
+
+		m, err := service.GetPublishedModel(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, m.Version)
+		assert.True(t, m.TypeTwo())
+		assert.Equal(t, def, m.Definition())
+	})
+
+	t.Run("Reload After Rollback", func(t *testing.T) {
+		var stored *models.FuzzyModel
+		def := model.Default().Definition()
+		mockRepo.EXPECT().GetLatestVersion(ctx).Return(2, nil)
+		mockRepo.EXPECT().CreateModel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fuzzyModel *models.FuzzyModel) error {
+			stored = fuzzyModel
+			return nil
+		})
+		_, err := service.CreateDraft(ctx, &dto.FuzzyModelRequest{Definition: &def})
+		require.NoError(t, err)
+		publishedAt := time.Now()
+		stored.Status = models.FuzzyModelArchived
+		stored.PublishedAt = &publishedAt
+
+		mockRepo.EXPECT().GetPublishedModel(ctx).Return(&models.FuzzyModel{Version: 4, Status: models.FuzzyModelPublished}, nil)
+		mockRepo.EXPECT().GetModels(ctx).Return([]*models.FuzzyModel{
+			stored,
+			{Version: 4, Status: models.FuzzyModelPublished, PublishedAt: &publishedAt},
+		}, nil)
+		mockRepo.EXPECT().PublishModel(ctx, 3).Return(nil)
+		mockRepo.EXPECT().GetModelByVersion(ctx, 3).Return(stored, nil)
+		_, err = service.RollbackModel(ctx)
+		require.NoError(t, err)
+
+		mockRepo.EXPECT().GetPublishedModel(ctx).Return(stored, nil)
+
+		m, err := service.GetPublishedModel(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, m.Version)
+		assert.False(t, m.TypeTwo())
+	})
+}
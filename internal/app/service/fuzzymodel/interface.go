@@ -0,0 +1,57 @@
+package fuzzymodel
+
+import (
+	"context"
+	dto "go-tsukamoto/internal/app/dto/fuzzymodel"
+	repo "go-tsukamoto/internal/app/repository/fuzzymodel"
+	predicateRepo "go-tsukamoto/internal/app/repository/predicate"
+	"go-tsukamoto/internal/modules/model"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// publishedTTL membatasi lama model terbit disimpan di memori. Publikasi dan
+// rollback langsung membuang model pada instance yang menanganinya; instance
+// API lain baru memuat versi terbit yang baru setelah TTL habis.
+const publishedTTL = time.Minute
+
+type fuzzyModelService struct {
+	repo          repo.FuzzyModelRepositoryInterface
+	predicateRepo predicateRepo.PredicateRepositoryInterface
+
+	// published menyimpan model terbit yang sudah dikompilasi sejak loadedAt;
+	// dikosongkan setiap kali versi terbit berganti dan dimuat ulang setelah
+	// publishedTTL
+	mu        sync.RWMutex
+	published *model.Model
+	loadedAt  time.Time
+}
+
+func NewFuzzyModelService(repo repo.FuzzyModelRepositoryInterface, predicateRepo predicateRepo.PredicateRepositoryInterface) FuzzyModelServiceInterface {
+	return &fuzzyModelService{repo: repo, predicateRepo: predicateRepo}
+}
+
+func NewService(db *gorm.DB) FuzzyModelServiceInterface {
+	return &fuzzyModelService{
+		repo:          repo.NewFuzzyModelRepository(db),
+		predicateRepo: predicateRepo.NewPredicateRepository(db),
+	}
+}
+
+type FuzzyModelServiceInterface interface {
+	GetModels(ctx context.Context) ([]*dto.FuzzyModelResponse, error)
+	GetModel(ctx context.Context, version int) (*dto.FuzzyModelResponse, error)
+	CreateDraft(ctx context.Context, req *dto.FuzzyModelRequest) (*dto.FuzzyModelResponse, error)
+	UpdateDraft(ctx context.Context, version int, req *dto.FuzzyModelRequest) (*dto.FuzzyModelResponse, error)
+	DeleteDraft(ctx context.Context, version int) error
+	ValidateModel(ctx context.Context, version int) (*dto.ValidationResponse, error)
+	CheckRules(ctx context.Context, version int, req *dto.RuleCheckRequest) (*dto.RuleCheckResponse, error)
+	ExportFCL(ctx context.Context, version int) ([]byte, error)
+	ImportFCL(ctx context.Context, data []byte, description string) (*dto.FuzzyModelResponse, error)
+	PublishModel(ctx context.Context, version int) (*dto.FuzzyModelResponse, error)
+	RollbackModel(ctx context.Context) (*dto.FuzzyModelResponse, error)
+	GetPublishedModel(ctx context.Context) (*model.Model, error)
+	CompileModel(ctx context.Context, version int) (*model.Model, error)
+}
@@ -0,0 +1,37 @@
+package fuzzymodel
+
+import (
+	"context"
+	mockFuzzyModelRepo "go-tsukamoto/internal/app/repository/fuzzymodel"
+	mockPredicateRepo "go-tsukamoto/internal/app/repository/predicate"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPublishedModel_TTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockFuzzyModelRepo.NewMockFuzzyModelRepositoryInterface(ctrl)
+	service := &fuzzyModelService{repo: mockRepo, predicateRepo: mockPredicateRepo.NewMockPredicateRepositoryInterface(ctrl)}
+	ctx := context.Background()
+
+	// Dimuat sekali selama TTL, lalu dimuat ulang agar versi yang dipublikasikan
+	// instance lain ikut terpakai
+	mockRepo.EXPECT().GetPublishedModel(ctx).Return(nil, nil).Times(2)
+
+	first, err := service.GetPublishedModel(ctx)
+	require.NoError(t, err)
+	cached, err := service.GetPublishedModel(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first, cached)
+
+	service.loadedAt = time.Now().Add(-publishedTTL)
+	reloaded, err := service.GetPublishedModel(ctx)
+	require.NoError(t, err)
+	assert.NotSame(t, first, reloaded)
+}
@@ -0,0 +1,392 @@
+package analisis
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+)
+
+// Lever adalah satu jenis perubahan masukan yang layak dilakukan mahasiswa.
+// Apply menerapkan sejumlah langkah perubahan dan mengembalikan false jika
+// langkah tersebut melanggar batas kelayakan; Cost adalah usaha per langkah.
+// Tuas Continuous (langkah kecil seperti IPK) tidak dijelajahi langkah demi
+// langkah, melainkan dicari jumlah langkah minimumnya pada setiap kombinasi
+// tuas lain.
+type Lever struct {
+	Name       string
+	Cost       float64
+	Continuous bool
+	Apply      func(in Input, steps int) (Input, bool)
+}
+
+// Change adalah perubahan satu variabel pada sebuah counterfactual
+type Change struct {
+	Variable  string
+	From      float64
+	To        float64
+	FromLevel string
+	ToLevel   string
+}
+
+// Counterfactual adalah kumpulan perubahan yang menaikkan predikat
+type Counterfactual struct {
+	Changes   []Change
+	Effort    float64
+	Score     float64
+	Predicate string
+}
+
+// CounterfactualOptions mengatur tuas perubahan dan batas pencarian
+type CounterfactualOptions struct {
+	Levers         []Lever
+	Limit          int
+	MaxEvaluations int
+}
+
+const (
+	defaultLimit          = 5
+	defaultMaxEvaluations = 20000
+	coarseSteps           = 5
+
+	// NewAchievementRank adalah peringkat yang diasumsikan untuk prestasi baru
+	NewAchievementRank = 3
+)
+
+// StepLever mengubah nilai tegas variabel sebesar step per langkah selama
+// nilainya tidak melewati limit
+func StepLever(variable string, step, limit, cost float64) Lever {
+	return Lever{
+		Name: variable,
+		Cost: cost,
+		Apply: func(in Input, steps int) (Input, bool) {
+			if steps == 0 {
+				return in, true
+			}
+			value := in.Crisp[variable] + step*float64(steps)
+			// Pembulatan menghindari galat akumulasi pada langkah pecahan
+			value = math.Round(value*1e6) / 1e6
+			if (step > 0 && value > limit) || (step < 0 && value < limit) {
+				return in, false
+			}
+			return in.with(variable, value), true
+		},
+	}
+}
+
+// ContinuousLever adalah StepLever yang dicari jumlah langkah minimumnya
+func ContinuousLever(variable string, step, limit, cost float64) Lever {
+	lever := StepLever(variable, step, limit, cost)
+	lever.Continuous = true
+	return lever
+}
+
+// LevelLever menaikkan level kategoris variabel satu tingkat per langkah
+// menurut urutan levels (terendah lebih dulu). crisp, jika diisi, mengganti
+// nilai tegas variabel sesuai level barunya; base dipakai sebagai nilai tegas
+// jika variabel belum memiliki nilai (misalnya prestasi baru).
+func LevelLever(variable string, levels []string, crisp map[string]float64, base float64, cost float64) Lever {
+	return Lever{
+		Name: variable + " level",
+		Cost: cost,
+		Apply: func(in Input, steps int) (Input, bool) {
+			if steps == 0 {
+				return in, true
+			}
+			position := 0
+			for i, level := range levels {
+				if strings.EqualFold(level, in.Levels[variable]) {
+					position = i
+				}
+			}
+			if position+steps >= len(levels) {
+				return in, false
+			}
+			level := levels[position+steps]
+
+			out := in.with(variable, in.Crisp[variable])
+			out.Levels = make(map[string]string, len(in.Levels)+1)
+			for name, l := range in.Levels {
+				out.Levels[name] = l
+			}
+			out.Levels[variable] = level
+			if value, ok := crisp[level]; ok {
+				out.Crisp[variable] = value
+			} else if out.Crisp[variable] == 0 {
+				out.Crisp[variable] = base
+			}
+			return out, true
+		},
+	}
+}
+
+// DefaultLevers adalah tuas perubahan untuk variabel bawaan. Usaha dinyatakan
+// relatif terhadap satu mata kuliah mengulang yang lebih sedikit (= 1).
+// Batas setiap tuas diambil dari semesta variabel pada model sehingga
+// pencarian tidak keluar dari domain model; variabel yang tidak dimiliki
+// model tidak memiliki tuas. Lama studi tidak dapat berkurang.
+func DefaultLevers(m *model.Model) []Lever {
+	var levers []Lever
+	add := func(variable string, lever func(v *fuzzifikasi.LinguisticVariable) Lever) {
+		if v, ok := m.Lookup(variable); ok {
+			levers = append(levers, lever(v))
+		}
+	}
+
+	add("ipk", func(v *fuzzifikasi.LinguisticVariable) Lever {
+		return ContinuousLever("ipk", 0.01, v.Max, 0.1)
+	})
+	add("repeatedCourses", func(v *fuzzifikasi.LinguisticVariable) Lever {
+		return StepLever("repeatedCourses", -1, v.Min, 1)
+	})
+	add("studyDuration", func(v *fuzzifikasi.LinguisticVariable) Lever {
+		return StepLever("studyDuration", 1, v.Max, 1)
+	})
+	add("achievement", func(v *fuzzifikasi.LinguisticVariable) Lever {
+		return LevelLever("achievement", []string{"", fuzzifikasi.LevelInternal, fuzzifikasi.LevelNasional, fuzzifikasi.LevelInternasional}, nil, NewAchievementRank, 1.5)
+	})
+	add("achievement", func(v *fuzzifikasi.LinguisticVariable) Lever {
+		return StepLever("achievement", -1, v.Min, 0.25)
+	})
+	add("thesis", func(v *fuzzifikasi.LinguisticVariable) Lever {
+		return LevelLever("thesis", []string{fuzzifikasi.LevelInternal, fuzzifikasi.LevelNasional, fuzzifikasi.LevelInternasional}, map[string]float64{
+			fuzzifikasi.LevelInternal:      1,
+			fuzzifikasi.LevelNasional:      3,
+			fuzzifikasi.LevelInternasional: 5,
+		}, 1, 2)
+	})
+	add("activity", func(v *fuzzifikasi.LinguisticVariable) Lever {
+		return StepLever("activity", 1, v.Max, 0.5)
+	})
+	return levers
+}
+
+// NextPredicate mengembalikan predikat satu tingkat di atas predikat saat ini;
+// kosong jika sudah berada pada predikat tertinggi
+func NextPredicate(m *model.Model, predicate string) string {
+	for i, band := range m.Output.Bands {
+		if band.Predicate == predicate && i > 0 {
+			return m.Output.Bands[i-1].Predicate
+		}
+	}
+	return ""
+}
+
+// Counterfactuals mencari perubahan layak dengan usaha terkecil yang menaikkan
+// predikat minimal satu tingkat. Pencarian berbiaya seragam atas jumlah
+// langkah setiap tuas sehingga hasil terurut menurut usaha; kombinasi yang
+// memuat solusi lain yang lebih murah tidak dilaporkan.
+func Counterfactuals(m *model.Model, in Input, opts CounterfactualOptions) (string, []Counterfactual) {
+	if opts.Levers == nil {
+		opts.Levers = DefaultLevers(m)
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultLimit
+	}
+	if opts.MaxEvaluations <= 0 {
+		opts.MaxEvaluations = defaultMaxEvaluations
+	}
+
+	current := inferensia.Evaluate(m, in.Crisp, in.Levels)
+	target := NextPredicate(m, current.Predicate)
+	if target == "" {
+		return "", nil
+	}
+
+	s := &search{m: m, in: in, target: bandRanks(m)[target], ranks: bandRanks(m), budget: opts.MaxEvaluations}
+	var results []Counterfactual
+	visited := map[string]bool{}
+	queue := &stateQueue{{steps: make([]int, len(opts.Levers))}}
+
+	for queue.Len() > 0 && len(results) < opts.Limit && s.budget > 0 {
+		state := heap.Pop(queue).(*searchState)
+
+		// Solusi dari pencarian tuas kontinu baru dilaporkan saat gilirannya
+		// tiba sehingga urutan menurut usaha tetap terjaga
+		if state.result != nil {
+			if !s.dominated(state.steps) {
+				s.solved = append(s.solved, state.steps)
+				results = append(results, *state.result)
+			}
+			continue
+		}
+
+		key := fmt.Sprint(state.steps)
+		if visited[key] || s.dominated(state.steps) {
+			continue
+		}
+		visited[key] = true
+
+		candidate, ok := apply(opts.Levers, in, state.steps)
+		if !ok {
+			continue
+		}
+		if result, ok := s.check(candidate, state.cost); ok {
+			s.solved = append(s.solved, state.steps)
+			results = append(results, result)
+			continue
+		}
+
+		for i, lever := range opts.Levers {
+			if !lever.Continuous {
+				next := append([]int(nil), state.steps...)
+				next[i]++
+				heap.Push(queue, &searchState{steps: next, cost: state.cost + lever.Cost})
+				continue
+			}
+			if state.steps[i] > 0 {
+				continue
+			}
+			if steps, result, ok := s.lineSearch(candidate, lever, state.cost); ok {
+				next := append([]int(nil), state.steps...)
+				next[i] = steps
+				heap.Push(queue, &searchState{steps: next, cost: result.Effort, result: &result})
+			}
+		}
+	}
+	return target, results
+}
+
+type search struct {
+	m      *model.Model
+	in     Input
+	ranks  map[string]int
+	target int
+	budget int
+	solved [][]int
+}
+
+// check mengevaluasi kandidat dan mengembalikan solusi jika predikat target
+// tercapai; kandidat yang gagal validasi tidak pernah menjadi solusi
+func (s *search) check(candidate Input, cost float64) (Counterfactual, bool) {
+	s.budget--
+	trace := inferensia.Evaluate(s.m, candidate.Crisp, candidate.Levels)
+	if trace.Err != nil || s.ranks[trace.Predicate] > s.target {
+		return Counterfactual{}, false
+	}
+	return Counterfactual{
+		Changes:   changes(s.in, candidate),
+		Effort:    cost,
+		Score:     trace.Score,
+		Predicate: trace.Predicate,
+	}, true
+}
+
+// lineSearch mencari jumlah langkah minimum tuas kontinu yang mencapai
+// predikat target: dipindai kasar lalu diperhalus pada selang pertama yang berhasil
+func (s *search) lineSearch(base Input, lever Lever, cost float64) (int, Counterfactual, bool) {
+	for coarse := coarseSteps; ; coarse += coarseSteps {
+		candidate, feasible := lever.Apply(base, coarse)
+		last := coarse
+		if !feasible {
+			// Periksa langkah layak terakhir sebelum batas
+			for last = coarse - 1; last > coarse-coarseSteps; last-- {
+				if candidate, feasible = lever.Apply(base, last); feasible {
+					break
+				}
+			}
+			if !feasible {
+				return 0, Counterfactual{}, false
+			}
+		}
+		if _, ok := s.check(candidate, 0); !ok {
+			if last != coarse || s.budget <= 0 {
+				return 0, Counterfactual{}, false
+			}
+			continue
+		}
+		for steps := coarse - coarseSteps + 1; steps <= last; steps++ {
+			candidate, _ := lever.Apply(base, steps)
+			if result, ok := s.check(candidate, cost+float64(steps)*lever.Cost); ok {
+				return steps, result, true
+			}
+		}
+	}
+}
+
+func apply(levers []Lever, in Input, steps []int) (Input, bool) {
+	for i, lever := range levers {
+		var ok bool
+		if in, ok = lever.Apply(in, steps[i]); !ok {
+			return in, false
+		}
+	}
+	return in, true
+}
+
+// dominated bernilai true jika steps memuat seluruh langkah solusi yang sudah ditemukan
+func (s *search) dominated(steps []int) bool {
+	for _, solution := range s.solved {
+		covers := true
+		for i := range solution {
+			if steps[i] < solution[i] {
+				covers = false
+				break
+			}
+		}
+		if covers {
+			return true
+		}
+	}
+	return false
+}
+
+// changes membandingkan masukan awal dan counterfactual per variabel
+func changes(from, to Input) []Change {
+	var result []Change
+	for _, variable := range sortedKeys(to.Crisp) {
+		change := Change{
+			Variable:  variable,
+			From:      from.Crisp[variable],
+			To:        to.Crisp[variable],
+			FromLevel: from.Levels[variable],
+			ToLevel:   to.Levels[variable],
+		}
+		if change.From != change.To || change.FromLevel != change.ToLevel {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+func sortedKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func bandRanks(m *model.Model) map[string]int {
+	ranks := make(map[string]int, len(m.Output.Bands))
+	for i, band := range m.Output.Bands {
+		ranks[band.Predicate] = i
+	}
+	return ranks
+}
+
+type searchState struct {
+	steps  []int
+	cost   float64
+	result *Counterfactual
+}
+
+type stateQueue []*searchState
+
+func (q stateQueue) Len() int            { return len(q) }
+func (q stateQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q stateQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *stateQueue) Push(x interface{}) { *q = append(*q, x.(*searchState)) }
+func (q *stateQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,130 @@
+package analisis
+
+import (
+	"testing"
+
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterfactuals(t *testing.T) {
+	m := model.Default()
+	ranks := bandRanks(m)
+
+	tests := []struct {
+		name string
+		in   Input
+	}{
+		{"Strong Student", studentInput()},
+		{"Weak Student", func() Input {
+			crisp, levels := rules.Inputs(2.5, 10, 4, 0, "", 1, "", 0)
+			return Input{Crisp: crisp, Levels: levels}
+		}()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := inferensia.Evaluate(m, tt.in.Crisp, tt.in.Levels)
+			target, results := Counterfactuals(m, tt.in, CounterfactualOptions{})
+
+			assert.Equal(t, NextPredicate(m, current.Predicate), target)
+			require.NotEmpty(t, results)
+
+			for i, result := range results {
+				if i > 0 {
+					assert.GreaterOrEqual(t, result.Effort, results[i-1].Effort, "hasil harus terurut menurut usaha")
+				}
+				assert.LessOrEqual(t, ranks[result.Predicate], ranks[target])
+				assert.NotEmpty(t, result.Changes)
+
+				for _, change := range result.Changes {
+					switch change.Variable {
+					case "ipk":
+						assert.Greater(t, change.To, change.From)
+						assert.LessOrEqual(t, change.To, 4.0)
+					case "studyDuration":
+						assert.Greater(t, change.To, change.From, "semester tidak dapat berkurang")
+					case "repeatedCourses":
+						assert.GreaterOrEqual(t, change.To, 0.0)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("Unreachable Target", func(t *testing.T) {
+		// Model bawaan tidak pernah mencapai Summa Cum Laude; pencarian harus
+		// berhenti tanpa hasil setelah seluruh kombinasi layak habis
+		crisp, levels := rules.Inputs(3.95, 7, 0, 1, "internasional", 5, "internasional", 8)
+		target, results := Counterfactuals(m, Input{Crisp: crisp, Levels: levels}, CounterfactualOptions{})
+		assert.Equal(t, m.Output.Bands[0].Predicate, target)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Invalid Candidate", func(t *testing.T) {
+		// Mata kuliah mengulang negatif gagal validasi sehingga predikatnya
+		// kosong; kandidat seperti ini tidak boleh dianggap mencapai target
+		crisp, levels := rules.Inputs(2.5, 10, 4, 0, "", 1, "", 0)
+		levers := []Lever{StepLever("repeatedCourses", -5, -100, 1)}
+		_, results := Counterfactuals(m, Input{Crisp: crisp, Levels: levels}, CounterfactualOptions{Levers: levers})
+		assert.Empty(t, results)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		crisp, levels := rules.Inputs(2.5, 10, 4, 0, "", 1, "", 0)
+		_, results := Counterfactuals(m, Input{Crisp: crisp, Levels: levels}, CounterfactualOptions{Limit: 1})
+		assert.Len(t, results, 1)
+	})
+}
+
+func TestNextPredicate(t *testing.T) {
+	m := model.Default()
+	assert.Equal(t, "Cum Laude", NextPredicate(m, "Sangat Memuaskan"))
+	assert.Empty(t, NextPredicate(m, m.Output.Bands[0].Predicate))
+	assert.Empty(t, NextPredicate(m, "unknown"))
+}
+
+func TestDefaultLevers(t *testing.T) {
+	// Model dengan semesta yang lebih sempit dan tanpa variabel lain hanya
+	// memiliki tuas untuk variabelnya sendiri dengan batas dari semestanya
+	m := &model.Model{Variables: []*fuzzifikasi.LinguisticVariable{
+		{Name: "ipk", Min: 0, Max: 3.5},
+		{Name: "activity", Min: 0, Max: 10},
+	}}
+	levers := DefaultLevers(m)
+	require.Len(t, levers, 2)
+
+	in := studentInput()
+	_, ok := levers[0].Apply(in, 10)
+	assert.False(t, ok, "IPK tidak dapat melebihi batas atas semesta model")
+	_, ok = levers[1].Apply(in, 10-int(in.Crisp["activity"])+1)
+	assert.False(t, ok, "kegiatan tidak dapat melebihi batas atas semesta model")
+
+	assert.Len(t, DefaultLevers(model.Default()), 7)
+}
+
+func TestLevers(t *testing.T) {
+	in := studentInput()
+
+	ipk := ContinuousLever("ipk", 0.01, 4.0, 0.1)
+	out, ok := ipk.Apply(in, 15)
+	assert.True(t, ok)
+	assert.Equal(t, 3.75, out.Crisp["ipk"])
+	assert.Equal(t, 3.6, in.Crisp["ipk"], "masukan awal tidak berubah")
+	_, ok = ipk.Apply(in, 41)
+	assert.False(t, ok, "IPK tidak dapat melebihi 4.0")
+
+	level := LevelLever("thesis", []string{"internal", "nasional", "internasional"}, map[string]float64{"internasional": 5}, 1, 2)
+	out, ok = level.Apply(in, 1)
+	assert.True(t, ok)
+	assert.Equal(t, "internasional", out.Levels["thesis"])
+	assert.Equal(t, 5.0, out.Crisp["thesis"])
+	assert.Equal(t, "nasional", in.Levels["thesis"])
+	_, ok = level.Apply(in, 2)
+	assert.False(t, ok)
+}
@@ -0,0 +1,170 @@
+package analisis
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+)
+
+const (
+	// NoiseUniform mengambil nilai secara seragam pada [x - Spread, x + Spread]
+	NoiseUniform = "uniform"
+	// NoiseNormal mengambil nilai dari distribusi normal dengan rata-rata x
+	// dan simpangan baku Spread
+	NoiseNormal = "normal"
+)
+
+// ErrInvalidNoise dikembalikan untuk konfigurasi derau yang tidak valid
+var ErrInvalidNoise = errors.New("invalid noise")
+
+// Noise adalah distribusi derau di sekitar nilai tersimpan satu variabel
+type Noise struct {
+	Distribution string
+	Spread       float64
+}
+
+// DefaultNoise adalah derau bawaan: IPK dibulatkan ke dua desimal sehingga
+// nilai sebenarnya berada dalam ±0.005, dan peringkat prestasi dilaporkan
+// sendiri oleh mahasiswa sehingga dapat meleset sekitar satu peringkat
+var DefaultNoise = map[string]Noise{
+	"ipk":         {Distribution: NoiseUniform, Spread: 0.005},
+	"achievement": {Distribution: NoiseNormal, Spread: 1},
+}
+
+// MonteCarloOptions mengatur jumlah sampel, derau setiap variabel, metode
+// inferensi dan batas peluang predikat terkuat yang dianggap meyakinkan
+type MonteCarloOptions struct {
+	Samples         int
+	Noise           map[string]Noise
+	Discrete        map[string]bool
+	Engine          inferensia.Engine
+	Seed            int64
+	ReviewThreshold float64
+}
+
+// MonteCarloResult adalah sebaran predikat dari masukan berderau.
+// Probabilities memuat setiap predikat model, terurut seperti Predicates dari
+// predikat tertinggi. Baseline adalah predikat masukan tanpa derau.
+type MonteCarloResult struct {
+	Samples         int
+	Predicates      []string
+	Probabilities   map[string]float64
+	MostLikely      string
+	Probability     float64
+	Baseline        string
+	ScoreMean       float64
+	ScoreStdDev     float64
+	ReviewThreshold float64
+	NeedsReview     bool
+}
+
+const (
+	defaultMonteCarloSamples = 2000
+	defaultReviewThreshold   = 0.8
+)
+
+// MonteCarlo mengambil sampel masukan dari distribusi derau di sekitar nilai
+// tersimpan lalu menjalankan inferensi pada setiap sampel. Nilai sampel
+// dipotong ke semesta variabel dan variabel diskret dibulatkan; variabel
+// yang nilai tersimpannya di luar semesta (misalnya peringkat 0 karena tidak
+// ada prestasi) dibiarkan tanpa derau. Mahasiswa ditandai untuk ditinjau
+// komisi jika peluang predikat terkuatnya di bawah ReviewThreshold.
+func MonteCarlo(m *model.Model, in Input, opts MonteCarloOptions) (MonteCarloResult, error) {
+	if opts.Samples <= 0 {
+		opts.Samples = defaultMonteCarloSamples
+	}
+	if opts.ReviewThreshold <= 0 {
+		opts.ReviewThreshold = defaultReviewThreshold
+	}
+	if opts.Engine == "" {
+		opts.Engine = inferensia.DefaultEngine()
+	}
+	if opts.Noise == nil {
+		opts.Noise = DefaultNoise
+	}
+	variables := map[string]bool{}
+	for _, v := range m.Variables {
+		variables[v.Name] = true
+	}
+	for name, noise := range opts.Noise {
+		if !variables[name] {
+			return MonteCarloResult{}, fmt.Errorf("%w: unknown variable %q", ErrInvalidNoise, name)
+		}
+		if noise.Distribution != NoiseUniform && noise.Distribution != NoiseNormal {
+			return MonteCarloResult{}, fmt.Errorf("%w: unknown distribution %q for %s", ErrInvalidNoise, noise.Distribution, name)
+		}
+		if noise.Spread < 0 || math.IsNaN(noise.Spread) {
+			return MonteCarloResult{}, fmt.Errorf("%w: negative spread for %s", ErrInvalidNoise, name)
+		}
+	}
+
+	result := MonteCarloResult{
+		Samples:         opts.Samples,
+		Probabilities:   map[string]float64{},
+		ReviewThreshold: opts.ReviewThreshold,
+		Baseline:        inferensia.EvaluateEngine(m, opts.Engine, in.Crisp, in.Levels).Predicate,
+	}
+	for _, band := range m.Output.Bands {
+		result.Predicates = append(result.Predicates, band.Predicate)
+		result.Probabilities[band.Predicate] = 0
+	}
+
+	random := rand.New(rand.NewSource(opts.Seed))
+	counts := map[string]int{}
+	sum, sumSquares := 0.0, 0.0
+	for i := 0; i < opts.Samples; i++ {
+		crisp := make(map[string]float64, len(in.Crisp))
+		for name, value := range in.Crisp {
+			crisp[name] = value
+		}
+		// Variabel diambil menurut urutan model agar hasil dengan seed yang
+		// sama selalu identik
+		for _, v := range m.Variables {
+			noise, ok := opts.Noise[v.Name]
+			value, known := in.Crisp[v.Name]
+			if !ok || !known || value < v.Min || value > v.Max {
+				continue
+			}
+			crisp[v.Name] = perturb(random, value, noise, v.Min, v.Max, opts.Discrete[v.Name])
+		}
+
+		trace := inferensia.EvaluateEngine(m, opts.Engine, crisp, in.Levels)
+		counts[trace.Predicate]++
+		sum += trace.Score
+		sumSquares += trace.Score * trace.Score
+	}
+
+	n := float64(opts.Samples)
+	for predicate, count := range counts {
+		result.Probabilities[predicate] = float64(count) / n
+	}
+	// Jika peluang sama, predikat yang lebih tinggi dipilih
+	for _, predicate := range result.Predicates {
+		if p := result.Probabilities[predicate]; p > result.Probability {
+			result.MostLikely, result.Probability = predicate, p
+		}
+	}
+	result.ScoreMean = sum / n
+	result.ScoreStdDev = math.Sqrt(math.Max(0, sumSquares/n-result.ScoreMean*result.ScoreMean))
+	result.NeedsReview = result.Probability < opts.ReviewThreshold
+	return result, nil
+}
+
+// perturb mengambil satu nilai berderau di sekitar value pada semesta
+// [lo, hi]; nilai diskret dibulatkan ke bilangan bulat terdekat
+func perturb(random *rand.Rand, value float64, noise Noise, lo, hi float64, discrete bool) float64 {
+	switch noise.Distribution {
+	case NoiseUniform:
+		value += noise.Spread * (2*random.Float64() - 1)
+	case NoiseNormal:
+		value += noise.Spread * random.NormFloat64()
+	}
+	if discrete {
+		value = math.Round(value)
+	}
+	return math.Min(hi, math.Max(lo, value))
+}
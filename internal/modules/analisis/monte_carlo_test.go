@@ -0,0 +1,100 @@
+package analisis
+
+import (
+	"math/rand"
+	"testing"
+
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonteCarlo(t *testing.T) {
+	m := model.Default()
+	opts := MonteCarloOptions{Samples: 1000, Discrete: DiscreteVariables, Seed: 3}
+
+	t.Run("Far From Any Boundary", func(t *testing.T) {
+		crisp, levels := rules.Inputs(3.2, 8, 0, 3, "nasional", 3, "nasional", 3)
+		result, err := MonteCarlo(m, Input{Crisp: crisp, Levels: levels}, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1000, result.Samples)
+		assert.Equal(t, "Memuaskan", result.Baseline)
+		assert.Equal(t, "Memuaskan", result.MostLikely)
+		assert.Equal(t, 1.0, result.Probability)
+		assert.False(t, result.NeedsReview)
+		assert.Len(t, result.Probabilities, len(m.Output.Bands))
+		assert.Equal(t, m.Output.Bands[0].Predicate, result.Predicates[0])
+	})
+
+	t.Run("Rounded IPK On Cum Laude Boundary", func(t *testing.T) {
+		// IPK 3.75 dapat berasal dari 3.745 yang belum memenuhi syarat Cum Laude
+		crisp, levels := rules.Inputs(3.75, 8, 0, 3, "nasional", 3, "nasional", 3)
+		in := Input{Crisp: crisp, Levels: levels}
+		result, err := MonteCarlo(m, in, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Cum Laude", result.Baseline)
+		assert.Greater(t, result.Probabilities["Cum Laude"], 0.3)
+		assert.Greater(t, result.Probabilities["Sangat Memuaskan"], 0.3)
+		assert.Less(t, result.Probability, 0.8)
+		assert.True(t, result.NeedsReview)
+		assert.Greater(t, result.ScoreStdDev, 0.0)
+
+		total := 0.0
+		for _, p := range result.Probabilities {
+			total += p
+		}
+		assert.InDelta(t, 1, total, 1e-9)
+
+		// Seed yang sama menghasilkan sebaran yang sama
+		again, err := MonteCarlo(m, in, opts)
+		require.NoError(t, err)
+		assert.Equal(t, result, again)
+
+		// Batas tinjauan yang lebih longgar tidak menandai mahasiswa
+		lenient := opts
+		lenient.ReviewThreshold = 0.3
+		result, err = MonteCarlo(m, in, lenient)
+		require.NoError(t, err)
+		assert.False(t, result.NeedsReview)
+	})
+
+	t.Run("Without Noise", func(t *testing.T) {
+		crisp, levels := rules.Inputs(3.75, 8, 0, 3, "nasional", 3, "nasional", 3)
+		quiet := opts
+		quiet.Noise = map[string]Noise{}
+		result, err := MonteCarlo(m, Input{Crisp: crisp, Levels: levels}, quiet)
+		require.NoError(t, err)
+		assert.Equal(t, result.Baseline, result.MostLikely)
+		assert.Equal(t, 1.0, result.Probability)
+		assert.InDelta(t, 0, result.ScoreStdDev, 1e-6)
+	})
+
+	t.Run("Invalid Noise", func(t *testing.T) {
+		in := studentInput()
+		for _, noise := range []map[string]Noise{
+			{"gpa": {Distribution: NoiseUniform, Spread: 0.1}},
+			{"ipk": {Distribution: "poisson", Spread: 0.1}},
+			{"ipk": {Distribution: NoiseNormal, Spread: -1}},
+		} {
+			_, err := MonteCarlo(m, in, MonteCarloOptions{Noise: noise})
+			assert.ErrorIs(t, err, ErrInvalidNoise)
+		}
+	})
+}
+
+func TestPerturb(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		value := perturb(random, 1, Noise{Distribution: NoiseNormal, Spread: 2}, 1, 20, true)
+		assert.GreaterOrEqual(t, value, 1.0)
+		assert.LessOrEqual(t, value, 20.0)
+		assert.Equal(t, float64(int(value)), value)
+
+		value = perturb(random, 3.5, Noise{Distribution: NoiseUniform, Spread: 0.005}, 0, 4, false)
+		assert.InDelta(t, 3.5, value, 0.005)
+	}
+}
@@ -0,0 +1,359 @@
+package analisis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+)
+
+// Arah monotonisitas predikat terhadap satu variabel
+const (
+	// Increasing berarti menaikkan variabel tidak pernah menurunkan predikat
+	Increasing = 1
+	// Decreasing berarti menaikkan variabel tidak pernah menaikkan predikat
+	Decreasing = -1
+)
+
+// PropertyRange adalah nama sifat bahwa setiap keluaran berada pada himpunan
+// predikat model dengan skor di dalam semesta keluaran
+const PropertyRange = "range"
+
+// Monotonicity adalah sifat monoton predikat terhadap satu variabel
+type Monotonicity struct {
+	Variable  string
+	Direction int
+}
+
+// Name adalah nama sifat, misalnya "ipk increasing"
+func (p Monotonicity) Name() string {
+	if p.Direction == Decreasing {
+		return p.Variable + " decreasing"
+	}
+	return p.Variable + " increasing"
+}
+
+// DefaultMonotonicity adalah sifat domain bawaan: IPK yang lebih tinggi tidak
+// pernah menurunkan predikat dan lebih banyak mata kuliah mengulang tidak
+// pernah menaikkannya
+var DefaultMonotonicity = []Monotonicity{
+	{Variable: "ipk", Direction: Increasing},
+	{Variable: "repeatedCourses", Direction: Decreasing},
+}
+
+// PropertyOptions mengatur pemeriksaan sifat: jumlah masukan acak, seed,
+// metode inferensi, variabel diskret, sifat monoton yang diperiksa (nil
+// berarti DefaultMonotonicity) dan jumlah contoh penyangkal yang dilaporkan.
+// Ranges membatasi masukan acak variabel tertentu ke [min, max] di dalam
+// semestanya; variabel lain memakai seluruh semesta.
+type PropertyOptions struct {
+	Samples            int
+	Seed               int64
+	Engine             inferensia.Engine
+	Discrete           map[string]bool
+	Ranges             map[string][2]float64
+	Monotonicity       []Monotonicity
+	MaxCounterexamples int
+}
+
+// Counterexample adalah contoh penyangkal yang sudah diperkecil. Untuk sifat
+// monoton, Input adalah masukan dengan Variable bernilai From; menaikkannya
+// menjadi To mengubah FromPredicate menjadi ToPredicate ke arah yang
+// dilarang. Untuk sifat range hanya Input, FromPredicate dan FromScore yang
+// terisi.
+type Counterexample struct {
+	Property      string
+	Input         Input
+	Variable      string
+	From          float64
+	To            float64
+	FromPredicate string
+	ToPredicate   string
+	FromScore     float64
+	ToScore       float64
+}
+
+// String menuliskan contoh penyangkal beserta seluruh masukannya
+func (c Counterexample) String() string {
+	if c.Property == PropertyRange {
+		return fmt.Sprintf("%s: predicate %q with score %g is outside the model output at %s", c.Property, c.FromPredicate, c.FromScore, describeInput(c.Input))
+	}
+	return fmt.Sprintf("%s: %s %s → %s changes %s → %s at %s", c.Property, c.Variable, formatValue(c.From), formatValue(c.To), c.FromPredicate, c.ToPredicate, describeInput(c.Input))
+}
+
+// PropertyReport adalah hasil pemeriksaan sifat. Checks adalah jumlah
+// pemeriksaan yang dijalankan dan Violations jumlah pemeriksaan yang gagal;
+// contoh penyangkal yang sama setelah diperkecil hanya dilaporkan sekali.
+type PropertyReport struct {
+	Samples         int
+	Checks          int
+	Violations      int
+	Counterexamples []Counterexample
+}
+
+// Valid bernilai true jika tidak ada sifat yang dilanggar
+func (r PropertyReport) Valid() bool {
+	return r.Violations == 0
+}
+
+const (
+	defaultPropertySamples    = 2000
+	defaultMaxCounterexamples = 10
+)
+
+// CheckProperties menjalankan masukan acak melalui model dan memeriksa
+// invarian domain: setiap keluaran berada pada himpunan predikat model dan
+// setiap sifat monoton terpenuhi untuk pasangan nilai acak variabelnya.
+// Pelanggaran diperkecil dengan menyederhanakan variabel lain selama
+// pelanggaran masih terjadi, lalu mempersempit pasangan nilai hingga
+// bersebelahan.
+func CheckProperties(m *model.Model, opts PropertyOptions) (PropertyReport, error) {
+	if opts.Samples <= 0 {
+		opts.Samples = defaultPropertySamples
+	}
+	if opts.MaxCounterexamples <= 0 {
+		opts.MaxCounterexamples = defaultMaxCounterexamples
+	}
+	if opts.Engine == "" {
+		opts.Engine = inferensia.DefaultEngine()
+	}
+	if opts.Monotonicity == nil {
+		opts.Monotonicity = DefaultMonotonicity
+	}
+	variables := map[string]*fuzzifikasi.LinguisticVariable{}
+	for _, v := range m.Variables {
+		variables[v.Name] = v
+	}
+	for _, property := range opts.Monotonicity {
+		if variables[property.Variable] == nil {
+			return PropertyReport{}, fmt.Errorf("unknown variable %q", property.Variable)
+		}
+		if property.Direction != Increasing && property.Direction != Decreasing {
+			return PropertyReport{}, fmt.Errorf("invalid direction %d for %s", property.Direction, property.Variable)
+		}
+	}
+
+	checker := propertyChecker{m: m, opts: opts, ranks: map[string]int{}}
+	for i, band := range m.Output.Bands {
+		checker.ranks[band.Predicate] = i
+	}
+	checker.lo, checker.hi = m.Output.Universe()
+
+	report := PropertyReport{Samples: opts.Samples}
+	seen := map[string]bool{}
+	add := func(c Counterexample) {
+		report.Violations++
+		if key := c.String(); !seen[key] && len(report.Counterexamples) < opts.MaxCounterexamples {
+			seen[key] = true
+			report.Counterexamples = append(report.Counterexamples, c)
+		}
+	}
+
+	random := rand.New(rand.NewSource(opts.Seed))
+	for i := 0; i < opts.Samples; i++ {
+		in := checker.randomInput(random)
+
+		report.Checks++
+		if !checker.inRange(in) {
+			in = checker.shrink(in, "", func(candidate Input) bool { return !checker.inRange(candidate) })
+			trace := checker.evaluate(in)
+			add(Counterexample{Property: PropertyRange, Input: in, FromPredicate: trace.Predicate, FromScore: trace.Score})
+		}
+
+		for _, property := range opts.Monotonicity {
+			v := variables[property.Variable]
+			a, b := checker.value(v, random), checker.value(v, random)
+			if a == b {
+				continue
+			}
+			if a > b {
+				a, b = b, a
+			}
+			report.Checks++
+			if checker.monotone(property, in, a, b) {
+				continue
+			}
+			add(checker.counterexample(property, in, a, b))
+		}
+	}
+	return report, nil
+}
+
+// propertyChecker menyimpan model dan urutan predikat selama pemeriksaan
+type propertyChecker struct {
+	m      *model.Model
+	opts   PropertyOptions
+	ranks  map[string]int
+	lo, hi float64
+}
+
+func (c *propertyChecker) evaluate(in Input) *inferensia.Trace {
+	return inferensia.EvaluateEngine(c.m, c.opts.Engine, in.Crisp, in.Levels)
+}
+
+// randomInput mengambil masukan acak pada semesta setiap variabel beserta
+// level acaknya
+func (c *propertyChecker) randomInput(random *rand.Rand) Input {
+	in := Input{Crisp: map[string]float64{}, Levels: map[string]string{}}
+	for _, v := range c.m.Variables {
+		in.Crisp[v.Name] = c.value(v, random)
+		if levels := variableLevels(v); len(levels) > 1 {
+			in.Levels[v.Name] = levels[random.Intn(len(levels))]
+		}
+	}
+	return in
+}
+
+// bounds adalah rentang masukan acak variabel: Ranges yang dipotong ke
+// semesta, atau seluruh semesta
+func (c *propertyChecker) bounds(v *fuzzifikasi.LinguisticVariable) (float64, float64) {
+	if r, ok := c.opts.Ranges[v.Name]; ok {
+		return math.Max(v.Min, r[0]), math.Min(v.Max, r[1])
+	}
+	return v.Min, v.Max
+}
+
+// value mengambil nilai acak pada rentang variabel; variabel diskret memakai
+// bilangan bulat
+func (c *propertyChecker) value(v *fuzzifikasi.LinguisticVariable, random *rand.Rand) float64 {
+	lo, hi := c.bounds(v)
+	if c.opts.Discrete[v.Name] {
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+		return lo + float64(random.Intn(int(hi-lo)+1))
+	}
+	return lo + random.Float64()*(hi-lo)
+}
+
+// inRange memeriksa bahwa predikat termasuk pita model dan skor berada di
+// dalam semesta keluaran
+func (c *propertyChecker) inRange(in Input) bool {
+	trace := c.evaluate(in)
+	_, ok := c.ranks[trace.Predicate]
+	return ok && !math.IsNaN(trace.Score) && trace.Score >= c.lo && trace.Score <= c.hi
+}
+
+// monotone memeriksa sifat monoton untuk nilai a < b; urutan predikat
+// mengikuti pita model dari predikat tertinggi
+func (c *propertyChecker) monotone(property Monotonicity, in Input, a, b float64) bool {
+	from := c.ranks[c.evaluate(in.with(property.Variable, a)).Predicate]
+	to := c.ranks[c.evaluate(in.with(property.Variable, b)).Predicate]
+	if property.Direction == Increasing {
+		return to <= from
+	}
+	return to >= from
+}
+
+// counterexample memperkecil pelanggaran sifat monoton: variabel lain
+// disederhanakan lebih dulu, lalu pasangan nilai dipersempit dengan bisection
+// hingga bersebelahan (selisih satu untuk variabel diskret, selisih presisi
+// laporan untuk variabel kontinu jika pelanggaran masih terjadi)
+func (c *propertyChecker) counterexample(property Monotonicity, in Input, a, b float64) Counterexample {
+	in = c.shrink(in, property.Variable, func(candidate Input) bool {
+		return !c.monotone(property, candidate, a, b)
+	})
+
+	discrete := c.opts.Discrete[property.Variable]
+	for i := 0; i < bisectIterations; i++ {
+		if discrete && b-a <= 1 {
+			break
+		}
+		mid := (a + b) / 2
+		if discrete {
+			mid = math.Floor(mid)
+		}
+		// Salah satu paruh selalu melanggar karena predikat pada mid tidak
+		// dapat sekaligus sesuai dengan kedua ujung
+		if !c.monotone(property, in, a, mid) {
+			b = mid
+		} else {
+			a = mid
+		}
+	}
+
+	if !discrete {
+		// Nilai dibulatkan ke presisi laporan agar contoh mudah dibaca
+		ra, rb := math.Floor(a*breakpointDecimal)/breakpointDecimal, math.Ceil(b*breakpointDecimal)/breakpointDecimal
+		if !c.monotone(property, in, ra, rb) {
+			a, b = ra, rb
+		}
+	}
+
+	from := c.evaluate(in.with(property.Variable, a))
+	to := c.evaluate(in.with(property.Variable, b))
+	return Counterexample{
+		Property:      property.Name(),
+		Input:         in.with(property.Variable, a),
+		Variable:      property.Variable,
+		From:          a,
+		To:            b,
+		FromPredicate: from.Predicate,
+		ToPredicate:   to.Predicate,
+		FromScore:     from.Score,
+		ToScore:       to.Score,
+	}
+}
+
+// shrink menyederhanakan setiap variabel selain skip selama violates masih
+// bernilai true. Nilai dicoba dari yang paling sederhana: batas bawah
+// rentang, lalu pembulatan ke bilangan bulat, satu dan dua desimal; level
+// dicoba dikosongkan.
+func (c *propertyChecker) shrink(in Input, skip string, violates func(Input) bool) Input {
+	for _, v := range c.m.Variables {
+		if v.Name == skip {
+			continue
+		}
+		lo, hi := c.bounds(v)
+		value := in.Crisp[v.Name]
+		for _, candidate := range []float64{lo, math.Round(value), math.Round(value*10) / 10, math.Round(value*100) / 100} {
+			candidate = math.Min(hi, math.Max(lo, candidate))
+			if candidate == value {
+				break
+			}
+			if next := in.with(v.Name, candidate); violates(next) {
+				in = next
+				break
+			}
+		}
+		if level, ok := in.Levels[v.Name]; ok && level != "" {
+			levels := make(map[string]string, len(in.Levels))
+			for name, l := range in.Levels {
+				levels[name] = l
+			}
+			levels[v.Name] = ""
+			if next := (Input{Crisp: in.Crisp, Levels: levels}); violates(next) {
+				in = next
+			}
+		}
+	}
+	return in
+}
+
+// describeInput menuliskan masukan terurut menurut nama variabel, misalnya
+// "achievement=3 (nasional) ipk=3.5"
+func describeInput(in Input) string {
+	names := make([]string, 0, len(in.Crisp))
+	for name := range in.Crisp {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		part := name + "=" + formatValue(in.Crisp[name])
+		if level := in.Levels[name]; level != "" {
+			part += " (" + level + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,133 @@
+package analisis
+
+import (
+	"testing"
+
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// linearModel adalah model Sugeno dengan satu aturan yang selalu menyala dan
+// skor 1.2 + 0.7·ipk - 0.01·repeatedCourses, sehingga predikatnya monoton
+// terhadap kedua variabel
+func linearModel(t *testing.T, constant float64) *model.Model {
+	def := model.Default().Definition()
+	def.Rules = []rules.RuleSpec{{
+		Rule:   "IF ipk IS SangatRendah OR ipk IS not SangatRendah THEN Cukup",
+		Output: &rules.Consequent{Constant: constant, Coefficients: map[string]float64{"ipk": 0.7, "repeatedCourses": -0.01}},
+	}}
+	def.Fallback = nil
+	m, err := model.Compile(def)
+	require.NoError(t, err)
+	return m
+}
+
+// realisticRanges membatasi masukan acak ke nilai lulusan yang wajar
+var realisticRanges = map[string][2]float64{
+	"ipk":             {2, 4},
+	"studyDuration":   {7, 14},
+	"repeatedCourses": {0, 5},
+	"achievement":     {1, 10},
+	"activity":        {0, 10},
+}
+
+func TestCheckProperties(t *testing.T) {
+	t.Run("Monotonic Model", func(t *testing.T) {
+		report, err := CheckProperties(linearModel(t, 1.2), PropertyOptions{
+			Samples:  300,
+			Engine:   inferensia.EngineSugeno,
+			Discrete: DiscreteVariables,
+		})
+		require.NoError(t, err)
+		assert.True(t, report.Valid())
+		assert.Empty(t, report.Counterexamples)
+		assert.Equal(t, 300, report.Samples)
+		assert.Greater(t, report.Checks, 300)
+	})
+
+	t.Run("Default Model", func(t *testing.T) {
+		// Rata-rata terbobot Tsukamoto tidak menjamin monoton: aturan yang
+		// baru menyala ketika IPK melewati syarat "when" dapat menurunkan skor
+		m := model.Default()
+		report, err := CheckProperties(m, PropertyOptions{
+			Samples:            500,
+			Discrete:           DiscreteVariables,
+			Ranges:             realisticRanges,
+			MaxCounterexamples: 50,
+		})
+		require.NoError(t, err)
+		require.False(t, report.Valid())
+		assert.GreaterOrEqual(t, report.Violations, len(report.Counterexamples))
+
+		ranks := map[string]int{}
+		for i, band := range m.Output.Bands {
+			ranks[band.Predicate] = i
+		}
+		for _, c := range report.Counterexamples {
+			// Contoh penyangkal dapat diulang dan pasangannya bersebelahan
+			from := inferensia.Evaluate(m, c.Input.Crisp, c.Input.Levels)
+			to := inferensia.Evaluate(m, c.Input.with(c.Variable, c.To).Crisp, c.Input.Levels)
+			assert.Equal(t, c.From, c.Input.Crisp[c.Variable])
+			assert.Equal(t, c.FromPredicate, from.Predicate)
+			assert.Equal(t, c.ToPredicate, to.Predicate)
+			switch c.Property {
+			case "ipk increasing":
+				assert.Greater(t, ranks[to.Predicate], ranks[from.Predicate], c.String())
+				assert.LessOrEqual(t, c.To-c.From, 2e-4+1e-12, c.String())
+			case "repeatedCourses decreasing":
+				assert.Less(t, ranks[to.Predicate], ranks[from.Predicate], c.String())
+				assert.Equal(t, 1.0, c.To-c.From, c.String())
+			default:
+				t.Errorf("unexpected property %q", c.Property)
+			}
+			for name, value := range c.Input.Crisp {
+				r, ok := realisticRanges[name]
+				if ok {
+					assert.GreaterOrEqual(t, value, r[0], name)
+					assert.LessOrEqual(t, value, r[1], name)
+				}
+			}
+		}
+	})
+
+	t.Run("Score Outside Output", func(t *testing.T) {
+		report, err := CheckProperties(linearModel(t, 3.5), PropertyOptions{
+			Samples:      200,
+			Engine:       inferensia.EngineSugeno,
+			Discrete:     DiscreteVariables,
+			Monotonicity: []Monotonicity{},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, report.Counterexamples)
+		c := report.Counterexamples[0]
+		assert.Equal(t, PropertyRange, c.Property)
+		assert.Greater(t, c.FromScore, 4.0)
+		// Variabel yang tidak memengaruhi pelanggaran disederhanakan ke batas bawah
+		assert.Equal(t, 1.0, c.Input.Crisp["studyDuration"])
+		assert.Equal(t, 0.0, c.Input.Crisp["activity"])
+	})
+
+	t.Run("Invalid Options", func(t *testing.T) {
+		_, err := CheckProperties(model.Default(), PropertyOptions{Monotonicity: []Monotonicity{{Variable: "gpa", Direction: Increasing}}})
+		assert.EqualError(t, err, `unknown variable "gpa"`)
+		_, err = CheckProperties(model.Default(), PropertyOptions{Monotonicity: []Monotonicity{{Variable: "ipk"}}})
+		assert.EqualError(t, err, "invalid direction 0 for ipk")
+	})
+}
+
+func TestCounterexampleString(t *testing.T) {
+	c := Counterexample{
+		Property:      "ipk increasing",
+		Input:         Input{Crisp: map[string]float64{"ipk": 3.4999, "achievement": 3}, Levels: map[string]string{"achievement": "nasional"}},
+		Variable:      "ipk",
+		From:          3.4999,
+		To:            3.5,
+		FromPredicate: "Memuaskan",
+		ToPredicate:   "Cukup",
+	}
+	assert.Equal(t, "ipk increasing: ipk 3.4999 → 3.5 changes Memuaskan → Cukup at achievement=3 (nasional) ipk=3.4999", c.String())
+}
@@ -0,0 +1,39 @@
+// Package propertytest menyediakan helper tes untuk memeriksa invarian domain
+// model fuzzy dengan masukan acak, misalnya:
+//
+//	func TestPublishedModel(t *testing.T) {
+//		propertytest.Check(t, m, propertytest.Options())
+//	}
+package propertytest
+
+import (
+	"testing"
+
+	"go-tsukamoto/internal/modules/analisis"
+	"go-tsukamoto/internal/modules/model"
+)
+
+// Options mengembalikan pengaturan bawaan: variabel diskret bawaan dan sifat
+// monoton bawaan (IPK naik, mata kuliah mengulang turun)
+func Options() analisis.PropertyOptions {
+	return analisis.PropertyOptions{Discrete: analisis.DiscreteVariables}
+}
+
+// Check menjalankan analisis.CheckProperties pada model dan menggagalkan tes
+// untuk setiap contoh penyangkal yang sudah diperkecil. Laporan dikembalikan
+// agar tes dapat memeriksa hasilnya lebih lanjut.
+func Check(t testing.TB, m *model.Model, opts analisis.PropertyOptions) analisis.PropertyReport {
+	t.Helper()
+	report, err := analisis.CheckProperties(m, opts)
+	if err != nil {
+		t.Fatalf("property check: %v", err)
+		return report
+	}
+	for _, counterexample := range report.Counterexamples {
+		t.Errorf("%s", counterexample)
+	}
+	if !report.Valid() {
+		t.Errorf("%d of %d property checks failed", report.Violations, report.Checks)
+	}
+	return report
+}
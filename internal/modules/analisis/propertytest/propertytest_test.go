@@ -0,0 +1,71 @@
+package propertytest
+
+import (
+	"fmt"
+	"testing"
+
+	"go-tsukamoto/internal/modules/analisis"
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder mencatat kegagalan tanpa menggagalkan tes yang sedang berjalan
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.fatal = fmt.Sprintf(format, args...)
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("Monotonic Model", func(t *testing.T) {
+		// Skor Sugeno 1.2 + 0.7·ipk - 0.01·repeatedCourses dari satu aturan
+		// yang selalu menyala
+		def := model.Default().Definition()
+		def.Rules = []rules.RuleSpec{{
+			Rule:   "IF ipk IS SangatRendah OR ipk IS not SangatRendah THEN Cukup",
+			Output: &rules.Consequent{Constant: 1.2, Coefficients: map[string]float64{"ipk": 0.7, "repeatedCourses": -0.01}},
+		}}
+		def.Fallback = nil
+		m, err := model.Compile(def)
+		require.NoError(t, err)
+
+		opts := Options()
+		opts.Samples = 200
+		opts.Engine = inferensia.EngineSugeno
+		report := Check(t, m, opts)
+		assert.True(t, report.Valid())
+	})
+
+	t.Run("Violations", func(t *testing.T) {
+		r := &recorder{TB: t}
+		opts := Options()
+		opts.Samples = 300
+		report := Check(r, model.Default(), opts)
+
+		require.False(t, report.Valid())
+		require.Len(t, r.errors, len(report.Counterexamples)+1)
+		assert.Equal(t, report.Counterexamples[0].String(), r.errors[0])
+		assert.Equal(t, fmt.Sprintf("%d of %d property checks failed", report.Violations, report.Checks), r.errors[len(r.errors)-1])
+	})
+
+	t.Run("Invalid Options", func(t *testing.T) {
+		r := &recorder{TB: t}
+		Check(r, model.Default(), analisis.PropertyOptions{Monotonicity: []analisis.Monotonicity{{Variable: "gpa", Direction: analisis.Increasing}}})
+		assert.Equal(t, `property check: unknown variable "gpa"`, r.fatal)
+		assert.Empty(t, r.errors)
+	})
+}
@@ -0,0 +1,368 @@
+package analisis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+)
+
+// Jenis temuan pemeriksaan basis aturan
+const (
+	IssueCoverageGap          = "coverage_gap"
+	IssueConflict             = "conflict"
+	IssueUnreachablePredicate = "unreachable_predicate"
+	IssueUnusedTerm           = "unused_term"
+)
+
+// Issue adalah satu temuan pemeriksaan basis aturan. Field yang terisi
+// bergantung pada jenisnya: Region, Input dan Strength untuk celah cakupan,
+// Rules untuk konflik, Predicate untuk predikat yang tidak tercapai, serta
+// Variable dan Term untuk himpunan yang tidak dirujuk.
+type Issue struct {
+	Kind      string
+	Message   string
+	Region    map[string]string
+	Input     *Input
+	Strength  float64
+	Rules     []string
+	Predicate string
+	Variable  string
+	Term      string
+}
+
+// RuleCheckOptions mengatur pemeriksaan basis aturan. Threshold adalah
+// kekuatan minimum agar sebuah wilayah dianggap tercakup aturan, Samples
+// adalah jumlah masukan acak tambahan untuk mencari predikat yang dapat
+// dicapai dan MaxGaps membatasi contoh celah cakupan yang dilaporkan.
+type RuleCheckOptions struct {
+	Threshold float64
+	Samples   int
+	MaxGaps   int
+	Seed      int64
+}
+
+// RuleCheckReport adalah hasil pemeriksaan basis aturan. Regions adalah
+// jumlah wilayah (kombinasi satu himpunan per variabel) yang diperiksa dan
+// Gaps adalah jumlah wilayah tanpa aturan di atas ambang; hanya MaxGaps
+// contoh pertama yang dimuat pada Issues.
+type RuleCheckReport struct {
+	Regions int
+	Gaps    int
+	Issues  []Issue
+}
+
+const (
+	defaultThreshold  = 0.1
+	defaultRandomRuns = 5000
+	defaultMaxGaps    = 20
+	peakSamples       = 200
+)
+
+// CheckRuleBase memeriksa basis aturan model sebelum dipublikasikan dan
+// melaporkan wilayah masukan tanpa aturan yang menyala di atas ambang,
+// aturan dengan anteseden sama tetapi konsekuen berbeda, predikat yang tidak
+// dihasilkan oleh masukan mana pun yang disampel, serta himpunan yang tidak
+// pernah dirujuk aturan. Aturan fallback tidak dihitung sebagai cakupan.
+func CheckRuleBase(m *model.Model, opts RuleCheckOptions) RuleCheckReport {
+	if opts.Threshold <= 0 {
+		opts.Threshold = defaultThreshold
+	}
+	if opts.Samples <= 0 {
+		opts.Samples = defaultRandomRuns
+	}
+	if opts.MaxGaps <= 0 {
+		opts.MaxGaps = defaultMaxGaps
+	}
+
+	var report RuleCheckReport
+	reached := map[string]bool{}
+
+	// Setiap wilayah diwakili titik puncak himpunannya pada setiap variabel
+	regions := termRegions(m.Variables)
+	report.Regions = len(regions)
+	for _, region := range regions {
+		trace := inferensia.Evaluate(m, region.input.Crisp, region.input.Levels)
+		reached[trace.Predicate] = true
+
+		strongest := 0.0
+		for _, rule := range trace.Rules {
+			if rule.Rule != "fallback" {
+				strongest = max(strongest, rule.Strength)
+			}
+		}
+		if strongest >= opts.Threshold {
+			continue
+		}
+		report.Gaps++
+		if report.Gaps <= opts.MaxGaps {
+			input := region.input
+			report.Issues = append(report.Issues, Issue{
+				Kind:     IssueCoverageGap,
+				Message:  fmt.Sprintf("no rule fires above %.2f when %s (strongest %.2f)", opts.Threshold, describeRegion(m.Variables, region.terms), strongest),
+				Region:   region.terms,
+				Input:    &input,
+				Strength: strongest,
+			})
+		}
+	}
+
+	// Masukan acak melengkapi titik puncak untuk mencari predikat yang dapat dicapai
+	random := rand.New(rand.NewSource(opts.Seed))
+	for i := 0; i < opts.Samples && len(reached) < len(m.Output.Bands); i++ {
+		in := randomInput(m.Variables, random)
+		reached[inferensia.Evaluate(m, in.Crisp, in.Levels).Predicate] = true
+	}
+
+	report.Issues = append(report.Issues, conflicts(m.RuleBase)...)
+	report.Issues = append(report.Issues, unreachable(m, reached)...)
+	report.Issues = append(report.Issues, unusedTerms(m)...)
+	return report
+}
+
+// Valid bernilai true jika pemeriksaan tidak menemukan masalah
+func (r RuleCheckReport) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+type region struct {
+	terms map[string]string
+	input Input
+}
+
+type peak struct {
+	value  float64
+	level  string
+	degree float64
+}
+
+// termRegions menyusun seluruh kombinasi satu himpunan per variabel beserta
+// masukan pada puncak setiap himpunan
+func termRegions(variables []*fuzzifikasi.LinguisticVariable) []region {
+	regions := []region{{terms: map[string]string{}, input: Input{Crisp: map[string]float64{}, Levels: map[string]string{}}}}
+	for _, v := range variables {
+		peaks := termPeaks(v)
+		var next []region
+		for _, r := range regions {
+			for _, term := range v.TermNames() {
+				extended := region{
+					terms: copyStrings(r.terms),
+					input: Input{Crisp: copyFloats(r.input.Crisp), Levels: copyStrings(r.input.Levels)},
+				}
+				extended.terms[v.Name] = term
+				extended.input.Crisp[v.Name] = peaks[term].value
+				if peaks[term].level != "" {
+					extended.input.Levels[v.Name] = peaks[term].level
+				}
+				next = append(next, extended)
+			}
+		}
+		regions = next
+	}
+	return regions
+}
+
+// termPeaks mencari nilai dan level masukan tempat derajat setiap himpunan
+// paling tinggi; pada dataran dipakai nilai terkecil. Selain sampel merata,
+// parameter fungsi keanggotaan ikut dicoba karena puncak fungsi linear
+// sepotong-sepotong berada tepat pada parameternya, begitu pula nilai tepat di
+// sebelahnya karena puncak di lompatan hanya dicapai dari satu sisi.
+func termPeaks(v *fuzzifikasi.LinguisticVariable) map[string]peak {
+	var candidates []float64
+	for i := 0; i <= peakSamples; i++ {
+		candidates = append(candidates, v.Min+(v.Max-v.Min)*float64(i)/peakSamples)
+	}
+	for _, term := range v.Terms {
+		for _, param := range term.Function.Params() {
+			for _, x := range []float64{math.Nextafter(param, math.Inf(-1)), param, math.Nextafter(param, math.Inf(1))} {
+				if x >= v.Min && x <= v.Max {
+					candidates = append(candidates, x)
+				}
+			}
+		}
+	}
+	sort.Float64s(candidates)
+
+	peaks := map[string]peak{}
+	for _, x := range candidates {
+		for _, level := range variableLevels(v) {
+			for term, degree := range v.FuzzifyLevel(x, level) {
+				if degree > peaks[term].degree {
+					peaks[term] = peak{value: x, level: level, degree: degree}
+				}
+			}
+		}
+	}
+	return peaks
+}
+
+// variableLevels mengembalikan level kategoris variabel, diawali level kosong
+func variableLevels(v *fuzzifikasi.LinguisticVariable) []string {
+	return append([]string{""}, v.Levels()...)
+}
+
+func randomInput(variables []*fuzzifikasi.LinguisticVariable, random *rand.Rand) Input {
+	in := Input{Crisp: map[string]float64{}, Levels: map[string]string{}}
+	for _, v := range variables {
+		in.Crisp[v.Name] = v.Min + random.Float64()*(v.Max-v.Min)
+		if levels := variableLevels(v); len(levels) > 1 {
+			in.Levels[v.Name] = levels[random.Intn(len(levels))]
+		}
+	}
+	return in
+}
+
+func describeRegion(variables []*fuzzifikasi.LinguisticVariable, terms map[string]string) string {
+	clauses := make([]string, 0, len(variables))
+	for _, v := range variables {
+		clauses = append(clauses, v.Name+" IS "+terms[v.Name])
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// conflicts mencari aturan dengan anteseden dan syarat tegas yang sama
+// (tanpa memperhatikan urutan operand AND/OR) tetapi konsekuen berbeda
+func conflicts(rb *rules.RuleBase) []Issue {
+	groups := map[string][]*rules.Rule{}
+	var keys []string
+	for _, rule := range rb.Rules {
+		key := canonicalExpr(rule.Antecedent) + " WHEN " + canonicalCondition(rule.Guard)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], rule)
+	}
+
+	var issues []Issue
+	for _, key := range keys {
+		group := groups[key]
+		consequents := map[string]bool{}
+		texts := make([]string, 0, len(group))
+		for _, rule := range group {
+			consequents[rule.Consequent] = true
+			texts = append(texts, rule.Text)
+		}
+		if len(consequents) < 2 {
+			continue
+		}
+		issues = append(issues, Issue{
+			Kind:    IssueConflict,
+			Message: fmt.Sprintf("%d rules share the same antecedent but conclude %s", len(group), strings.Join(sortedSet(consequents), ", ")),
+			Rules:   texts,
+		})
+	}
+	return issues
+}
+
+func canonicalExpr(e *rules.Expr) string {
+	if e.Op == "" {
+		return strings.Join(append(append([]string{e.Variable, "IS"}, e.Hedges...), e.Term), " ")
+	}
+	operands := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		operands[i] = canonicalExpr(operand)
+	}
+	sort.Strings(operands)
+	return "(" + strings.Join(operands, " "+e.Op+" ") + ")"
+}
+
+func canonicalCondition(c *rules.Condition) string {
+	if c == nil {
+		return ""
+	}
+	if c.Op == rules.OpAnd || c.Op == rules.OpOr {
+		operands := make([]string, len(c.Operands))
+		for i, operand := range c.Operands {
+			operands[i] = canonicalCondition(operand)
+		}
+		sort.Strings(operands)
+		return "(" + strings.Join(operands, " "+c.Op+" ") + ")"
+	}
+	return fmt.Sprintf("%s %s %g", c.Variable, c.Op, c.Value)
+}
+
+// unreachable melaporkan predikat keluaran yang tidak dihasilkan masukan mana pun
+func unreachable(m *model.Model, reached map[string]bool) []Issue {
+	concluded := map[string]bool{}
+	for _, rule := range m.RuleBase.Rules {
+		concluded[rule.Consequent] = true
+	}
+
+	var issues []Issue
+	for _, band := range m.Output.Bands {
+		if reached[band.Predicate] {
+			continue
+		}
+		message := fmt.Sprintf("predicate %q is not produced by any sampled input", band.Predicate)
+		if !concluded[band.Predicate] {
+			message += " and no rule concludes it"
+		}
+		issues = append(issues, Issue{Kind: IssueUnreachablePredicate, Message: message, Predicate: band.Predicate})
+	}
+	return issues
+}
+
+// unusedTerms melaporkan himpunan yang tidak dirujuk anteseden aturan mana pun
+func unusedTerms(m *model.Model) []Issue {
+	used := map[string]bool{}
+	for _, rule := range m.RuleBase.Rules {
+		collectTerms(rule.Antecedent, used)
+	}
+
+	var issues []Issue
+	for _, v := range m.Variables {
+		for _, term := range v.TermNames() {
+			if used[v.Name+"."+term] {
+				continue
+			}
+			issues = append(issues, Issue{
+				Kind:     IssueUnusedTerm,
+				Message:  fmt.Sprintf("term %s of variable %s is never referenced", term, v.Name),
+				Variable: v.Name,
+				Term:     term,
+			})
+		}
+	}
+	return issues
+}
+
+func collectTerms(e *rules.Expr, used map[string]bool) {
+	if e.Op == "" {
+		used[e.Variable+"."+e.Term] = true
+		return
+	}
+	for _, operand := range e.Operands {
+		collectTerms(operand, used)
+	}
+}
+
+func copyFloats(values map[string]float64) map[string]float64 {
+	result := make(map[string]float64, len(values))
+	for key, value := range values {
+		result[key] = value
+	}
+	return result
+}
+
+func copyStrings(values map[string]string) map[string]string {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		result[key] = value
+	}
+	return result
+}
+
+func sortedSet(values map[string]bool) []string {
+	result := make([]string, 0, len(values))
+	for value := range values {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
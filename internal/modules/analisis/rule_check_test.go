@@ -0,0 +1,107 @@
+package analisis
+
+import (
+	"testing"
+
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issuesOf(report RuleCheckReport, kind string) []Issue {
+	var issues []Issue
+	for _, issue := range report.Issues {
+		if issue.Kind == kind {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func TestCheckRuleBase(t *testing.T) {
+	t.Run("Default Model", func(t *testing.T) {
+		report := CheckRuleBase(model.Default(), RuleCheckOptions{})
+
+		// 5 himpunan pada setiap 6 variabel
+		assert.Equal(t, 15625, report.Regions)
+		// IPK Tinggi bernilai 0 mulai 3.75, tepat tempat syarat Magna Cum Laude
+		// dimulai, sehingga sebagian wilayahnya hanya dicakup lemah
+		assert.Equal(t, 80, report.Gaps)
+		for _, gap := range issuesOf(report, IssueCoverageGap) {
+			assert.Equal(t, "Tinggi", gap.Region["ipk"])
+		}
+		assert.Empty(t, issuesOf(report, IssueConflict))
+		assert.False(t, report.Valid())
+
+		unreachable := issuesOf(report, IssueUnreachablePredicate)
+		require.Len(t, unreachable, 1)
+		assert.Equal(t, "Summa Cum Laude", unreachable[0].Predicate)
+
+		unused := issuesOf(report, IssueUnusedTerm)
+		require.Len(t, unused, 1)
+		assert.Equal(t, "activity", unused[0].Variable)
+		assert.Equal(t, "SangatTinggi", unused[0].Term)
+	})
+
+	t.Run("Gaps And Conflicts", func(t *testing.T) {
+		def := model.Default().Definition()
+		def.And = rules.TNormMin
+		def.Or = ""
+		def.Rules = []rules.RuleSpec{
+			{Rule: "IF ipk IS Tinggi AND (activity IS Sedang OR activity IS Tinggi) THEN Cum Laude"},
+			{Rule: "IF (activity IS Tinggi OR activity IS Sedang) AND ipk IS Tinggi THEN Magna Cum Laude"},
+			{Rule: "IF ipk IS Rendah THEN Memuaskan"},
+		}
+		m, err := model.Compile(def)
+		require.NoError(t, err)
+
+		report := CheckRuleBase(m, RuleCheckOptions{MaxGaps: 3, Samples: 500})
+
+		assert.Positive(t, report.Gaps)
+		gaps := issuesOf(report, IssueCoverageGap)
+		require.Len(t, gaps, 3)
+		for _, gap := range gaps {
+			assert.Less(t, gap.Strength, 0.1)
+			assert.Len(t, gap.Region, len(m.Variables))
+			require.NotNil(t, gap.Input)
+		}
+
+		conflicts := issuesOf(report, IssueConflict)
+		require.Len(t, conflicts, 1)
+		assert.Len(t, conflicts[0].Rules, 2)
+
+		// Summa Cum Laude tidak disimpulkan aturan mana pun
+		unreachable := map[string]string{}
+		for _, issue := range issuesOf(report, IssueUnreachablePredicate) {
+			unreachable[issue.Predicate] = issue.Message
+		}
+		assert.Contains(t, unreachable["Summa Cum Laude"], "no rule concludes it")
+
+		// Syarat tegas berbeda bukan konflik
+		def.Rules[1].When = "ipk >= 3.8"
+		m, err = model.Compile(def)
+		require.NoError(t, err)
+		assert.Empty(t, issuesOf(CheckRuleBase(m, RuleCheckOptions{Samples: 1}), IssueConflict))
+	})
+}
+
+func TestTermPeaks(t *testing.T) {
+	m := model.Default()
+
+	// Puncak segitiga (2.00, 2.75, 3.50) tepat pada parameternya
+	peaks := termPeaks(m.Variables[0])
+	assert.Equal(t, 2.75, peaks["Sedang"].value)
+	assert.Equal(t, 1.0, peaks["Sedang"].degree)
+
+	// Tinggi melompat ke 0 di 3.75, sehingga puncaknya tepat sebelum 3.75
+	assert.Less(t, peaks["Tinggi"].value, 3.75)
+	assert.InDelta(t, 3.75, peaks["Tinggi"].value, 1e-9)
+	assert.InDelta(t, 1.0, peaks["Tinggi"].degree, 1e-9)
+
+	// Himpunan kategoris memakai level yang menjadi prasyaratnya
+	peaks = termPeaks(m.Variables[3])
+	assert.Equal(t, "internasional", peaks["SangatTinggi"].level)
+	assert.Equal(t, 1.0, peaks["SangatTinggi"].value)
+}
@@ -0,0 +1,174 @@
+package analisis
+
+import (
+	"math"
+
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+)
+
+// DiscreteVariables adalah variabel bawaan yang nilainya berupa bilangan bulat
+// (jumlah semester, mata kuliah, peringkat, aktivitas)
+var DiscreteVariables = map[string]bool{
+	"studyDuration":   true,
+	"repeatedCourses": true,
+	"achievement":     true,
+	"activity":        true,
+}
+
+// Input adalah nilai tegas setiap variabel beserta level kategorisnya
+type Input struct {
+	Crisp  map[string]float64
+	Levels map[string]string
+}
+
+// with mengembalikan salinan masukan dengan satu variabel diganti
+func (in Input) with(variable string, value float64) Input {
+	crisp := make(map[string]float64, len(in.Crisp))
+	for name, v := range in.Crisp {
+		crisp[name] = v
+	}
+	crisp[variable] = value
+	return Input{Crisp: crisp, Levels: in.Levels}
+}
+
+// Point adalah skor dan predikat pada satu nilai masukan
+type Point struct {
+	Value     float64
+	Score     float64
+	Predicate string
+}
+
+// Breakpoint adalah nilai masukan tempat predikat berubah dari From menjadi
+// To ketika nilai dinaikkan melewati Value
+type Breakpoint struct {
+	Value float64
+	From  string
+	To    string
+}
+
+// Sweep adalah kurva skor satu variabel sementara variabel lain tetap
+type Sweep struct {
+	Variable    string
+	Current     float64
+	Min         float64
+	Max         float64
+	Points      []Point
+	Breakpoints []Breakpoint
+}
+
+// Impact adalah selisih skor tertinggi dan terendah sepanjang kurva
+func (s Sweep) Impact() float64 {
+	if len(s.Points) == 0 {
+		return 0
+	}
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, point := range s.Points {
+		low = min(low, point.Score)
+		high = max(high, point.Score)
+	}
+	return high - low
+}
+
+// SensitivityOptions mengatur jumlah sampel dan variabel diskret
+type SensitivityOptions struct {
+	Steps    int
+	Discrete map[string]bool
+}
+
+const (
+	defaultSteps      = 100
+	bisectIterations  = 40
+	breakpointDecimal = 1e4
+	maxDepth          = 8
+)
+
+// Sensitivity menyapu setiap variabel masukan di sepanjang semestanya dengan
+// variabel lain tetap, lalu mencari titik tepat perubahan predikat
+func Sensitivity(m *model.Model, in Input, opts SensitivityOptions) []Sweep {
+	if opts.Steps <= 0 {
+		opts.Steps = defaultSteps
+	}
+
+	sweeps := make([]Sweep, 0, len(m.Variables))
+	for _, v := range m.Variables {
+		sweep := Sweep{Variable: v.Name, Current: in.Crisp[v.Name], Min: v.Min, Max: v.Max}
+		discrete := opts.Discrete[v.Name]
+
+		for _, value := range samples(v.Min, v.Max, opts.Steps, discrete) {
+			trace := inferensia.Evaluate(m, in.with(v.Name, value).Crisp, in.Levels)
+			point := Point{Value: value, Score: trace.Score, Predicate: trace.Predicate}
+
+			if n := len(sweep.Points); n > 0 && sweep.Points[n-1].Predicate != point.Predicate {
+				previous := sweep.Points[n-1]
+				if discrete {
+					sweep.Breakpoints = append(sweep.Breakpoints, Breakpoint{Value: point.Value, From: previous.Predicate, To: point.Predicate})
+				} else {
+					sweep.Breakpoints = append(sweep.Breakpoints, locate(m, in, v.Name, previous, point, maxDepth)...)
+				}
+			}
+			sweep.Points = append(sweep.Points, point)
+		}
+		sweeps = append(sweeps, sweep)
+	}
+	return sweeps
+}
+
+// samples membagi semesta [lo, hi] menjadi titik sampel; variabel diskret
+// memakai setiap bilangan bulat
+func samples(lo, hi float64, steps int, discrete bool) []float64 {
+	var values []float64
+	if discrete {
+		for value := math.Ceil(lo); value <= hi; value++ {
+			values = append(values, value)
+		}
+		return values
+	}
+	for i := 0; i <= steps; i++ {
+		values = append(values, lo+(hi-lo)*float64(i)/float64(steps))
+	}
+	return values
+}
+
+// locate mencari titik perubahan predikat di antara dua sampel. Jika di
+// antara keduanya terdapat lebih dari satu perubahan, bagian kiri dicari ulang.
+func locate(m *model.Model, in Input, variable string, from, to Point, depth int) []Breakpoint {
+	lo, hi := bisect(m, in, variable, from.Value, to.Value, to.Predicate)
+	breakpoint := Breakpoint{Value: round(hi), From: lo.Predicate, To: to.Predicate}
+	if lo.Predicate == from.Predicate || depth == 0 {
+		breakpoint.From = from.Predicate
+		return []Breakpoint{breakpoint}
+	}
+	return append(locate(m, in, variable, from, lo, depth-1), breakpoint)
+}
+
+// bisect mempersempit (lo, hi] hingga hi adalah nilai terkecil yang sudah
+// menghasilkan predikat target; lo adalah titik terakhir sebelum perubahan
+func bisect(m *model.Model, in Input, variable string, lo, hi float64, target string) (Point, float64) {
+	last := Point{Value: lo}
+	for i := 0; i < bisectIterations; i++ {
+		mid := (lo + hi) / 2
+		trace := inferensia.Evaluate(m, in.with(variable, mid).Crisp, in.Levels)
+		if trace.Predicate == target {
+			hi = mid
+		} else {
+			lo = mid
+			last = Point{Value: mid, Score: trace.Score, Predicate: trace.Predicate}
+		}
+	}
+	if last.Predicate == "" {
+		trace := inferensia.Evaluate(m, in.with(variable, lo).Crisp, in.Levels)
+		last = Point{Value: lo, Score: trace.Score, Predicate: trace.Predicate}
+	}
+	return last, hi
+}
+
+// round membulatkan titik perubahan ke atas pada presisi laporan agar nilai
+// yang dilaporkan sudah menghasilkan predikat baru
+func round(value float64) float64 {
+	rounded := math.Round(value*breakpointDecimal) / breakpointDecimal
+	if rounded < value {
+		rounded += 1 / breakpointDecimal
+	}
+	return rounded
+}
@@ -0,0 +1,53 @@
+package analisis
+
+import (
+	"testing"
+
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func studentInput() Input {
+	crisp, levels := rules.Inputs(3.6, 8, 1, 3, "nasional", 3, "nasional", 3)
+	return Input{Crisp: crisp, Levels: levels}
+}
+
+func TestSensitivity(t *testing.T) {
+	m := model.Default()
+	in := studentInput()
+
+	sweeps := Sensitivity(m, in, SensitivityOptions{Steps: 50, Discrete: DiscreteVariables})
+	require.Len(t, sweeps, len(m.Variables))
+
+	for _, sweep := range sweeps {
+		t.Run(sweep.Variable, func(t *testing.T) {
+			assert.Equal(t, in.Crisp[sweep.Variable], sweep.Current)
+			assert.Equal(t, sweep.Min, sweep.Points[0].Value)
+			assert.Equal(t, sweep.Max, sweep.Points[len(sweep.Points)-1].Value)
+
+			for _, bp := range sweep.Breakpoints {
+				// Tepat pada titik perubahan predikat sudah berganti, sedikit di bawahnya belum
+				at := inferensia.Evaluate(m, in.with(sweep.Variable, bp.Value).Crisp, in.Levels)
+				assert.Equal(t, bp.To, at.Predicate)
+				if !DiscreteVariables[sweep.Variable] {
+					before := inferensia.Evaluate(m, in.with(sweep.Variable, bp.Value-0.001).Crisp, in.Levels)
+					assert.Equal(t, bp.From, before.Predicate)
+				}
+			}
+		})
+	}
+
+	ipk := sweeps[0]
+	assert.Equal(t, "ipk", ipk.Variable)
+	assert.NotEmpty(t, ipk.Breakpoints, "IPK seharusnya mengubah predikat")
+	assert.Greater(t, ipk.Impact(), 0.0)
+}
+
+func TestSamples(t *testing.T) {
+	assert.Equal(t, []float64{0, 1, 2, 3}, samples(0, 3, 10, true))
+	assert.Equal(t, []float64{0, 0.5, 1}, samples(0, 1, 2, false))
+}
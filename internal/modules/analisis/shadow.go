@@ -0,0 +1,106 @@
+package analisis
+
+import (
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+)
+
+// Subject adalah satu mahasiswa yang dievaluasi pada evaluasi bayangan
+type Subject struct {
+	ID    int
+	Input Input
+}
+
+// Transition adalah perubahan predikat satu mahasiswa dari model yang
+// sedang dipakai ke model kandidat
+type Transition struct {
+	ID        int
+	From      string
+	To        string
+	FromScore float64
+	ToScore   float64
+}
+
+// Rejection adalah mahasiswa yang masukannya ditolak validasi salah satu
+// model; Err berasal dari model pertama yang menolaknya (lihat
+// inferensia.Validate)
+type Rejection struct {
+	ID  int
+	Err error
+}
+
+// ShadowReport adalah hasil evaluasi bayangan. Predicates memuat predikat
+// kedua model, terurut dari predikat tertinggi model yang sedang dipakai
+// lalu predikat yang hanya ada pada kandidat; Matrix[lama][baru] adalah
+// jumlah mahasiswa untuk setiap pasangan predikat dan selalu memuat seluruh
+// pasangan tersebut. Affected hanya memuat mahasiswa yang predikatnya berubah.
+// Mahasiswa pada Invalid tidak dihitung pada Total maupun Matrix.
+type ShadowReport struct {
+	Predicates []string
+	Matrix     map[string]map[string]int
+	Total      int
+	Upgraded   int
+	Downgraded int
+	Affected   []Transition
+	Invalid    []Rejection
+}
+
+// Shadow menjalankan model yang sedang dipakai dan model kandidat pada
+// setiap mahasiswa tanpa menyimpan hasil apa pun, sehingga dampak perubahan
+// model dapat dilihat sebelum dipublikasikan. Naik atau turunnya predikat
+// ditentukan menurut urutan Predicates.
+func Shadow(current, candidate *model.Model, engine inferensia.Engine, subjects []Subject) ShadowReport {
+	report := ShadowReport{Matrix: map[string]map[string]int{}}
+	ranks := map[string]int{}
+	for _, m := range []*model.Model{current, candidate} {
+		for _, band := range m.Output.Bands {
+			if _, ok := ranks[band.Predicate]; !ok {
+				ranks[band.Predicate] = len(report.Predicates)
+				report.Predicates = append(report.Predicates, band.Predicate)
+			}
+		}
+	}
+	for _, from := range report.Predicates {
+		report.Matrix[from] = map[string]int{}
+		for _, to := range report.Predicates {
+			report.Matrix[from][to] = 0
+		}
+	}
+
+	for _, subject := range subjects {
+		before := inferensia.EvaluateEngine(current, engine, subject.Input.Crisp, subject.Input.Levels)
+		after := inferensia.EvaluateEngine(candidate, engine, subject.Input.Crisp, subject.Input.Levels)
+		if before.Err != nil {
+			report.Invalid = append(report.Invalid, Rejection{ID: subject.ID, Err: before.Err})
+			continue
+		}
+		if after.Err != nil {
+			report.Invalid = append(report.Invalid, Rejection{ID: subject.ID, Err: after.Err})
+			continue
+		}
+		report.Total++
+		report.Matrix[before.Predicate][after.Predicate]++
+		if before.Predicate == after.Predicate {
+			continue
+		}
+
+		if ranks[after.Predicate] < ranks[before.Predicate] {
+			report.Upgraded++
+		} else {
+			report.Downgraded++
+		}
+		report.Affected = append(report.Affected, Transition{
+			ID:        subject.ID,
+			From:      before.Predicate,
+			To:        after.Predicate,
+			FromScore: before.Score,
+			ToScore:   after.Score,
+		})
+	}
+	return report
+}
+
+// Changed mengembalikan jumlah mahasiswa yang predikatnya berubah
+func (r ShadowReport) Changed() int {
+	return len(r.Affected)
+}
@@ -0,0 +1,96 @@
+package analisis
+
+import (
+	"math/rand"
+	"testing"
+
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func shadowSubjects(m *model.Model, n int) []Subject {
+	random := rand.New(rand.NewSource(7))
+	subjects := []Subject{{ID: 1, Input: studentInput()}}
+	for i := 2; i <= n; i++ {
+		subjects = append(subjects, Subject{ID: i, Input: randomInput(m.Variables, random)})
+	}
+	return subjects
+}
+
+func TestShadow(t *testing.T) {
+	current := model.Default()
+	subjects := shadowSubjects(current, 300)
+
+	t.Run("Same Model", func(t *testing.T) {
+		report := Shadow(current, model.Default(), inferensia.EngineTsukamoto, subjects)
+
+		assert.Equal(t, 300, report.Total)
+		assert.Equal(t, 0, report.Changed())
+		assert.Empty(t, report.Affected)
+		assert.Len(t, report.Predicates, len(current.Output.Bands))
+		for _, from := range report.Predicates {
+			assert.Len(t, report.Matrix[from], len(report.Predicates))
+			for _, to := range report.Predicates {
+				if from != to {
+					assert.Zero(t, report.Matrix[from][to])
+				}
+			}
+		}
+	})
+
+	t.Run("Wider Cum Laude Band", func(t *testing.T) {
+		// Pita Cum Laude diperlebar ke bawah sehingga sebagian Sangat
+		// Memuaskan naik dan tidak ada yang turun
+		def := current.Definition()
+		for i := range def.Outputs {
+			switch def.Outputs[i].Predicate {
+			case "Cum Laude":
+				def.Outputs[i].MinScore = 2.5
+			case "Sangat Memuaskan":
+				def.Outputs[i].MaxScore = 2.5
+			}
+		}
+		candidate, err := model.Compile(def)
+		require.NoError(t, err)
+
+		report := Shadow(current, candidate, inferensia.EngineTsukamoto, subjects)
+
+		sum := 0
+		for _, row := range report.Matrix {
+			for _, count := range row {
+				sum += count
+			}
+		}
+		assert.Equal(t, report.Total, sum)
+		require.NotEmpty(t, report.Affected)
+		assert.Equal(t, report.Changed(), report.Upgraded)
+		assert.Zero(t, report.Downgraded)
+		assert.Equal(t, report.Changed(), report.Matrix["Sangat Memuaskan"]["Cum Laude"])
+		for _, transition := range report.Affected {
+			assert.Equal(t, "Sangat Memuaskan", transition.From)
+			assert.Equal(t, "Cum Laude", transition.To)
+			assert.GreaterOrEqual(t, transition.ToScore, 2.5)
+			assert.InDelta(t, transition.FromScore, transition.ToScore, 1e-9)
+		}
+	})
+
+	t.Run("Invalid Subject", func(t *testing.T) {
+		// IPK 4.5 ditolak kedua model; mahasiswa tersebut dilaporkan terpisah
+		// dan tidak masuk ke matriks
+		crisp, levels := rules.Inputs(4.5, 8, 1, 3, "nasional", 3, "nasional", 3)
+		invalid := append(subjects[:10:10], Subject{ID: 999, Input: Input{Crisp: crisp, Levels: levels}})
+
+		report := Shadow(current, model.Default(), inferensia.EngineTsukamoto, invalid)
+
+		assert.Equal(t, 10, report.Total)
+		if assert.Len(t, report.Invalid, 1) {
+			assert.Equal(t, 999, report.Invalid[0].ID)
+			var validation *inferensia.ValidationError
+			assert.ErrorAs(t, report.Invalid[0].Err, &validation)
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package defuzzifikasi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Nama metode defuzzifikasi
+const (
+	DefuzzifierCentroid        = "centroid"
+	DefuzzifierBisector        = "bisector"
+	DefuzzifierMOM             = "mom"
+	DefuzzifierSOM             = "som"
+	DefuzzifierLOM             = "lom"
+	DefuzzifierWeightedAverage = "weighted_average"
+)
+
+// maxTolerance adalah selisih derajat yang masih dianggap sama dengan
+// derajat maksimum pada metode MOM, SOM dan LOM
+const maxTolerance = 1e-9
+
+// Aggregate adalah masukan defuzzifikasi: himpunan keluaran teragregasi yang
+// disampel pada semesta keluaran (Z, Mu) serta kekuatan dan keluaran tegas
+// setiap aturan (Alpha, Outputs). Metode berbasis himpunan memakai Z dan Mu,
+// rata-rata terbobot memakai Alpha dan Outputs.
+type Aggregate struct {
+	Z       []float64
+	Mu      []float64
+	Alpha   []float64
+	Outputs []float64
+}
+
+// Defuzzifier mengubah himpunan keluaran menjadi satu nilai tegas. Defuzzify
+// mengembalikan false jika himpunan kosong (tidak ada derajat di atas nol).
+type Defuzzifier interface {
+	Name() string
+	Defuzzify(a Aggregate) (float64, bool)
+}
+
+var defuzzifiers = map[string]Defuzzifier{
+	DefuzzifierCentroid:        Centroid{},
+	DefuzzifierBisector:        Bisector{},
+	DefuzzifierMOM:             MeanOfMaximum{},
+	DefuzzifierSOM:             SmallestOfMaximum{},
+	DefuzzifierLOM:             LargestOfMaximum{},
+	DefuzzifierWeightedAverage: WeightedAverage{},
+}
+
+// NewDefuzzifier mengembalikan metode defuzzifikasi berdasarkan namanya;
+// nama kosong berarti centroid
+func NewDefuzzifier(name string) (Defuzzifier, error) {
+	if name == "" {
+		return Centroid{}, nil
+	}
+	d, ok := defuzzifiers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown defuzzifier %q", name)
+	}
+	return d, nil
+}
+
+// Defuzzifiers mengembalikan nama seluruh metode defuzzifikasi, terurut
+func Defuzzifiers() []string {
+	names := make([]string, 0, len(defuzzifiers))
+	for name := range defuzzifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Centroid adalah pusat massa himpunan: ∫z·μ(z)dz / ∫μ(z)dz
+type Centroid struct{}
+
+func (Centroid) Name() string { return DefuzzifierCentroid }
+
+func (Centroid) Defuzzify(a Aggregate) (float64, bool) {
+	moment, area := 0.0, 0.0
+	for i := 1; i < len(a.Z); i++ {
+		// Aturan trapesium per selang sampel
+		width := a.Z[i] - a.Z[i-1]
+		area += width * (a.Mu[i-1] + a.Mu[i]) / 2
+		moment += width * (a.Z[i-1]*a.Mu[i-1] + a.Z[i]*a.Mu[i]) / 2
+	}
+	if area <= 0 {
+		return 0, false
+	}
+	return moment / area, true
+}
+
+// Bisector adalah nilai z yang membagi luas himpunan menjadi dua bagian sama besar
+type Bisector struct{}
+
+func (Bisector) Name() string { return DefuzzifierBisector }
+
+func (Bisector) Defuzzify(a Aggregate) (float64, bool) {
+	areas := make([]float64, len(a.Z))
+	total := 0.0
+	for i := 1; i < len(a.Z); i++ {
+		areas[i] = (a.Z[i] - a.Z[i-1]) * (a.Mu[i-1] + a.Mu[i]) / 2
+		total += areas[i]
+	}
+	if total <= 0 {
+		return 0, false
+	}
+
+	half := total / 2
+	cumulative := 0.0
+	for i := 1; i < len(a.Z); i++ {
+		if cumulative+areas[i] < half {
+			cumulative += areas[i]
+			continue
+		}
+		// Cari titik di dalam selang [z_{i-1}, z_i] tempat luas kumulatif
+		// mencapai setengah; μ linear di dalam selang sehingga luasnya kuadratik
+		return bisectSegment(a.Z[i-1], a.Z[i], a.Mu[i-1], a.Mu[i], half-cumulative), true
+	}
+	return a.Z[len(a.Z)-1], true
+}
+
+// bisectSegment mencari t pada selang [z0, z1] dengan μ linear dari mu0 ke mu1
+// sehingga luas dari z0 hingga t sama dengan target
+func bisectSegment(z0, z1, mu0, mu1, target float64) float64 {
+	width := z1 - z0
+	slope := (mu1 - mu0) / width
+	// luas(x) = mu0·x + slope·x²/2 dengan x = t - z0
+	if math.Abs(slope) < 1e-12 {
+		if mu0 == 0 {
+			return z0
+		}
+		return z0 + target/mu0
+	}
+	x := (-mu0 + math.Sqrt(mu0*mu0+2*slope*target)) / slope
+	return z0 + math.Max(0, math.Min(width, x))
+}
+
+// MeanOfMaximum adalah rata-rata nilai z yang derajatnya maksimum
+type MeanOfMaximum struct{}
+
+func (MeanOfMaximum) Name() string { return DefuzzifierMOM }
+
+func (MeanOfMaximum) Defuzzify(a Aggregate) (float64, bool) {
+	maxima := maximum(a)
+	if len(maxima) == 0 {
+		return 0, false
+	}
+	sum := 0.0
+	for _, z := range maxima {
+		sum += z
+	}
+	return sum / float64(len(maxima)), true
+}
+
+// SmallestOfMaximum adalah nilai z terkecil yang derajatnya maksimum
+type SmallestOfMaximum struct{}
+
+func (SmallestOfMaximum) Name() string { return DefuzzifierSOM }
+
+func (SmallestOfMaximum) Defuzzify(a Aggregate) (float64, bool) {
+	maxima := maximum(a)
+	if len(maxima) == 0 {
+		return 0, false
+	}
+	return maxima[0], true
+}
+
+// LargestOfMaximum adalah nilai z terbesar yang derajatnya maksimum
+type LargestOfMaximum struct{}
+
+func (LargestOfMaximum) Name() string { return DefuzzifierLOM }
+
+func (LargestOfMaximum) Defuzzify(a Aggregate) (float64, bool) {
+	maxima := maximum(a)
+	if len(maxima) == 0 {
+		return 0, false
+	}
+	return maxima[len(maxima)-1], true
+}
+
+// maximum mengembalikan seluruh sampel z dengan derajat maksimum, terurut naik
+func maximum(a Aggregate) []float64 {
+	peak := 0.0
+	for _, mu := range a.Mu {
+		peak = math.Max(peak, mu)
+	}
+	if peak <= 0 {
+		return nil
+	}
+	var maxima []float64
+	for i, mu := range a.Mu {
+		if peak-mu <= maxTolerance {
+			maxima = append(maxima, a.Z[i])
+		}
+	}
+	return maxima
+}
+
+// WeightedAverage adalah rata-rata keluaran setiap aturan yang dibobot
+// kekuatannya: Σ(α_i·z_i) / Σα_i
+type WeightedAverage struct{}
+
+func (WeightedAverage) Name() string { return DefuzzifierWeightedAverage }
+
+func (WeightedAverage) Defuzzify(a Aggregate) (float64, bool) {
+	numerator, denominator := 0.0, 0.0
+	for i, alpha := range a.Alpha {
+		numerator += alpha * a.Outputs[i]
+		denominator += alpha
+	}
+	if denominator == 0 {
+		return 0, false
+	}
+	return numerator / denominator, true
+}
@@ -0,0 +1,133 @@
+package defuzzifikasi
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trapezoid adalah fungsi keanggotaan trapesium (a, b, c, d); segitiga jika b == c
+func trapezoid(a, b, c, d float64) func(float64) float64 {
+	return func(z float64) float64 {
+		switch {
+		case z <= a || z >= d:
+			return 0
+		case z < b:
+			return (z - a) / (b - a)
+		case z <= c:
+			return 1
+		default:
+			return (d - z) / (d - c)
+		}
+	}
+}
+
+// sample menyampel fungsi keanggotaan pada [lo, hi] dengan n selang
+func sample(mu func(float64) float64, lo, hi float64, n int) Aggregate {
+	a := Aggregate{Z: make([]float64, n+1), Mu: make([]float64, n+1)}
+	for i := range a.Z {
+		a.Z[i] = lo + (hi-lo)*float64(i)/float64(n)
+		a.Mu[i] = mu(a.Z[i])
+	}
+	return a
+}
+
+// TestDefuzzifierReference membandingkan setiap metode dengan hasil analitik
+func TestDefuzzifierReference(t *testing.T) {
+	// Trapesium (-10, -8, -4, 7) pada [-10, 10]:
+	//   luas = 1 + 4 + 5.5 = 10.5, momen = -34.5 -> centroid = -23/7
+	//   bisector: luas hingga -4 = 5, sisa 0.25 pada lereng turun
+	//   x - x²/22 = 0.25 -> z = -4 + 11 - √115.5
+	//   maksimum pada [-8, -4] -> MOM -6, SOM -8, LOM -4
+	trap := sample(trapezoid(-10, -8, -4, 7), -10, 10, 2000)
+
+	// Segitiga (0, 2, 6) pada [0, 8]:
+	//   centroid = (0 + 2 + 6) / 3 = 8/3
+	//   bisector: (6 - z)²/8 = 3/2 -> z = 6 - √12
+	//   maksimum tunggal pada z = 2
+	tri := sample(trapezoid(0, 2, 2, 6), 0, 8, 800)
+
+	// Dua dataran setinggi 1 pada [1, 2] dan [4, 5] (tepi tegak) pada [0, 6]:
+	//   centroid = 3 karena simetris (bisector tidak tunggal: setiap titik
+	//   pada [2, 4] membagi luas sama besar)
+	//   MOM adalah rata-rata seluruh titik maksimum = 3, SOM 1, LOM 5
+	plateaus := sample(func(z float64) float64 {
+		if (z >= 1 && z <= 2) || (z >= 4 && z <= 5) {
+			return 1
+		}
+		return 0
+	}, 0, 6, 600)
+
+	tests := []struct {
+		name        string
+		defuzzifier Defuzzifier
+		aggregate   Aggregate
+		expected    float64
+	}{
+		{"Centroid trapesium", Centroid{}, trap, -23.0 / 7},
+		{"Bisector trapesium", Bisector{}, trap, 7 - math.Sqrt(115.5)},
+		{"MOM trapesium", MeanOfMaximum{}, trap, -6},
+		{"SOM trapesium", SmallestOfMaximum{}, trap, -8},
+		{"LOM trapesium", LargestOfMaximum{}, trap, -4},
+
+		{"Centroid segitiga", Centroid{}, tri, 8.0 / 3},
+		{"Bisector segitiga", Bisector{}, tri, 6 - math.Sqrt(12)},
+		{"MOM segitiga", MeanOfMaximum{}, tri, 2},
+		{"SOM segitiga", SmallestOfMaximum{}, tri, 2},
+		{"LOM segitiga", LargestOfMaximum{}, tri, 2},
+
+		{"Centroid dua dataran", Centroid{}, plateaus, 3},
+		{"MOM dua dataran", MeanOfMaximum{}, plateaus, 3},
+		{"SOM dua dataran", SmallestOfMaximum{}, plateaus, 1},
+		{"LOM dua dataran", LargestOfMaximum{}, plateaus, 5},
+
+		// (0.3·2.5 + 0.5·5 + 1·6.5) / (0.3 + 0.5 + 1) = 9.75 / 1.8
+		{"Rata-rata terbobot", WeightedAverage{}, Aggregate{Alpha: []float64{0.3, 0.5, 1}, Outputs: []float64{2.5, 5, 6.5}}, 9.75 / 1.8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := tt.defuzzifier.Defuzzify(tt.aggregate)
+			require.True(t, ok)
+			assert.InDelta(t, tt.expected, value, 1e-3)
+		})
+	}
+}
+
+func TestDefuzzifierEmpty(t *testing.T) {
+	empty := sample(func(float64) float64 { return 0 }, 0, 1, 10)
+	for _, name := range Defuzzifiers() {
+		d, err := NewDefuzzifier(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, d.Name())
+
+		_, ok := d.Defuzzify(empty)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestNewDefuzzifier(t *testing.T) {
+	d, err := NewDefuzzifier("")
+	assert.NoError(t, err)
+	assert.Equal(t, Centroid{}, d)
+
+	_, err = NewDefuzzifier("median")
+	assert.Error(t, err)
+}
+
+func TestMamdaniDefuzzifier(t *testing.T) {
+	activations := []Activation{{"Magna Cum Laude", 0.7}, {"Memuaskan", 0.4}}
+
+	// SOM dan LOM mengambil ujung dataran tertinggi: himpunan Magna yang
+	// dipotong pada 0.7 mendatar dari 3.425 hingga 3.5
+	som := DefaultOutput.Mamdani(activations, ImplicationMin, SmallestOfMaximum{})
+	lom := DefaultOutput.Mamdani(activations, ImplicationMin, LargestOfMaximum{})
+	assert.InDelta(t, 3.425, som.Score, 1e-2)
+	assert.InDelta(t, 3.5, lom.Score, 1e-2)
+
+	// Rata-rata terbobot memakai centroid himpunan utuh: Magna 3.4167, Memuaskan 1.9167
+	weighted := DefaultOutput.Mamdani(activations, ImplicationMin, WeightedAverage{})
+	assert.InDelta(t, (0.7*(3.25+0.25*2/3)+0.4*(1.75+0.25*2/3))/1.1, weighted.Score, 1e-9)
+}
@@ -0,0 +1,119 @@
+package defuzzifikasi
+
+import "math"
+
+// Implication menentukan cara kekuatan penyulutan membentuk himpunan keluaran
+// aturan pada metode Mamdani
+type Implication string
+
+const (
+	// ImplicationMin memotong (clip) himpunan keluaran pada tinggi α
+	ImplicationMin Implication = "min"
+	// ImplicationProduct menskalakan himpunan keluaran dengan α (Larsen)
+	ImplicationProduct Implication = "product"
+)
+
+// outputSamples adalah jumlah selang pada semesta keluaran yang disampel
+const outputSamples = 1000
+
+// Membership menghitung derajat keanggotaan z pada himpunan keluaran. Pada
+// metode Mamdani himpunan monoton diperlakukan sebagai landai di dalam
+// [Low, High] dan bernilai 0 di luarnya, sehingga tetap berada di pita
+// predikatnya sendiri.
+func (o OutputSet) Membership(z float64) float64 {
+	if z < o.Low || z > o.High {
+		return 0
+	}
+	if o.Increasing {
+		return (z - o.Low) / (o.High - o.Low)
+	}
+	return (o.High - z) / (o.High - o.Low)
+}
+
+// Centroid adalah pusat massa himpunan keluaran utuh (landai segitiga siku-siku)
+func (o OutputSet) Centroid() float64 {
+	if o.Increasing {
+		return o.Low + (o.High-o.Low)*2/3
+	}
+	return o.Low + (o.High-o.Low)/3
+}
+
+// imply menerapkan implikasi pada derajat keanggotaan mu dengan kekuatan alpha
+func (i Implication) imply(alpha, mu float64) float64 {
+	if i == ImplicationProduct {
+		return alpha * mu
+	}
+	return math.Min(alpha, mu)
+}
+
+// Universe mengembalikan semesta keluaran: batas terendah dan tertinggi
+// seluruh himpunan keluaran
+func (o *Output) Universe() (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, set := range o.Sets {
+		lo = math.Min(lo, set.Low)
+		hi = math.Max(hi, set.High)
+	}
+	return lo, hi
+}
+
+// Mamdani menghitung nilai tegas dengan metode Mamdani: himpunan keluaran
+// setiap aturan dipotong atau diskalakan dengan α, diagregasi dengan max,
+// lalu didefuzzifikasi dengan defuzzifier (bawaan centroid) atas semesta yang
+// disampel. Z setiap kontribusi adalah centroid himpunan keluaran aturan itu
+// sendiri; rata-rata terbobot memakai centroid himpunan keluaran utuhnya.
+func (o *Output) Mamdani(activations []Activation, implication Implication, defuzzifier Defuzzifier) Result {
+	if defuzzifier == nil {
+		defuzzifier = Centroid{}
+	}
+	lo, hi := o.Universe()
+	step := (hi - lo) / outputSamples
+
+	contributions := make([]Contribution, len(activations))
+	aggregate := Aggregate{
+		Z:       make([]float64, outputSamples+1),
+		Mu:      make([]float64, outputSamples+1),
+		Alpha:   make([]float64, len(activations)),
+		Outputs: make([]float64, len(activations)),
+	}
+	for k := range aggregate.Z {
+		aggregate.Z[k] = lo + step*float64(k)
+	}
+
+	alphaSum := 0.0
+	for i, activation := range activations {
+		contributions[i] = Contribution{Predicate: activation.Predicate, Alpha: activation.Alpha}
+		set, ok := o.Sets[activation.Predicate]
+		if !ok {
+			contributions[i].Alpha = 0
+			continue
+		}
+		alphaSum += activation.Alpha
+		aggregate.Alpha[i] = activation.Alpha
+		aggregate.Outputs[i] = set.Centroid()
+
+		// Himpunan keluaran aturan setelah implikasi, diagregasi dengan max
+		rule := make([]float64, len(aggregate.Z))
+		for k, z := range aggregate.Z {
+			rule[k] = implication.imply(activation.Alpha, set.Membership(z))
+			aggregate.Mu[k] = math.Max(aggregate.Mu[k], rule[k])
+		}
+		contributions[i].Z, _ = Centroid{}.Defuzzify(Aggregate{Z: aggregate.Z, Mu: rule})
+	}
+
+	finalScore, ok := defuzzifier.Defuzzify(aggregate)
+	// Jika tidak ada hasil, kembalikan kategori terendah
+	if !ok {
+		band := o.Bands[len(o.Bands)-1]
+		return Result{Contributions: contributions, Band: band, Predicate: band.Predicate}
+	}
+
+	for i := range contributions {
+		if alphaSum > 0 {
+			contributions[i].Normalized = contributions[i].Alpha / alphaSum
+		}
+	}
+
+	band := o.Band(finalScore)
+	return Result{Contributions: contributions, Score: finalScore, Band: band, Predicate: band.Predicate}
+}
@@ -0,0 +1,63 @@
+package defuzzifikasi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputSetMembership(t *testing.T) {
+	up := OutputSet{Low: 2, High: 4, Increasing: true}
+	down := OutputSet{Low: 2, High: 4, Increasing: false}
+
+	assert.InDelta(t, 0.5, up.Membership(3), 1e-9)
+	assert.InDelta(t, 1, up.Membership(4), 1e-9)
+	assert.InDelta(t, 0.25, down.Membership(3.5), 1e-9)
+	assert.Equal(t, 0.0, up.Membership(1.9))
+	assert.Equal(t, 0.0, down.Membership(4.1))
+}
+
+func TestMamdani(t *testing.T) {
+	t.Run("Aturan tunggal jatuh pada predikatnya", func(t *testing.T) {
+		for predicate := range DefaultOutput.Sets {
+			for _, implication := range []Implication{ImplicationMin, ImplicationProduct} {
+				result := DefaultOutput.Mamdani([]Activation{{Predicate: predicate, Alpha: 0.6}}, implication, nil)
+				assert.Equal(t, predicate, result.Predicate)
+			}
+		}
+	})
+
+	t.Run("Centroid segitiga", func(t *testing.T) {
+		// Himpunan naik [0, 3] dengan α = 1 adalah segitiga siku-siku dengan
+		// centroid 2/3 panjang alas: z = 2 (toleransi mengikuti resolusi sampel)
+		output, err := NewOutput(
+			map[string]OutputSet{"Tinggi": {Low: 0, High: 3, Increasing: true}},
+			[]Band{{Predicate: "Tinggi", MinScore: 0}},
+		)
+		assert.NoError(t, err)
+
+		result := output.Mamdani([]Activation{{Predicate: "Tinggi", Alpha: 1}}, ImplicationMin, nil)
+		assert.InDelta(t, 2, result.Score, 1e-2)
+		assert.InDelta(t, 2, result.Contributions[0].Z, 1e-2)
+
+		// Implikasi product tidak mengubah bentuk sehingga centroid tetap
+		scaled := output.Mamdani([]Activation{{Predicate: "Tinggi", Alpha: 0.4}}, ImplicationProduct, nil)
+		assert.InDelta(t, 2, scaled.Score, 1e-2)
+
+		// Pemotongan pada α = 0.5 menggeser centroid ke kiri
+		clipped := output.Mamdani([]Activation{{Predicate: "Tinggi", Alpha: 0.5}}, ImplicationMin, nil)
+		assert.Less(t, clipped.Score, 2.0)
+	})
+
+	t.Run("Agregasi max", func(t *testing.T) {
+		result := DefaultOutput.Mamdani([]Activation{{"Summa Cum Laude", 0.8}, {"Cukup", 0.2}}, ImplicationMin, nil)
+		assert.InDelta(t, 0.8, result.Contributions[0].Normalized, 1e-9)
+		assert.Greater(t, result.Score, 2.5)
+	})
+
+	t.Run("Tanpa aktivasi", func(t *testing.T) {
+		result := DefaultOutput.Mamdani([]Activation{{"Summa Cum Laude", 0}}, ImplicationMin, nil)
+		assert.Equal(t, "Cukup", result.Predicate)
+		assert.Equal(t, 0.0, result.Score)
+	})
+}
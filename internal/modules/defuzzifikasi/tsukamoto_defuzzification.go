@@ -1,60 +1,283 @@
 package defuzzifikasi
 
-import log "github.com/sirupsen/logrus"
+import (
+	"fmt"
+	"sort"
 
-// Bobot nilai masing-masing kategori
-var categoryWeights = map[string]float64{
-	"Summa Cum Laude":  4.0,
-	"Magna Cum Laude":  3.5,
-	"Cum Laude":        3.0,
-	"Sangat Memuaskan": 2.5,
-	"Memuaskan":        2.0,
-	"Cukup":            1.5,
+	log "github.com/sirupsen/logrus"
+)
+
+// OutputSet adalah himpunan fuzzy keluaran yang monoton untuk satu predikat.
+// Pada metode Tsukamoto setiap konsekuen harus monoton sehingga dapat
+// diinversikan: untuk derajat α diperoleh nilai tegas z dengan μ(z) = α.
+type OutputSet struct {
+	Low        float64
+	High       float64
+	Increasing bool
 }
 
-// Defuzzify the results using Weighted Average Method (WAM)
-func Defuzzify(ruleResults map[string]float64) string {
-	log.Infof("Hasil Aturan Fuzzy: %+v", ruleResults)
+// Invert menghitung nilai z dari himpunan keluaran pada derajat alpha
+func (o OutputSet) Invert(alpha float64) float64 {
+	if alpha <= 0 {
+		alpha = 0
+	}
+	if alpha >= 1 {
+		alpha = 1
+	}
+	if o.Increasing {
+		return o.Low + alpha*(o.High-o.Low)
+	}
+	return o.High - alpha*(o.High-o.Low)
+}
 
-	numerator := 0.0
-	denominator := 0.0
+// Center adalah titik tengah himpunan keluaran; dipakai sebagai konsekuen
+// Sugeno orde nol jika aturan tidak menentukan keluarannya sendiri
+func (o OutputSet) Center() float64 {
+	return (o.Low + o.High) / 2
+}
+
+// Band adalah rentang skor akhir [MinScore, MaxScore) untuk sebuah predikat.
+// MaxScore pita tertinggi bersifat inklusif.
+type Band struct {
+	Predicate string
+	MinScore  float64
+	MaxScore  float64
+}
+
+// bandTolerance adalah selisih batas pita yang masih dianggap bersambung
+const bandTolerance = 1e-9
+
+// Output adalah variabel keluaran: himpunan monoton setiap predikat dan
+// pita skor yang memetakan skor akhir ke predikat
+type Output struct {
+	Sets  map[string]OutputSet
+	Bands []Band
+}
+
+// Activation adalah kekuatan penyulutan (α) satu aturan beserta predikat konsekuennya
+type Activation struct {
+	Predicate string
+	Alpha     float64
+}
 
-	for predicate, membership := range ruleResults {
-		weight := categoryWeights[predicate]
-		numerator += membership * weight
-		denominator += membership
+// DefaultOutput adalah variabel keluaran bawaan pada semesta skor [1, 4].
+// Setiap himpunan berada di dalam pita predikatnya sendiri sehingga aturan
+// tunggal selalu jatuh pada predikatnya.
+var DefaultOutput = &Output{
+	Sets: map[string]OutputSet{
+		"Summa Cum Laude":  {Low: 3.75, High: 4.00, Increasing: true},
+		"Magna Cum Laude":  {Low: 3.25, High: 3.50, Increasing: true},
+		"Cum Laude":        {Low: 2.75, High: 3.00, Increasing: true},
+		"Sangat Memuaskan": {Low: 2.25, High: 2.50, Increasing: true},
+		"Memuaskan":        {Low: 1.75, High: 2.00, Increasing: true},
+		"Cukup":            {Low: 1.00, High: 1.75, Increasing: false},
+	},
+	Bands: []Band{
+		{Predicate: "Summa Cum Laude", MinScore: 3.75, MaxScore: 4.00},
+		{Predicate: "Magna Cum Laude", MinScore: 3.25, MaxScore: 3.75},
+		{Predicate: "Cum Laude", MinScore: 2.75, MaxScore: 3.25},
+		{Predicate: "Sangat Memuaskan", MinScore: 2.25, MaxScore: 2.75},
+		{Predicate: "Memuaskan", MinScore: 1.75, MaxScore: 2.25},
+		{Predicate: "Cukup", MinScore: 0, MaxScore: 1.75},
+	},
+}
+
+// NewOutput membuat variabel keluaran dan memvalidasi bahwa setiap pita
+// memiliki himpunan keluaran, terurut menurun, bersambung tanpa celah maupun
+// tumpang tindih, dan mencakup seluruh semesta keluaran. MaxScore kosong
+// diisi dengan skor minimum pita di atasnya (pita tertinggi: batas atas
+// semesta keluaran).
+func NewOutput(sets map[string]OutputSet, bands []Band) (*Output, error) {
+	if len(bands) == 0 {
+		return nil, fmt.Errorf("output has no predicate bands")
+	}
+	for predicate, set := range sets {
+		if set.Low >= set.High {
+			return nil, fmt.Errorf("output set %q needs low < high", predicate)
+		}
+	}
+	output := &Output{Sets: sets, Bands: make([]Band, len(bands))}
+	lo, hi := output.Universe()
+
+	seen := map[string]bool{}
+	for i, band := range bands {
+		if _, ok := sets[band.Predicate]; !ok {
+			return nil, fmt.Errorf("predicate %q has no output set", band.Predicate)
+		}
+		if seen[band.Predicate] {
+			return nil, fmt.Errorf("predicate %q has more than one band", band.Predicate)
+		}
+		seen[band.Predicate] = true
+		if i > 0 && band.MinScore >= bands[i-1].MinScore {
+			return nil, fmt.Errorf("bands must be ordered by decreasing minimum score")
+		}
+
+		if band.MaxScore == 0 {
+			band.MaxScore = hi
+			if i > 0 {
+				band.MaxScore = output.Bands[i-1].MinScore
+			}
+		}
+		if band.MinScore >= band.MaxScore {
+			return nil, fmt.Errorf("band %q needs min_score < max_score", band.Predicate)
+		}
+		if i > 0 {
+			above := output.Bands[i-1]
+			switch {
+			case band.MaxScore > above.MinScore+bandTolerance:
+				return nil, fmt.Errorf("band %q overlaps band %q between %v and %v", band.Predicate, above.Predicate, above.MinScore, band.MaxScore)
+			case band.MaxScore < above.MinScore-bandTolerance:
+				return nil, fmt.Errorf("gap between band %q and band %q from %v to %v", band.Predicate, above.Predicate, band.MaxScore, above.MinScore)
+			}
+		}
+		output.Bands[i] = band
+	}
+	for predicate := range sets {
+		if !seen[predicate] {
+			return nil, fmt.Errorf("predicate %q has no band", predicate)
+		}
 	}
 
-	// Jika tidak ada hasil, kembalikan kategori default
-	if denominator == 0 {
-		log.Info("Denominator 0, returning Cukup")
-		return "Cukup"
+	if top := output.Bands[0]; top.MaxScore < hi-bandTolerance {
+		return nil, fmt.Errorf("band %q ends at %v below the output universe maximum %v", top.Predicate, top.MaxScore, hi)
 	}
+	if bottom := output.Bands[len(output.Bands)-1]; bottom.MinScore > lo+bandTolerance {
+		return nil, fmt.Errorf("band %q starts at %v above the output universe minimum %v", bottom.Predicate, bottom.MinScore, lo)
+	}
+	return output, nil
+}
 
-	// Hitung hasil akhir (WAM)
-	finalScore := numerator / denominator
-	log.Infof("Final Score: %f", finalScore)
+// Predicates mengembalikan nama predikat yang memiliki himpunan keluaran, terurut
+func (o *Output) Predicates() []string {
+	predicates := make([]string, 0, len(o.Sets))
+	for predicate := range o.Sets {
+		predicates = append(predicates, predicate)
+	}
+	sort.Strings(predicates)
+	return predicates
+}
 
-	result := determineCategory(finalScore)
-	log.Infof("Hasil Defuzzifikasi: %s", result)
+// Predicates mengembalikan nama predikat pada variabel keluaran bawaan
+func Predicates() []string {
+	return DefaultOutput.Predicates()
+}
+
+// Contribution adalah keluaran satu aturan: z hasil inversi α dan porsi
+// α terhadap jumlah seluruh α (bobot ternormalisasi)
+type Contribution struct {
+	Predicate  string
+	Alpha      float64
+	Z          float64
+	Normalized float64
+}
+
+// Result adalah rincian defuzzifikasi. Interval hanya diisi pada model
+// interval tipe-2.
+type Result struct {
+	Contributions []Contribution
+	Score         float64
+	Band          Band
+	Predicate     string
+	Interval      *ScoreInterval
+}
+
+// Defuzzify menghitung nilai tegas dengan metode Tsukamoto dan mengembalikan predikatnya
+func (o *Output) Defuzzify(activations []Activation) string {
+	return o.Evaluate(activations).Predicate
+}
+
+// Evaluate menghitung nilai tegas seperti Compute dan mencatat setiap langkahnya
+func (o *Output) Evaluate(activations []Activation) Result {
+	log.Infof("Hasil Aturan Fuzzy: %+v", activations)
+
+	result := o.Compute(activations)
+	for _, contribution := range result.Contributions {
+		if _, ok := o.Sets[contribution.Predicate]; !ok {
+			log.Warnf("Himpunan keluaran untuk predikat %q tidak ditemukan", contribution.Predicate)
+			continue
+		}
+		log.Infof("Inversi %s: alpha=%f, z=%f", contribution.Predicate, contribution.Alpha, contribution.Z)
+	}
+	log.Infof("Final Score: %f", result.Score)
+	log.Infof("Hasil Defuzzifikasi: %s", result.Predicate)
 
 	return result
 }
 
-// Tentukan kategori berdasarkan skor akhir
-func determineCategory(score float64) string {
-	switch {
-	case score >= 3.75:
-		return "Summa Cum Laude"
-	case score >= 3.25:
-		return "Magna Cum Laude"
-	case score >= 2.75:
-		return "Cum Laude"
-	case score >= 2.25:
-		return "Sangat Memuaskan"
-	case score >= 1.75:
-		return "Memuaskan"
-	default:
-		return "Cukup"
+// Compute menghitung nilai tegas dengan metode Tsukamoto: setiap α_i
+// diinversikan pada himpunan keluarannya menjadi z_i, lalu
+// z = Σ(α_i·z_i) / Σα_i
+func (o *Output) Compute(activations []Activation) Result {
+	outputs := make([]float64, len(activations))
+	for i, activation := range activations {
+		if set, ok := o.Sets[activation.Predicate]; ok {
+			outputs[i] = set.Invert(activation.Alpha)
+		}
+	}
+	return o.WeightedAverage(activations, outputs)
+}
+
+// WeightedAverage menghitung z = Σ(α_i·z_i) / Σα_i dari keluaran aturan z_i
+// yang sudah diketahui. Aktivasi tanpa himpunan keluaran diabaikan.
+func (o *Output) WeightedAverage(activations []Activation, outputs []float64) Result {
+	contributions := make([]Contribution, len(activations))
+	aggregate := Aggregate{Alpha: make([]float64, len(activations)), Outputs: outputs}
+	denominator := 0.0
+
+	for i, activation := range activations {
+		contributions[i] = Contribution{Predicate: activation.Predicate, Alpha: activation.Alpha}
+		if _, ok := o.Sets[activation.Predicate]; !ok {
+			contributions[i].Alpha = 0
+			continue
+		}
+		contributions[i].Z = outputs[i]
+		aggregate.Alpha[i] = activation.Alpha
+		denominator += activation.Alpha
+	}
+
+	finalScore, ok := WeightedAverage{}.Defuzzify(aggregate)
+	// Jika tidak ada hasil, kembalikan kategori terendah
+	if !ok {
+		band := o.Bands[len(o.Bands)-1]
+		return Result{Contributions: contributions, Band: band, Predicate: band.Predicate}
+	}
+
+	for i := range contributions {
+		contributions[i].Normalized = contributions[i].Alpha / denominator
+	}
+
+	// Hitung hasil akhir
+	band := o.Band(finalScore)
+	return Result{Contributions: contributions, Score: finalScore, Band: band, Predicate: band.Predicate}
+}
+
+// Defuzzify memakai variabel keluaran bawaan
+func Defuzzify(activations []Activation) string {
+	return DefaultOutput.Defuzzify(activations)
+}
+
+// Category menentukan predikat berdasarkan skor akhir
+func (o *Output) Category(score float64) string {
+	return o.Band(score).Predicate
+}
+
+// Band mengembalikan pita pertama yang skor minimumnya terpenuhi
+func (o *Output) Band(score float64) Band {
+	for _, band := range o.Bands {
+		if score >= band.MinScore {
+			return band
+		}
+	}
+	return o.Bands[len(o.Bands)-1]
+}
+
+// Upper mengembalikan batas atas (eksklusif) pita sebuah predikat; false
+// untuk pita tertinggi yang tidak memiliki batas atas
+func (o *Output) Upper(predicate string) (float64, bool) {
+	for i, band := range o.Bands {
+		if band.Predicate == predicate && i > 0 {
+			return band.MaxScore, true
+		}
 	}
+	return 0, false
 }
@@ -0,0 +1,136 @@
+package defuzzifikasi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputSetInvert(t *testing.T) {
+	up := OutputSet{Low: 2, High: 4, Increasing: true}
+	down := OutputSet{Low: 2, High: 4, Increasing: false}
+
+	tests := []struct {
+		name     string
+		set      OutputSet
+		alpha    float64
+		expected float64
+	}{
+		{"Naik alpha 0", up, 0, 2},
+		{"Naik alpha 0.5", up, 0.5, 3},
+		{"Naik alpha 1", up, 1, 4},
+		{"Turun alpha 0", down, 0, 4},
+		{"Turun alpha 0.25", down, 0.25, 3.5},
+		{"Turun alpha 1", down, 1, 2},
+		{"Alpha di luar rentang", up, 1.5, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tt.set.Invert(tt.alpha), 1e-9)
+		})
+	}
+}
+
+func TestDefuzzify(t *testing.T) {
+	t.Run("Aturan tunggal jatuh pada predikatnya", func(t *testing.T) {
+		for predicate := range DefaultOutput.Sets {
+			for _, alpha := range []float64{0.05, 0.5, 1} {
+				assert.Equal(t, predicate, Defuzzify([]Activation{{Predicate: predicate, Alpha: alpha}}))
+			}
+		}
+	})
+
+	t.Run("Rata-rata terbobot z", func(t *testing.T) {
+		// Summa: z = 3.75 + 0.8*0.25 = 3.95, Cukup: z = 1.75 - 0.2*0.75 = 1.6
+		// (0.8*3.95 + 0.2*1.6) / 1.0 = 3.48 -> Magna Cum Laude
+		result := Defuzzify([]Activation{{"Summa Cum Laude", 0.8}, {"Cukup", 0.2}})
+		assert.Equal(t, "Magna Cum Laude", result)
+	})
+
+	t.Run("Tanpa aturan aktif", func(t *testing.T) {
+		assert.Equal(t, "Cukup", Defuzzify([]Activation{{"Cum Laude", 0}}))
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	result := DefaultOutput.Evaluate([]Activation{{"Summa Cum Laude", 0.8}, {"Cukup", 0.2}})
+
+	assert.InDelta(t, 3.48, result.Score, 1e-9)
+	assert.Equal(t, Band{Predicate: "Magna Cum Laude", MinScore: 3.25, MaxScore: 3.75}, result.Band)
+	assert.Len(t, result.Contributions, 2)
+	assert.InDelta(t, 3.95, result.Contributions[0].Z, 1e-9)
+	assert.InDelta(t, 0.8, result.Contributions[0].Normalized, 1e-9)
+	assert.InDelta(t, 1.6, result.Contributions[1].Z, 1e-9)
+
+	upper, ok := DefaultOutput.Upper("Magna Cum Laude")
+	assert.True(t, ok)
+	assert.Equal(t, 3.75, upper)
+	_, ok = DefaultOutput.Upper("Summa Cum Laude")
+	assert.False(t, ok)
+}
+
+func TestNewOutput(t *testing.T) {
+	sets := map[string]OutputSet{
+		"Baik":  {Low: 2, High: 3, Increasing: true},
+		"Cukup": {Low: 1, High: 2},
+	}
+
+	output, err := NewOutput(sets, []Band{{Predicate: "Baik", MinScore: 2}, {Predicate: "Cukup", MinScore: 0}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Baik", output.Category(2.5))
+	assert.Equal(t, "Cukup", output.Category(1.9))
+	assert.Equal(t, []string{"Baik", "Cukup"}, output.Predicates())
+
+	_, err = NewOutput(sets, []Band{{Predicate: "Cukup", MinScore: 0}, {Predicate: "Baik", MinScore: 2}})
+	assert.Error(t, err, "pita tidak terurut")
+	_, err = NewOutput(sets, []Band{{Predicate: "Baik", MinScore: 2}})
+	assert.Error(t, err, "himpunan tanpa pita")
+	_, err = NewOutput(sets, []Band{{Predicate: "Baik", MinScore: 2}, {Predicate: "Istimewa", MinScore: 1}, {Predicate: "Cukup", MinScore: 0}})
+	assert.Error(t, err, "pita tanpa himpunan")
+	_, err = NewOutput(map[string]OutputSet{"Baik": {Low: 3, High: 2}}, []Band{{Predicate: "Baik", MinScore: 0}})
+	assert.Error(t, err, "himpunan terbalik")
+}
+
+func TestNewOutputContiguous(t *testing.T) {
+	sets := map[string]OutputSet{
+		"Baik":  {Low: 2, High: 3, Increasing: true},
+		"Cukup": {Low: 1, High: 2},
+	}
+
+	// MaxScore kosong diisi dari pita di atasnya dan batas atas semesta
+	output, err := NewOutput(sets, []Band{{Predicate: "Baik", MinScore: 2}, {Predicate: "Cukup", MinScore: 0}})
+	require.NoError(t, err)
+	assert.Equal(t, []Band{{Predicate: "Baik", MinScore: 2, MaxScore: 3}, {Predicate: "Cukup", MinScore: 0, MaxScore: 2}}, output.Bands)
+
+	tests := []struct {
+		name  string
+		bands []Band
+	}{
+		{"Celah", []Band{{Predicate: "Baik", MinScore: 2, MaxScore: 3}, {Predicate: "Cukup", MinScore: 1, MaxScore: 1.8}}},
+		{"Tumpang tindih", []Band{{Predicate: "Baik", MinScore: 2, MaxScore: 3}, {Predicate: "Cukup", MinScore: 1, MaxScore: 2.2}}},
+		{"Pita terbalik", []Band{{Predicate: "Baik", MinScore: 2, MaxScore: 1.5}, {Predicate: "Cukup", MinScore: 1}}},
+		{"Tidak mencapai batas atas semesta", []Band{{Predicate: "Baik", MinScore: 2, MaxScore: 2.5}, {Predicate: "Cukup", MinScore: 1}}},
+		{"Tidak mencapai batas bawah semesta", []Band{{Predicate: "Baik", MinScore: 2}, {Predicate: "Cukup", MinScore: 1.5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewOutput(sets, tt.bands)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWeightedAverage(t *testing.T) {
+	// (0.6*3.2 + 0.2*2.0) / 0.8 = 2.9 -> Cum Laude; predikat tanpa himpunan diabaikan
+	result := DefaultOutput.WeightedAverage(
+		[]Activation{{"Cum Laude", 0.6}, {"Memuaskan", 0.2}, {"Istimewa", 0.5}},
+		[]float64{3.2, 2.0, 10},
+	)
+	assert.InDelta(t, 2.9, result.Score, 1e-9)
+	assert.Equal(t, "Cum Laude", result.Predicate)
+	assert.InDelta(t, 0.75, result.Contributions[0].Normalized, 1e-9)
+	assert.Equal(t, 0.0, result.Contributions[2].Alpha)
+}
@@ -0,0 +1,199 @@
+package defuzzifikasi
+
+import (
+	"math"
+	"sort"
+)
+
+// IntervalActivation adalah kekuatan penyulutan interval [Lower, Upper] satu
+// aturan pada model interval tipe-2
+type IntervalActivation struct {
+	Predicate string
+	Lower     float64
+	Upper     float64
+}
+
+// ScoreInterval adalah interval ketidakpastian skor hasil reduksi tipe
+// beserta predikat pada kedua ujungnya
+type ScoreInterval struct {
+	Lower          float64
+	Upper          float64
+	LowerPredicate string
+	UpperPredicate string
+}
+
+// kmTolerance adalah perubahan titik alih Karnik-Mendel yang dianggap konvergen
+const kmTolerance = 1e-12
+
+// karnikMendel menghitung ujung kiri (right false) atau kanan (right true)
+// dari Σ(θ_i·y_i) / Σθ_i untuk θ_i pada [lower_i, upper_i] dengan algoritma
+// Karnik-Mendel: mulai dari titik tengah interval, cari titik alih k dengan
+// y_k <= y < y_(k+1), pilih bobot atas di sisi kiri titik alih dan bobot
+// bawah di sisi kanannya (sebaliknya untuk ujung kanan), lalu ulangi hingga
+// titik alih tidak berubah. false jika seluruh bobot atas nol.
+func karnikMendel(points, lower, upper []float64, right bool) (float64, bool) {
+	order := make([]int, len(points))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return points[order[a]] < points[order[b]] })
+
+	weighted := func(theta func(i, rank int) float64) (float64, bool) {
+		numerator, denominator := 0.0, 0.0
+		for rank, i := range order {
+			w := theta(i, rank)
+			numerator += w * points[i]
+			denominator += w
+		}
+		if denominator <= 0 {
+			return 0, false
+		}
+		return numerator / denominator, true
+	}
+
+	y, ok := weighted(func(i, _ int) float64 { return (lower[i] + upper[i]) / 2 })
+	if !ok {
+		if y, ok = weighted(func(i, _ int) float64 { return upper[i] }); !ok {
+			return 0, false
+		}
+	}
+	for iteration := 0; iteration <= len(points); iteration++ {
+		switchPoint := -1
+		for rank, i := range order {
+			if points[i] <= y {
+				switchPoint = rank
+			}
+		}
+		next, ok := weighted(func(i, rank int) float64 {
+			if (rank <= switchPoint) != right {
+				return upper[i]
+			}
+			return lower[i]
+		})
+		if !ok || math.Abs(next-y) < kmTolerance {
+			break
+		}
+		y = next
+	}
+	return y, true
+}
+
+// IntervalCompute adalah Compute untuk model interval tipe-2. Setiap aturan
+// menghasilkan interval z dari inversi batas bawah dan atas penyulutannya,
+// lalu interval skor dihitung dengan reduksi tipe center-of-sets
+// Karnik-Mendel. Reduksi ini memperlakukan z dan α secara terpisah sehingga
+// intervalnya mencakup seluruh kombinasi di dalam footprint of uncertainty.
+func (o *Output) IntervalCompute(activations []IntervalActivation) Result {
+	left := make([]float64, len(activations))
+	right := make([]float64, len(activations))
+	for i, activation := range activations {
+		if set, ok := o.Sets[activation.Predicate]; ok {
+			a, b := set.Invert(activation.Lower), set.Invert(activation.Upper)
+			left[i], right[i] = math.Min(a, b), math.Max(a, b)
+		}
+	}
+	return o.IntervalWeightedAverage(activations, left, right)
+}
+
+// IntervalWeightedAverage menghitung interval skor dari keluaran interval
+// [left_i, right_i] setiap aturan dengan reduksi tipe center-of-sets
+// Karnik-Mendel. Skor adalah titik tengah interval; kontribusi memakai titik
+// tengah penyulutan dan keluaran setiap aturan.
+func (o *Output) IntervalWeightedAverage(activations []IntervalActivation, left, right []float64) Result {
+	contributions := make([]Contribution, len(activations))
+	lower := make([]float64, len(activations))
+	upper := make([]float64, len(activations))
+	alphaSum := 0.0
+	for i, activation := range activations {
+		contributions[i] = Contribution{Predicate: activation.Predicate}
+		if _, ok := o.Sets[activation.Predicate]; !ok {
+			continue
+		}
+		lower[i], upper[i] = activation.Lower, activation.Upper
+		contributions[i].Alpha = (activation.Lower + activation.Upper) / 2
+		contributions[i].Z = (left[i] + right[i]) / 2
+		alphaSum += contributions[i].Alpha
+	}
+
+	yl, okLeft := karnikMendel(left, lower, upper, false)
+	yr, okRight := karnikMendel(right, lower, upper, true)
+	if !okLeft || !okRight {
+		band := o.Bands[len(o.Bands)-1]
+		return Result{Contributions: contributions, Band: band, Predicate: band.Predicate}
+	}
+	for i := range contributions {
+		if alphaSum > 0 {
+			contributions[i].Normalized = contributions[i].Alpha / alphaSum
+		}
+	}
+	return o.intervalResult(contributions, yl, yr)
+}
+
+// IntervalMamdani adalah Mamdani untuk model interval tipe-2: fungsi
+// keanggotaan atas dan bawah keluaran teragregasi dibentuk dari batas atas
+// dan bawah penyulutan, lalu interval centroid-nya dihitung dengan
+// Karnik-Mendel atas semesta yang disampel. Reduksi tipe selalu memakai
+// centroid.
+func (o *Output) IntervalMamdani(activations []IntervalActivation, implication Implication) Result {
+	lo, hi := o.Universe()
+	step := (hi - lo) / outputSamples
+	z := make([]float64, outputSamples+1)
+	lower := make([]float64, len(z))
+	upper := make([]float64, len(z))
+	for k := range z {
+		z[k] = lo + step*float64(k)
+	}
+
+	contributions := make([]Contribution, len(activations))
+	alphaSum := 0.0
+	for i, activation := range activations {
+		contributions[i] = Contribution{Predicate: activation.Predicate}
+		set, ok := o.Sets[activation.Predicate]
+		if !ok {
+			continue
+		}
+		contributions[i].Alpha = (activation.Lower + activation.Upper) / 2
+		alphaSum += contributions[i].Alpha
+
+		rule := make([]float64, len(z))
+		for k := range z {
+			mu := set.Membership(z[k])
+			lower[k] = math.Max(lower[k], implication.imply(activation.Lower, mu))
+			upper[k] = math.Max(upper[k], implication.imply(activation.Upper, mu))
+			rule[k] = implication.imply(contributions[i].Alpha, mu)
+		}
+		contributions[i].Z, _ = Centroid{}.Defuzzify(Aggregate{Z: z, Mu: rule})
+	}
+
+	yl, okLeft := karnikMendel(z, lower, upper, false)
+	yr, okRight := karnikMendel(z, lower, upper, true)
+	if !okLeft || !okRight {
+		band := o.Bands[len(o.Bands)-1]
+		return Result{Contributions: contributions, Band: band, Predicate: band.Predicate}
+	}
+	for i := range contributions {
+		if alphaSum > 0 {
+			contributions[i].Normalized = contributions[i].Alpha / alphaSum
+		}
+	}
+	return o.intervalResult(contributions, yl, yr)
+}
+
+// intervalResult menyusun hasil dari interval skor [yl, yr]; predikat
+// ditentukan dari titik tengahnya
+func (o *Output) intervalResult(contributions []Contribution, yl, yr float64) Result {
+	score := (yl + yr) / 2
+	band := o.Band(score)
+	return Result{
+		Contributions: contributions,
+		Score:         score,
+		Band:          band,
+		Predicate:     band.Predicate,
+		Interval: &ScoreInterval{
+			Lower:          yl,
+			Upper:          yr,
+			LowerPredicate: o.Category(yl),
+			UpperPredicate: o.Category(yr),
+		},
+	}
+}
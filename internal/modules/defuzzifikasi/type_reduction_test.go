@@ -0,0 +1,127 @@
+package defuzzifikasi
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exhaustive mencari ujung interval dengan mencoba setiap titik alih; ujung
+// Σθy/Σθ selalu dicapai pada salah satu titik alih
+func exhaustive(points, lower, upper []float64, right bool) float64 {
+	order := make([]int, len(points))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return points[order[a]] < points[order[b]] })
+	best := math.Inf(1)
+	if right {
+		best = math.Inf(-1)
+	}
+	for k := -1; k < len(points); k++ {
+		numerator, denominator := 0.0, 0.0
+		for rank, i := range order {
+			w := lower[i]
+			if (rank <= k) != right {
+				w = upper[i]
+			}
+			numerator += w * points[i]
+			denominator += w
+		}
+		if denominator == 0 {
+			continue
+		}
+		if right {
+			best = math.Max(best, numerator/denominator)
+		} else {
+			best = math.Min(best, numerator/denominator)
+		}
+	}
+	return best
+}
+
+func TestKarnikMendel(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		n := 1 + random.Intn(8)
+		points := make([]float64, n)
+		lower := make([]float64, n)
+		upper := make([]float64, n)
+		for i := range points {
+			points[i] = 1 + 3*random.Float64()
+			upper[i] = random.Float64()
+			lower[i] = upper[i] * random.Float64()
+		}
+
+		left, ok := karnikMendel(points, lower, upper, false)
+		require.True(t, ok)
+		right, ok := karnikMendel(points, lower, upper, true)
+		require.True(t, ok)
+		assert.InDelta(t, exhaustive(points, lower, upper, false), left, 1e-9)
+		assert.InDelta(t, exhaustive(points, lower, upper, true), right, 1e-9)
+		assert.LessOrEqual(t, left, right+1e-12)
+	}
+
+	_, ok := karnikMendel([]float64{1, 2}, []float64{0, 0}, []float64{0, 0}, false)
+	assert.False(t, ok)
+}
+
+func TestIntervalCompute(t *testing.T) {
+	t.Run("Interval tanpa lebar sama dengan tipe-1", func(t *testing.T) {
+		activations := []Activation{{Predicate: "Cum Laude", Alpha: 0.6}, {Predicate: "Magna Cum Laude", Alpha: 0.3}}
+		intervals := []IntervalActivation{{Predicate: "Cum Laude", Lower: 0.6, Upper: 0.6}, {Predicate: "Magna Cum Laude", Lower: 0.3, Upper: 0.3}}
+
+		expected := DefaultOutput.Compute(activations)
+		result := DefaultOutput.IntervalCompute(intervals)
+		require.NotNil(t, result.Interval)
+		assert.InDelta(t, expected.Score, result.Score, 1e-9)
+		assert.InDelta(t, expected.Score, result.Interval.Lower, 1e-9)
+		assert.InDelta(t, expected.Score, result.Interval.Upper, 1e-9)
+		assert.Equal(t, expected.Predicate, result.Predicate)
+	})
+
+	t.Run("Interval mencakup skor setiap kombinasi penyulutan", func(t *testing.T) {
+		intervals := []IntervalActivation{{Predicate: "Cum Laude", Lower: 0.2, Upper: 0.7}, {Predicate: "Magna Cum Laude", Lower: 0.1, Upper: 0.5}}
+		result := DefaultOutput.IntervalCompute(intervals)
+		require.NotNil(t, result.Interval)
+		assert.Less(t, result.Interval.Lower, result.Interval.Upper)
+		for _, a := range []float64{0.2, 0.45, 0.7} {
+			for _, b := range []float64{0.1, 0.3, 0.5} {
+				score := DefaultOutput.Compute([]Activation{{Predicate: "Cum Laude", Alpha: a}, {Predicate: "Magna Cum Laude", Alpha: b}}).Score
+				assert.GreaterOrEqual(t, score, result.Interval.Lower-1e-9)
+				assert.LessOrEqual(t, score, result.Interval.Upper+1e-9)
+			}
+		}
+		assert.Equal(t, DefaultOutput.Category(result.Interval.Lower), result.Interval.LowerPredicate)
+		assert.Equal(t, DefaultOutput.Category(result.Interval.Upper), result.Interval.UpperPredicate)
+	})
+
+	t.Run("Tanpa aturan yang menyala", func(t *testing.T) {
+		result := DefaultOutput.IntervalCompute([]IntervalActivation{{Predicate: "Cum Laude"}})
+		assert.Equal(t, "Cukup", result.Predicate)
+		assert.Nil(t, result.Interval)
+	})
+}
+
+func TestIntervalMamdani(t *testing.T) {
+	activations := []Activation{{Predicate: "Cum Laude", Alpha: 0.6}, {Predicate: "Magna Cum Laude", Alpha: 0.3}}
+	expected := DefaultOutput.Mamdani(activations, ImplicationMin, Centroid{})
+	result := DefaultOutput.IntervalMamdani([]IntervalActivation{
+		{Predicate: "Cum Laude", Lower: 0.6, Upper: 0.6},
+		{Predicate: "Magna Cum Laude", Lower: 0.3, Upper: 0.3},
+	}, ImplicationMin)
+	require.NotNil(t, result.Interval)
+	assert.InDelta(t, expected.Score, result.Score, 1e-9)
+
+	wide := DefaultOutput.IntervalMamdani([]IntervalActivation{
+		{Predicate: "Cum Laude", Lower: 0.3, Upper: 0.6},
+		{Predicate: "Magna Cum Laude", Lower: 0.1, Upper: 0.3},
+	}, ImplicationProduct)
+	require.NotNil(t, wide.Interval)
+	assert.Less(t, wide.Interval.Lower, wide.Score)
+	assert.Greater(t, wide.Interval.Upper, wide.Score)
+}
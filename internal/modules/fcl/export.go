@@ -0,0 +1,179 @@
+package fcl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-tsukamoto/internal/modules/defuzzifikasi"
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+	"go-tsukamoto/internal/modules/utils"
+)
+
+// Export menuliskan model sebagai berkas FCL: variabel masukan, himpunan,
+// variabel keluaran, metode defuzzifikasi, operator dan aturan
+func Export(m *model.Model) []byte {
+	var b strings.Builder
+	if m.Version > 0 {
+		fmt.Fprintf(&b, "(* go-tsukamoto model version %d *)\n", m.Version)
+	}
+	fmt.Fprintf(&b, "FUNCTION_BLOCK %s\n\n", FunctionBlock)
+
+	b.WriteString("VAR_INPUT\n")
+	for _, v := range m.Variables {
+		fmt.Fprintf(&b, "\t%s : REAL;", v.Name)
+		if weight, ok := m.RuleBase.Weights[v.Name]; ok {
+			fmt.Fprintf(&b, " (*@ %s %s *)", annotationWeight, number(weight))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("END_VAR\n\n")
+	fmt.Fprintf(&b, "VAR_OUTPUT\n\t%s : REAL;\nEND_VAR\n\n", OutputVariable)
+
+	for _, v := range m.Variables {
+		writeFuzzify(&b, v)
+	}
+	writeDefuzzify(&b, m)
+	writeRuleBlock(&b, m.RuleBase)
+
+	b.WriteString("END_FUNCTION_BLOCK\n")
+	return []byte(b.String())
+}
+
+func writeFuzzify(b *strings.Builder, v *fuzzifikasi.LinguisticVariable) {
+	fmt.Fprintf(b, "FUZZIFY %s\n", v.Name)
+	for _, term := range v.Terms {
+		fmt.Fprintf(b, "\tTERM %s := %s;", term.Name, membership(term.Function))
+		if len(term.Levels) > 0 {
+			fmt.Fprintf(b, " (*@ %s %s *)", annotationLevels, strings.Join(term.Levels, ","))
+		}
+		if term.Lower != nil {
+			fmt.Fprintf(b, " (*@ %s %s %s *)", annotationLower, term.Lower.Kind(), numbers(term.Lower.Params()))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(b, "\t(*@ %s %s %s", annotationRange, number(v.Min), number(v.Max))
+	if v.OpenMax {
+		fmt.Fprintf(b, " %s", rangeOpen)
+	}
+	b.WriteString(" *)\n")
+	b.WriteString("END_FUZZIFY\n\n")
+}
+
+func writeDefuzzify(b *strings.Builder, m *model.Model) {
+	fmt.Fprintf(b, "DEFUZZIFY %s\n", OutputVariable)
+	for _, band := range m.Output.Bands {
+		set := m.Output.Sets[band.Predicate]
+		lowDegree, highDegree := 0, 1
+		if !set.Increasing {
+			lowDegree, highDegree = 1, 0
+		}
+		fmt.Fprintf(b, "\tTERM %s := (%s, %d) (%s, %d); (*@ %s %s %s *)\n",
+			termName(band.Predicate), number(set.Low), lowDegree, number(set.High), highDegree,
+			annotationBand, number(band.MinScore), number(band.MaxScore))
+	}
+	method := methods[defuzzifierName(m)]
+	lo, hi := m.Output.Universe()
+	fmt.Fprintf(b, "\tMETHOD : %s;\n", method)
+	fmt.Fprintf(b, "\tDEFAULT := %s;\n", number(lo))
+	fmt.Fprintf(b, "\tRANGE := (%s .. %s);\n", number(lo), number(hi))
+	b.WriteString("END_DEFUZZIFY\n\n")
+}
+
+func writeRuleBlock(b *strings.Builder, rb *rules.RuleBase) {
+	fmt.Fprintf(b, "RULEBLOCK %s\n", RuleBlock)
+	fmt.Fprintf(b, "\tAND : %s;", andOperators[rb.And])
+	if rb.And == rules.TNormWeightedMean {
+		fmt.Fprintf(b, " (*@ %s %s *)", annotationAnd, rb.And)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(b, "\tOR : %s;\n", orOperators[rb.Or])
+	b.WriteString("\tACT : MIN;\n\tACCU : MAX;\n")
+
+	for i, rule := range rb.Rules {
+		fmt.Fprintf(b, "\tRULE %d : IF %s THEN %s IS %s", i+1, rule.Antecedent, OutputVariable, termName(rule.Consequent))
+		if rule.Weight != 1 {
+			fmt.Fprintf(b, " WITH %s", number(rule.Weight))
+		}
+		b.WriteString(";")
+		if when := rb.Spec.Rules[i].When; when != "" {
+			fmt.Fprintf(b, " (*@ %s %s *)", annotationWhen, strings.Join(strings.Fields(when), " "))
+		}
+		if rule.Output != nil {
+			fmt.Fprintf(b, " (*@ %s %s *)", annotationOutput, consequent(rule.Output))
+		}
+		b.WriteString("\n")
+	}
+	if fallback := rb.Fallback; fallback != nil {
+		fmt.Fprintf(b, "\t(*@ %s %s %s", annotationFallback, termName(fallback.Predicate), number(fallback.Strength))
+		if fallback.Output != nil {
+			fmt.Fprintf(b, " %s", consequent(fallback.Output))
+		}
+		b.WriteString(" *)\n")
+	}
+	b.WriteString("END_RULEBLOCK\n\n")
+}
+
+// membership menuliskan fungsi keanggotaan. Fungsi linear sepotong-sepotong
+// ditulis sebagai titik (x, μ) sesuai standar; fungsi lain memakai sintaks
+// jFuzzyLogic (trian, trape, gauss, gbell, sigm).
+func membership(mf utils.MembershipFunction) string {
+	params := mf.Params()
+	switch mf.Kind() {
+	case utils.KindTriangular:
+		if params[0] < params[1] && params[1] < params[2] {
+			return points(params[0], 0, params[1], 1, params[2], 0)
+		}
+		return "trian " + numbers(params)
+	case utils.KindTrapezoidal:
+		if params[0] < params[1] && params[1] < params[2] && params[2] < params[3] {
+			return points(params[0], 0, params[1], 1, params[2], 1, params[3], 0)
+		}
+		return "trape " + numbers(params)
+	case utils.KindGaussian:
+		return "gauss " + numbers(params)
+	case utils.KindBell:
+		return "gbell " + numbers(params)
+	case utils.KindSigmoid:
+		return "sigm " + numbers(params)
+	}
+	return points(params...)
+}
+
+// points menuliskan pasangan x, μ sebagai "(x1, μ1) (x2, μ2) ..."
+func points(pairs ...float64) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, fmt.Sprintf("(%s, %s)", number(pairs[i]), number(pairs[i+1])))
+	}
+	return strings.Join(parts, " ")
+}
+
+func numbers(values []float64) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = number(value)
+	}
+	return strings.Join(parts, " ")
+}
+
+func number(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// consequent menuliskan konsekuen Sugeno sebagai JSON satu baris
+func consequent(c *rules.Consequent) string {
+	data, _ := json.Marshal(c)
+	return string(data)
+}
+
+// defuzzifierName mengembalikan metode defuzzifikasi model; bawaan centroid
+func defuzzifierName(m *model.Model) string {
+	if m.Defuzzifier == nil {
+		return defuzzifikasi.DefuzzifierCentroid
+	}
+	return m.Defuzzifier.Name()
+}
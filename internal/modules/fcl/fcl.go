@@ -0,0 +1,92 @@
+// Package fcl mengekspor dan mengimpor model fuzzy dalam Fuzzy Control
+// Language (IEC 61131-7) sehingga model dapat dipertukarkan dengan
+// jFuzzyLogic, scikit-fuzzy dan alat lain.
+//
+// Bagian model yang tidak memiliki padanan FCL (bobot faktor, operator
+// rata-rata terbobot, level kategoris, pita skor, syarat tegas "when",
+// konsekuen Sugeno dan fallback) ditulis sebagai anotasi di dalam komentar
+// berbentuk (*@ kunci nilai *). Alat lain mengabaikannya sebagai komentar,
+// sedangkan Import membacanya kembali sehingga ekspor-impor tidak kehilangan
+// informasi.
+package fcl
+
+import (
+	"strings"
+
+	"go-tsukamoto/internal/modules/defuzzifikasi"
+	"go-tsukamoto/internal/modules/rules"
+)
+
+const (
+	// FunctionBlock adalah nama blok fungsi hasil ekspor
+	FunctionBlock = "kelulusan"
+	// OutputVariable adalah nama variabel keluaran hasil ekspor
+	OutputVariable = "predikat"
+	// RuleBlock adalah nama blok aturan hasil ekspor
+	RuleBlock = "aturan"
+)
+
+// Kunci anotasi
+const (
+	annotationWeight   = "weight"
+	annotationRange    = "range"
+	annotationLevels   = "levels"
+	annotationLower    = "lower"
+	annotationBand     = "band"
+	annotationAnd      = "and"
+	annotationWhen     = "when"
+	annotationOutput   = "output"
+	annotationFallback = "fallback"
+)
+
+// rangeOpen menandai batas atas terbuka pada anotasi range
+const rangeOpen = "open"
+
+// andOperators memetakan t-norm ke nama operator AND pada FCL. Rata-rata
+// terbobot tidak ada pada FCL sehingga ditulis sebagai MIN beserta anotasi.
+var andOperators = map[string]string{
+	rules.TNormMin:          "MIN",
+	rules.TNormProduct:      "PROD",
+	rules.TNormLukasiewicz:  "BDIF",
+	rules.TNormEinstein:     "EINSTEIN",
+	rules.TNormWeightedMean: "MIN",
+}
+
+// orOperators memetakan s-norm ke nama operator OR pada FCL
+var orOperators = map[string]string{
+	rules.SNormMax:         "MAX",
+	rules.SNormProbSum:     "ASUM",
+	rules.SNormBoundedSum:  "BSUM",
+	rules.SNormEinsteinSum: "EINSTEIN",
+}
+
+// methods memetakan metode defuzzifikasi ke METHOD pada FCL
+var methods = map[string]string{
+	defuzzifikasi.DefuzzifierCentroid:        "COG",
+	defuzzifikasi.DefuzzifierBisector:        "COA",
+	defuzzifikasi.DefuzzifierMOM:             "MM",
+	defuzzifikasi.DefuzzifierSOM:             "LM",
+	defuzzifikasi.DefuzzifierLOM:             "RM",
+	defuzzifikasi.DefuzzifierWeightedAverage: "COGS",
+}
+
+// termName mengubah nama predikat menjadi identifier FCL ("Cum Laude" → "Cum_Laude")
+func termName(predicate string) string {
+	return strings.ReplaceAll(predicate, " ", "_")
+}
+
+// predicateName adalah kebalikan termName
+func predicateName(term string) string {
+	return strings.ReplaceAll(term, "_", " ")
+}
+
+// lookup mencari kunci peta yang nilainya sama dengan name (tanpa membedakan
+// huruf besar/kecil); skip adalah kunci yang tidak boleh dipilih
+func lookup(m map[string]string, name, skip string) (string, bool) {
+	for key, value := range m {
+		if key != skip && strings.EqualFold(value, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,271 @@
+package fcl
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+	"go-tsukamoto/internal/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRoundTrip(t *testing.T) {
+	original := model.Default()
+	exported := Export(original)
+
+	def, err := Import(exported)
+	require.NoError(t, err)
+	imported, err := model.Compile(def)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(exported), string(Export(imported)))
+	assert.Equal(t, original.RuleBase.Spec.Weights, def.Weights)
+	assert.Equal(t, original.RuleBase.Spec.And, def.And)
+	assert.Equal(t, original.Output.Bands, imported.Output.Bands)
+
+	// Model hasil impor menghasilkan skor yang sama dengan model asal
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		crisp := map[string]float64{}
+		levels := map[string]string{}
+		for _, v := range original.Variables {
+			crisp[v.Name] = v.Min + random.Float64()*(v.Max-v.Min)
+		}
+		for _, name := range []string{"achievement", "thesis"} {
+			levels[name] = []string{"internasional", "nasional", "internal"}[random.Intn(3)]
+		}
+
+		want := inferensia.Evaluate(original, crisp, levels)
+		got := inferensia.Evaluate(imported, crisp, levels)
+		require.InDelta(t, want.Score, got.Score, 1e-9, "input %v %v", crisp, levels)
+		require.Equal(t, want.Predicate, got.Predicate)
+	}
+}
+
+func TestTypeTwoRoundTrip(t *testing.T) {
+	def := model.Default().Definition()
+	def.Variables[0].Terms[4].Lower = &model.FunctionSpec{Type: "piecewise", Params: []float64{3.75, 0, 4, 1}}
+	original, err := model.Compile(def)
+	require.NoError(t, err)
+
+	exported := Export(original)
+	assert.Contains(t, string(exported), "(*@ lower piecewise 3.75 0 4 1 *)")
+	assert.Contains(t, string(exported), "(*@ range 0 20 open *)")
+	imported, err := Import(exported)
+	require.NoError(t, err)
+	assert.Equal(t, def.Variables, imported.Variables)
+
+	_, err = Import([]byte(strings.Replace(string(exported), "lower piecewise 3.75", "lower piecewise x", 1)))
+	assert.ErrorContains(t, err, `variable ipk lower: invalid number "x"`)
+}
+
+func TestImportSugenoOutputs(t *testing.T) {
+	m := model.Default()
+	def := m.Definition()
+	def.Rules[0].Output = &rules.Consequent{Constant: 3.9, Coefficients: map[string]float64{"ipk": 0.01}}
+	def.Fallback.Output = &rules.Consequent{Constant: 1.5}
+	def.And = rules.TNormProduct
+	def.Or = rules.SNormProbSum
+	def.Defuzzifier = "mom"
+	withOutputs, err := model.Compile(def)
+	require.NoError(t, err)
+
+	imported, err := Import(Export(withOutputs))
+	require.NoError(t, err)
+	assert.Equal(t, def.Rules[0].Output, imported.Rules[0].Output)
+	assert.Equal(t, def.Fallback.Output, imported.Fallback.Output)
+	assert.Equal(t, rules.TNormProduct, imported.And)
+	assert.Equal(t, rules.SNormProbSum, imported.Or)
+	assert.Equal(t, "mom", imported.Defuzzifier)
+}
+
+// sample mengikuti gaya berkas jFuzzyLogic: himpunan trian/gauss, RANGE
+// pada FUZZIFY dan tanpa anotasi
+const sample = `
+// Contoh sederhana
+FUNCTION_BLOCK tipper
+
+VAR_INPUT
+	service : REAL;
+	food : REAL;
+END_VAR
+
+VAR_OUTPUT
+	tip : REAL;
+END_VAR
+
+FUZZIFY service
+	TERM poor := gauss 0 1.5;
+	TERM good := trian 2 5 8;
+	TERM excellent := (5, 0) (10, 1);
+	RANGE := (0 .. 10);
+END_FUZZIFY
+
+FUZZIFY food
+	TERM rancid := (0, 1) (1, 1) (3, 0);
+	TERM delicious := (7, 0) (9, 1);
+END_FUZZIFY
+
+DEFUZZIFY tip
+	TERM cheap := (0, 1) (10, 0);
+	TERM average := (10, 0) (15, 1);
+	TERM generous := (20, 0) (30, 1);
+	METHOD : COG;
+	DEFAULT := 0;
+END_DEFUZZIFY
+
+RULEBLOCK No1
+	AND : MIN;
+	ACT : MIN;
+	ACCU : MAX;
+	RULE 1 : IF service IS poor OR food IS rancid THEN tip IS cheap;
+	RULE 2 : IF service IS good THEN tip IS average;
+	RULE 3 : IF service IS excellent AND food IS NOT rancid THEN tip IS generous WITH 0.8;
+END_RULEBLOCK
+
+END_FUNCTION_BLOCK
+`
+
+func TestImportSample(t *testing.T) {
+	def, err := Import([]byte(sample))
+	require.NoError(t, err)
+
+	require.Len(t, def.Variables, 2)
+	service := def.Variables[0]
+	assert.Equal(t, 0.0, service.Min)
+	assert.Equal(t, 10.0, service.Max)
+	assert.Equal(t, model.TermSpec{Name: "poor", Type: utils.KindGaussian, Params: []float64{0, 1.5}}, service.Terms[0])
+	assert.Equal(t, model.TermSpec{Name: "good", Type: utils.KindTriangular, Params: []float64{2, 5, 8}}, service.Terms[1])
+	assert.Equal(t, utils.KindPiecewise, service.Terms[2].Type)
+
+	// Tanpa RANGE semesta diambil dari titik himpunan
+	food := def.Variables[1]
+	assert.Equal(t, 0.0, food.Min)
+	assert.Equal(t, 9.0, food.Max)
+	assert.Equal(t, model.TermSpec{Name: "rancid", Type: utils.KindPiecewise, Params: []float64{0, 1, 1, 1, 3, 0}}, food.Terms[0])
+
+	// Tanpa anotasi pita, pita diturunkan dari himpunan keluaran
+	assert.Equal(t, []model.OutputSpec{
+		{Predicate: "generous", Low: 20, High: 30, Increasing: true, MinScore: 20},
+		{Predicate: "average", Low: 10, High: 15, Increasing: true, MinScore: 10},
+		{Predicate: "cheap", Low: 0, High: 10, Increasing: false, MinScore: 0},
+	}, def.Outputs)
+
+	assert.Equal(t, []rules.RuleSpec{
+		{Rule: "IF service IS poor OR food IS rancid THEN cheap"},
+		{Rule: "IF service IS good THEN average"},
+		{Rule: "IF service IS excellent AND food IS NOT rancid THEN generous [0.8]"},
+	}, def.Rules)
+	assert.Equal(t, "centroid", def.Defuzzifier)
+
+	m, err := model.Compile(def)
+	require.NoError(t, err)
+	trace := inferensia.Evaluate(m, map[string]float64{"service": 9, "food": 8}, nil)
+	assert.Equal(t, "generous", trace.Predicate)
+}
+
+func TestImportErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		errMsg string
+	}{
+		{
+			name:   "Bukan Blok Fungsi",
+			input:  "VAR_INPUT x : REAL; END_VAR",
+			errMsg: "line 1: expected FUNCTION_BLOCK",
+		},
+		{
+			name:   "Komentar Tidak Ditutup",
+			input:  "FUNCTION_BLOCK a (* komentar",
+			errMsg: "unterminated comment",
+		},
+		{
+			name:   "Blok Tidak Ditutup",
+			input:  "FUNCTION_BLOCK a\nVAR_INPUT x : REAL; END_VAR\n",
+			errMsg: "line 3: missing END_FUNCTION_BLOCK",
+		},
+		{
+			name:   "Fungsi Tidak Didukung",
+			input:  "FUNCTION_BLOCK a\nFUZZIFY x\n\tTERM t := cosine 1 2;\nEND_FUZZIFY\nEND_FUNCTION_BLOCK",
+			errMsg: `line 3: unsupported membership function "cosine" for term t`,
+		},
+		{
+			name:   "Singleton",
+			input:  "FUNCTION_BLOCK a\nFUZZIFY x\n\tTERM t := 5;\nEND_FUZZIFY\nEND_FUNCTION_BLOCK",
+			errMsg: "singleton term t is not supported",
+		},
+		{
+			name:   "Keluaran Tidak Monoton",
+			input:  "FUNCTION_BLOCK a\nDEFUZZIFY y\n\tTERM t := (0, 0) (1, 1) (2, 0);\nEND_DEFUZZIFY\nEND_FUNCTION_BLOCK",
+			errMsg: "output term t must be a two-point ramp",
+		},
+		{
+			name:   "Metode Tidak Didukung",
+			input:  "FUNCTION_BLOCK a\nDEFUZZIFY y\n\tMETHOD : XYZ;\nEND_DEFUZZIFY\nEND_FUNCTION_BLOCK",
+			errMsg: `unsupported defuzzification method "XYZ"`,
+		},
+		{
+			name:   "Dua Keluaran",
+			input:  "FUNCTION_BLOCK a\nVAR_OUTPUT y : REAL; z : REAL; END_VAR\nEND_FUNCTION_BLOCK",
+			errMsg: "exactly one output variable is supported, got 2",
+		},
+		{
+			name:   "Masukan Tanpa FUZZIFY",
+			input:  "FUNCTION_BLOCK a\nVAR_INPUT x : REAL; END_VAR\nVAR_OUTPUT y : REAL; END_VAR\nEND_FUNCTION_BLOCK",
+			errMsg: "input x has no FUZZIFY block",
+		},
+		{
+			name:   "Konsekuen Variabel Lain",
+			input:  "FUNCTION_BLOCK a\nVAR_OUTPUT y : REAL; END_VAR\nRULEBLOCK r\n\tRULE 1 : IF x IS t THEN z IS u;\nEND_RULEBLOCK\nEND_FUNCTION_BLOCK",
+			errMsg: `line 4: rule concludes unknown output "z"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Import([]byte(test.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.errMsg)
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	def := model.Default().Definition()
+	yamlData, err := yaml.Marshal(def)
+	require.NoError(t, err)
+
+	files := map[string][]byte{
+		"model.FCL":  Export(model.Default()),
+		"model.yaml": yamlData,
+		"bad.fcl":    []byte("FUNCTION_BLOCK"),
+		"bad.yaml":   []byte("variables: ["),
+	}
+	for name, data := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o600))
+	}
+
+	m, err := LoadFile(filepath.Join(dir, "model.FCL"))
+	require.NoError(t, err)
+	assert.Equal(t, string(files["model.FCL"]), string(Export(m)))
+	m, err = LoadFile(filepath.Join(dir, "model.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, def, m.Definition())
+
+	_, err = LoadFile(filepath.Join(dir, "bad.fcl"))
+	assert.ErrorContains(t, err, "invalid FCL file")
+	_, err = LoadFile(filepath.Join(dir, "bad.yaml"))
+	assert.ErrorContains(t, err, "invalid model file")
+	_, err = LoadFile(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
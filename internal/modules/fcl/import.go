@@ -0,0 +1,859 @@
+package fcl
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+	"go-tsukamoto/internal/modules/utils"
+)
+
+// Import membaca berkas FCL menjadi definisi model. Definisi perlu
+// dikompilasi dengan model.Compile sebelum dijalankan. Hanya satu variabel
+// keluaran yang didukung; himpunan keluarannya harus berupa landai dua titik
+// karena konsekuen Tsukamoto harus monoton.
+func Import(data []byte) (model.Definition, error) {
+	tokens, err := tokenize(string(data))
+	if err != nil {
+		return model.Definition{}, err
+	}
+	p := &parser{tokens: tokens, fuzzify: map[string]*fuzzifyBlock{}}
+	if err := p.parseFunctionBlock(); err != nil {
+		return model.Definition{}, err
+	}
+	return p.definition()
+}
+
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota
+	tokNumber
+	tokAssign
+	tokColon
+	tokSemicolon
+	tokLParen
+	tokRParen
+	tokComma
+	tokRange
+	tokAnnotation
+	tokEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	line := 1
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' && i+1 < len(runes) && runes[i+1] == '*':
+			start, startLine := i+2, line
+			end := -1
+			for j := start; j+1 < len(runes); j++ {
+				if runes[j] == '*' && runes[j+1] == ')' {
+					end = j
+					break
+				}
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", startLine)
+			}
+			comment := string(runes[start:end])
+			line += strings.Count(comment, "\n")
+			if strings.HasPrefix(comment, "@") {
+				tokens = append(tokens, token{tokAnnotation, strings.TrimSpace(comment[1:]), startLine})
+			}
+			i = end + 2
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == ':' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, token{tokAssign, ":=", line})
+			i += 2
+		case r == '.' && i+1 < len(runes) && runes[i+1] == '.':
+			tokens = append(tokens, token{tokRange, "..", line})
+			i += 2
+		case r == ':':
+			tokens = append(tokens, token{tokColon, ":", line})
+			i++
+		case r == ';':
+			tokens = append(tokens, token{tokSemicolon, ";", line})
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "(", line})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")", line})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokComma, ",", line})
+			i++
+		case unicode.IsDigit(r) || ((r == '-' || r == '+' || r == '.') && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) {
+				c := runes[i]
+				if unicode.IsDigit(c) {
+					i++
+				} else if c == '.' && !(i+1 < len(runes) && runes[i+1] == '.') {
+					i++
+				} else if (c == 'e' || c == 'E') && i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '-' || runes[i+1] == '+') {
+					i += 2
+				} else {
+					break
+				}
+			}
+			tokens = append(tokens, token{tokNumber, string(runes[start:i]), line})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, string(runes[start:i]), line})
+		default:
+			return nil, fmt.Errorf("line %d: unexpected character %q", line, r)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, line: line}), nil
+}
+
+type fuzzifyBlock struct {
+	terms    []model.TermSpec
+	min, max float64
+	hasRange bool
+	openMax  bool
+}
+
+type outputTerm struct {
+	spec    model.OutputSpec
+	hasBand bool
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+
+	inputs    []string
+	weights   map[string]float64
+	outputs   []string
+	fuzzify   map[string]*fuzzifyBlock
+	outTerms  []outputTerm
+	outRange  []float64
+	method    string
+	and       string
+	or        string
+	ruleSpecs []rules.RuleSpec
+	fallback  *rules.FallbackSpec
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.peek().line, fmt.Sprintf(format, args...))
+}
+
+// isKeyword memeriksa apakah token saat ini adalah kata kunci (tanpa membedakan huruf besar/kecil)
+func (p *parser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && strings.EqualFold(t.text, keyword)
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if !p.isKeyword(keyword) {
+		return p.errorf("expected %s, got %q", keyword, p.peek().text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	if p.peek().kind != kind {
+		return token{}, p.errorf("expected %s, got %q", what, p.peek().text)
+	}
+	return p.next(), nil
+}
+
+func (p *parser) expectNumber() (float64, error) {
+	t, err := p.expect(tokNumber, "number")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(t.text, 64)
+}
+
+func (p *parser) parseFunctionBlock() error {
+	if err := p.expectKeyword("FUNCTION_BLOCK"); err != nil {
+		return err
+	}
+	if p.peek().kind == tokIdent && !p.isBlockKeyword() {
+		p.next()
+	}
+	for !p.isKeyword("END_FUNCTION_BLOCK") {
+		var err error
+		switch {
+		case p.isKeyword("VAR_INPUT"):
+			p.inputs, err = p.parseVarBlock(true)
+		case p.isKeyword("VAR_OUTPUT"):
+			p.outputs, err = p.parseVarBlock(false)
+		case p.isKeyword("FUZZIFY"):
+			err = p.parseFuzzify()
+		case p.isKeyword("DEFUZZIFY"):
+			err = p.parseDefuzzify()
+		case p.isKeyword("RULEBLOCK"):
+			err = p.parseRuleBlock()
+		case p.peek().kind == tokAnnotation:
+			p.next()
+		case p.peek().kind == tokEOF:
+			return p.errorf("missing END_FUNCTION_BLOCK")
+		default:
+			return p.errorf("unexpected %q", p.peek().text)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) isBlockKeyword() bool {
+	for _, keyword := range []string{"VAR_INPUT", "VAR_OUTPUT", "FUZZIFY", "DEFUZZIFY", "RULEBLOCK", "END_FUNCTION_BLOCK"} {
+		if p.isKeyword(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseVarBlock: VAR_INPUT|VAR_OUTPUT { nama : REAL ; [(*@ weight w *)] } END_VAR
+func (p *parser) parseVarBlock(input bool) ([]string, error) {
+	p.next()
+	var names []string
+	for !p.isKeyword("END_VAR") {
+		name, err := p.expect(tokIdent, "variable name")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokColon, ":"); err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokIdent, "variable type"); err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokSemicolon, ";"); err != nil {
+			return nil, err
+		}
+		names = append(names, name.text)
+
+		for p.peek().kind == tokAnnotation {
+			key, fields := annotation(p.next())
+			if !input || key != annotationWeight {
+				continue
+			}
+			weight, err := parseFloats(fields, 1)
+			if err != nil {
+				return nil, fmt.Errorf("variable %s weight: %v", name.text, err)
+			}
+			if p.weights == nil {
+				p.weights = map[string]float64{}
+			}
+			p.weights[name.text] = weight[0]
+		}
+	}
+	p.next()
+	return names, nil
+}
+
+// parseFuzzify: FUZZIFY nama { TERM nama := fungsi ; | RANGE := (a .. b) ; } END_FUZZIFY
+func (p *parser) parseFuzzify() error {
+	p.next()
+	name, err := p.expect(tokIdent, "variable name")
+	if err != nil {
+		return err
+	}
+	if _, ok := p.fuzzify[name.text]; ok {
+		return p.errorf("variable %s is fuzzified more than once", name.text)
+	}
+	block := &fuzzifyBlock{}
+	p.fuzzify[name.text] = block
+
+	for !p.isKeyword("END_FUZZIFY") {
+		switch {
+		case p.isKeyword("TERM"):
+			term, err := p.parseTerm()
+			if err != nil {
+				return fmt.Errorf("variable %s: %v", name.text, err)
+			}
+			block.terms = append(block.terms, term)
+		case p.isKeyword("RANGE"):
+			if block.min, block.max, err = p.parseRange(); err != nil {
+				return err
+			}
+			block.hasRange = true
+		case p.peek().kind == tokAnnotation:
+			key, fields := annotation(p.next())
+			switch key {
+			case annotationLevels:
+				if len(block.terms) == 0 {
+					return p.errorf("levels annotation before any term")
+				}
+				block.terms[len(block.terms)-1].Levels = strings.Split(strings.Join(fields, ""), ",")
+			case annotationLower:
+				if len(block.terms) == 0 {
+					return p.errorf("lower annotation before any term")
+				}
+				if len(fields) < 2 {
+					return p.errorf("lower annotation needs a function type and its parameters")
+				}
+				params, err := parseFloats(fields[1:], len(fields)-1)
+				if err != nil {
+					return fmt.Errorf("variable %s lower: %v", name.text, err)
+				}
+				block.terms[len(block.terms)-1].Lower = &model.FunctionSpec{Type: fields[0], Params: params}
+			case annotationRange:
+				if len(fields) == 3 && fields[2] == rangeOpen {
+					fields, block.openMax = fields[:2], true
+				}
+				values, err := parseFloats(fields, 2)
+				if err != nil {
+					return fmt.Errorf("variable %s range: %v", name.text, err)
+				}
+				block.min, block.max, block.hasRange = values[0], values[1], true
+			}
+		case p.peek().kind == tokEOF:
+			return p.errorf("missing END_FUZZIFY")
+		default:
+			return p.errorf("unexpected %q in FUZZIFY %s", p.peek().text, name.text)
+		}
+	}
+	p.next()
+	return nil
+}
+
+// parseTerm: TERM nama := (x, μ) ... ; | TERM nama := trian|trape|gauss|gbell|sigm p... ;
+func (p *parser) parseTerm() (model.TermSpec, error) {
+	p.next()
+	name, err := p.expect(tokIdent, "term name")
+	if err != nil {
+		return model.TermSpec{}, err
+	}
+	if _, err := p.expect(tokAssign, ":="); err != nil {
+		return model.TermSpec{}, err
+	}
+
+	term := model.TermSpec{Name: name.text}
+	switch p.peek().kind {
+	case tokLParen:
+		var pairs []float64
+		for p.peek().kind == tokLParen {
+			p.next()
+			x, err := p.expectNumber()
+			if err != nil {
+				return term, err
+			}
+			if _, err := p.expect(tokComma, ","); err != nil {
+				return term, err
+			}
+			y, err := p.expectNumber()
+			if err != nil {
+				return term, err
+			}
+			if _, err := p.expect(tokRParen, ")"); err != nil {
+				return term, err
+			}
+			pairs = append(pairs, x, y)
+		}
+		term.Type, term.Params = shape(pairs)
+	case tokIdent:
+		kinds := map[string]string{
+			"trian": utils.KindTriangular,
+			"trape": utils.KindTrapezoidal,
+			"gauss": utils.KindGaussian,
+			"gbell": utils.KindBell,
+			"sigm":  utils.KindSigmoid,
+		}
+		kind, ok := kinds[strings.ToLower(p.peek().text)]
+		if !ok {
+			return term, p.errorf("unsupported membership function %q for term %s", p.peek().text, name.text)
+		}
+		p.next()
+		term.Type = kind
+		for p.peek().kind == tokNumber {
+			value, _ := p.expectNumber()
+			term.Params = append(term.Params, value)
+		}
+	case tokNumber:
+		return term, p.errorf("singleton term %s is not supported", name.text)
+	default:
+		return term, p.errorf("expected membership function for term %s", name.text)
+	}
+	if _, err := p.expect(tokSemicolon, ";"); err != nil {
+		return term, err
+	}
+	return term, nil
+}
+
+// shape mengenali titik segitiga (0, 1, 0) dan trapesium (0, 1, 1, 0);
+// titik lain menjadi fungsi piecewise
+func shape(pairs []float64) (string, []float64) {
+	var xs, ys []float64
+	for i := 0; i+1 < len(pairs); i += 2 {
+		xs = append(xs, pairs[i])
+		ys = append(ys, pairs[i+1])
+	}
+	increasing := sort.SliceIsSorted(xs, func(i, j int) bool { return xs[i] < xs[j] })
+	switch {
+	case increasing && len(xs) == 3 && equal(ys, 0, 1, 0) && xs[0] < xs[1] && xs[1] < xs[2]:
+		return utils.KindTriangular, xs
+	case increasing && len(xs) == 4 && equal(ys, 0, 1, 1, 0) && xs[0] < xs[1] && xs[2] < xs[3]:
+		return utils.KindTrapezoidal, xs
+	}
+	return utils.KindPiecewise, pairs
+}
+
+func equal(values []float64, expected ...float64) bool {
+	for i, value := range values {
+		if value != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parser) parseRange() (float64, float64, error) {
+	p.next()
+	if _, err := p.expect(tokAssign, ":="); err != nil {
+		return 0, 0, err
+	}
+	if _, err := p.expect(tokLParen, "("); err != nil {
+		return 0, 0, err
+	}
+	lo, err := p.expectNumber()
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := p.expect(tokRange, ".."); err != nil {
+		return 0, 0, err
+	}
+	hi, err := p.expectNumber()
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := p.expect(tokRParen, ")"); err != nil {
+		return 0, 0, err
+	}
+	if _, err := p.expect(tokSemicolon, ";"); err != nil {
+		return 0, 0, err
+	}
+	return lo, hi, nil
+}
+
+// parseDefuzzify: DEFUZZIFY nama { TERM ... ; | METHOD : m ; | DEFAULT := v ; | RANGE ... ; } END_DEFUZZIFY
+func (p *parser) parseDefuzzify() error {
+	p.next()
+	name, err := p.expect(tokIdent, "variable name")
+	if err != nil {
+		return err
+	}
+	if len(p.outTerms) > 0 {
+		return p.errorf("only one output variable is supported, got a second DEFUZZIFY %s", name.text)
+	}
+
+	for !p.isKeyword("END_DEFUZZIFY") {
+		switch {
+		case p.isKeyword("TERM"):
+			term, err := p.parseTerm()
+			if err != nil {
+				return fmt.Errorf("output %s: %v", name.text, err)
+			}
+			set, err := outputSet(term)
+			if err != nil {
+				return fmt.Errorf("output %s: %v", name.text, err)
+			}
+			p.outTerms = append(p.outTerms, outputTerm{spec: set})
+		case p.isKeyword("METHOD"):
+			p.next()
+			if _, err := p.expect(tokColon, ":"); err != nil {
+				return err
+			}
+			method, err := p.expect(tokIdent, "defuzzification method")
+			if err != nil {
+				return err
+			}
+			if p.method, err = lookupName(methods, method.text, "", "defuzzification method"); err != nil {
+				return fmt.Errorf("line %d: %v", method.line, err)
+			}
+			if _, err := p.expect(tokSemicolon, ";"); err != nil {
+				return err
+			}
+		case p.isKeyword("DEFAULT"), p.isKeyword("LOCK"):
+			// Nilai bawaan dan kunci keluaran tidak dipakai: tanpa aturan yang
+			// menyala, model selalu memilih predikat terendah
+			for p.peek().kind != tokSemicolon && p.peek().kind != tokEOF {
+				p.next()
+			}
+			p.next()
+		case p.isKeyword("RANGE"):
+			lo, hi, err := p.parseRange()
+			if err != nil {
+				return err
+			}
+			p.outRange = []float64{lo, hi}
+		case p.peek().kind == tokAnnotation:
+			key, fields := annotation(p.next())
+			if key != annotationBand {
+				continue
+			}
+			if len(p.outTerms) == 0 {
+				return p.errorf("band annotation before any term")
+			}
+			values, err := parseFloats(fields, 2)
+			if err != nil {
+				return fmt.Errorf("output band: %v", err)
+			}
+			last := &p.outTerms[len(p.outTerms)-1]
+			last.spec.MinScore, last.spec.MaxScore, last.hasBand = values[0], values[1], true
+		case p.peek().kind == tokEOF:
+			return p.errorf("missing END_DEFUZZIFY")
+		default:
+			return p.errorf("unexpected %q in DEFUZZIFY %s", p.peek().text, name.text)
+		}
+	}
+	p.next()
+	return nil
+}
+
+// outputSet mengubah himpunan keluaran dua titik menjadi landai monoton
+func outputSet(term model.TermSpec) (model.OutputSpec, error) {
+	spec := model.OutputSpec{Predicate: predicateName(term.Name)}
+	params := term.Params
+	if term.Type != utils.KindPiecewise || len(params) != 4 || params[0] >= params[2] {
+		return spec, fmt.Errorf("output term %s must be a two-point ramp such as (a, 0) (b, 1); Tsukamoto needs monotonic consequents", term.Name)
+	}
+	spec.Low, spec.High = params[0], params[2]
+	switch {
+	case params[1] == 0 && params[3] == 1:
+		spec.Increasing = true
+	case params[1] == 1 && params[3] == 0:
+		spec.Increasing = false
+	default:
+		return spec, fmt.Errorf("output term %s must ramp between degree 0 and 1", term.Name)
+	}
+	return spec, nil
+}
+
+// parseRuleBlock: RULEBLOCK nama { AND|OR|ACT|ACCU : op ; | RULE n : IF ... THEN v IS t [WITH w] ; } END_RULEBLOCK
+func (p *parser) parseRuleBlock() error {
+	p.next()
+	if p.peek().kind == tokIdent && !p.isKeyword("AND") && !p.isKeyword("OR") && !p.isKeyword("RULE") {
+		p.next()
+	}
+	weightedMean := false
+
+	for !p.isKeyword("END_RULEBLOCK") {
+		switch {
+		case p.isKeyword("AND"), p.isKeyword("OR"):
+			keyword := strings.ToUpper(p.next().text)
+			if _, err := p.expect(tokColon, ":"); err != nil {
+				return err
+			}
+			operator, err := p.expect(tokIdent, "operator")
+			if err != nil {
+				return err
+			}
+			if keyword == "AND" {
+				p.and, err = lookupName(andOperators, operator.text, rules.TNormWeightedMean, "AND operator")
+			} else {
+				p.or, err = lookupName(orOperators, operator.text, "", "OR operator")
+			}
+			if err != nil {
+				return fmt.Errorf("line %d: %v", operator.line, err)
+			}
+			if _, err := p.expect(tokSemicolon, ";"); err != nil {
+				return err
+			}
+		case p.isKeyword("ACT"), p.isKeyword("ACCU"):
+			// Implikasi dan akumulasi ditentukan oleh metode inferensi
+			for p.peek().kind != tokSemicolon && p.peek().kind != tokEOF {
+				p.next()
+			}
+			p.next()
+		case p.isKeyword("RULE"):
+			spec, err := p.parseRule()
+			if err != nil {
+				return err
+			}
+			p.ruleSpecs = append(p.ruleSpecs, spec)
+		case p.peek().kind == tokAnnotation:
+			t := p.next()
+			key, fields := annotation(t)
+			rest := strings.TrimSpace(strings.TrimPrefix(t.text, key))
+			switch key {
+			case annotationAnd:
+				weightedMean = len(fields) == 1 && fields[0] == rules.TNormWeightedMean
+			case annotationWhen, annotationOutput:
+				if len(p.ruleSpecs) == 0 {
+					return fmt.Errorf("line %d: %s annotation before any rule", t.line, key)
+				}
+				last := &p.ruleSpecs[len(p.ruleSpecs)-1]
+				if key == annotationWhen {
+					last.When = rest
+					continue
+				}
+				var output rules.Consequent
+				if err := json.Unmarshal([]byte(rest), &output); err != nil {
+					return fmt.Errorf("line %d: invalid output annotation: %v", t.line, err)
+				}
+				last.Output = &output
+			case annotationFallback:
+				fallback, err := parseFallback(fields, rest)
+				if err != nil {
+					return fmt.Errorf("line %d: %v", t.line, err)
+				}
+				p.fallback = fallback
+			}
+		case p.peek().kind == tokEOF:
+			return p.errorf("missing END_RULEBLOCK")
+		default:
+			return p.errorf("unexpected %q in RULEBLOCK", p.peek().text)
+		}
+	}
+	p.next()
+	if weightedMean {
+		p.and = rules.TNormWeightedMean
+	}
+	return nil
+}
+
+// parseRule mengubah aturan FCL menjadi sintaks aturan model:
+// "IF <anteseden> THEN <predikat> [bobot]"
+func (p *parser) parseRule() (rules.RuleSpec, error) {
+	p.next()
+	if p.peek().kind == tokNumber || p.peek().kind == tokIdent {
+		p.next()
+	}
+	if _, err := p.expect(tokColon, ":"); err != nil {
+		return rules.RuleSpec{}, err
+	}
+	if err := p.expectKeyword("IF"); err != nil {
+		return rules.RuleSpec{}, err
+	}
+
+	var antecedent []string
+	for !p.isKeyword("THEN") {
+		t := p.next()
+		if t.kind == tokSemicolon || t.kind == tokEOF {
+			return rules.RuleSpec{}, fmt.Errorf("line %d: rule is missing THEN", t.line)
+		}
+		antecedent = append(antecedent, t.text)
+	}
+	p.next()
+
+	variable, err := p.expect(tokIdent, "output variable")
+	if err != nil {
+		return rules.RuleSpec{}, err
+	}
+	if len(p.outputs) > 0 && variable.text != p.outputs[0] {
+		return rules.RuleSpec{}, fmt.Errorf("line %d: rule concludes unknown output %q", variable.line, variable.text)
+	}
+	if err := p.expectKeyword("IS"); err != nil {
+		return rules.RuleSpec{}, err
+	}
+	term, err := p.expect(tokIdent, "output term")
+	if err != nil {
+		return rules.RuleSpec{}, err
+	}
+
+	text := fmt.Sprintf("IF %s THEN %s", strings.Join(antecedent, " "), predicateName(term.text))
+	if p.isKeyword("WITH") {
+		p.next()
+		weight, err := p.expect(tokNumber, "rule weight")
+		if err != nil {
+			return rules.RuleSpec{}, err
+		}
+		text += " [" + weight.text + "]"
+	}
+	if p.peek().kind == tokComma {
+		return rules.RuleSpec{}, p.errorf("rules with more than one consequent are not supported")
+	}
+	if _, err := p.expect(tokSemicolon, ";"); err != nil {
+		return rules.RuleSpec{}, err
+	}
+	return rules.RuleSpec{Rule: strings.ReplaceAll(strings.ReplaceAll(text, "( ", "("), " )", ")")}, nil
+}
+
+// parseFallback: fallback <himpunan> <kekuatan> [konsekuen JSON]
+func parseFallback(fields []string, rest string) (*rules.FallbackSpec, error) {
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("fallback annotation needs a term and a strength")
+	}
+	strength, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fallback strength %q", fields[1])
+	}
+	fallback := &rules.FallbackSpec{Predicate: predicateName(fields[0]), Strength: strength}
+	if output := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(rest, fields[0])), fields[1])); output != "" {
+		fallback.Output = &rules.Consequent{}
+		if err := json.Unmarshal([]byte(output), fallback.Output); err != nil {
+			return nil, fmt.Errorf("invalid fallback output: %v", err)
+		}
+	}
+	return fallback, nil
+}
+
+// definition menyusun definisi model dari blok-blok yang sudah diurai
+func (p *parser) definition() (model.Definition, error) {
+	def := model.Definition{Defuzzifier: p.method}
+	def.Weights = p.weights
+	def.And = p.and
+	def.Or = p.or
+	def.Rules = p.ruleSpecs
+	def.Fallback = p.fallback
+
+	if len(p.outputs) != 1 {
+		return def, fmt.Errorf("exactly one output variable is supported, got %d", len(p.outputs))
+	}
+	for _, name := range p.inputs {
+		block, ok := p.fuzzify[name]
+		if !ok {
+			return def, fmt.Errorf("input %s has no FUZZIFY block", name)
+		}
+		vs := model.VariableSpec{Name: name, Min: block.min, Max: block.max, OpenMax: block.openMax, Terms: block.terms}
+		if !block.hasRange {
+			vs.Min, vs.Max = termRange(block.terms)
+		}
+		def.Variables = append(def.Variables, vs)
+	}
+	for name := range p.fuzzify {
+		if !contains(p.inputs, name) {
+			return def, fmt.Errorf("FUZZIFY %s does not match any input variable", name)
+		}
+	}
+
+	def.Outputs = outputs(p.outTerms, p.outRange)
+	return def, nil
+}
+
+// outputs menyusun pita skor. Tanpa anotasi pita, himpunan diurutkan menurun
+// menurut titik tengahnya dan setiap pita dimulai dari batas bawah
+// himpunannya; pita terendah mencakup batas bawah semesta keluaran.
+func outputs(terms []outputTerm, universe []float64) []model.OutputSpec {
+	specs := make([]model.OutputSpec, len(terms))
+	annotated := true
+	for i, term := range terms {
+		specs[i] = term.spec
+		annotated = annotated && term.hasBand
+	}
+	if annotated {
+		sort.SliceStable(specs, func(i, j int) bool { return specs[i].MinScore > specs[j].MinScore })
+		return specs
+	}
+
+	sort.SliceStable(specs, func(i, j int) bool {
+		return specs[i].Low+specs[i].High > specs[j].Low+specs[j].High
+	})
+	for i := range specs {
+		specs[i].MinScore, specs[i].MaxScore = specs[i].Low, 0
+	}
+	if n := len(specs); n > 0 {
+		lowest := specs[n-1].Low
+		if len(universe) == 2 {
+			lowest = math.Min(lowest, universe[0])
+		}
+		specs[n-1].MinScore = lowest
+	}
+	return specs
+}
+
+// termRange mengambil semesta variabel dari parameter himpunannya jika RANGE
+// tidak ditulis
+func termRange(terms []model.TermSpec) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, term := range terms {
+		params := term.Params
+		step := 1
+		if term.Type == utils.KindPiecewise {
+			step = 2
+		}
+		if term.Type == utils.KindGaussian || term.Type == utils.KindSigmoid || term.Type == utils.KindBell {
+			continue
+		}
+		for i := 0; i < len(params); i += step {
+			lo, hi = math.Min(lo, params[i]), math.Max(hi, params[i])
+		}
+	}
+	if math.IsInf(lo, 0) {
+		return 0, 0
+	}
+	return lo, hi
+}
+
+// annotation memisahkan kunci dan isian anotasi "(*@ kunci isian... *)"
+func annotation(t token) (string, []string) {
+	fields := strings.Fields(t.text)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return strings.ToLower(fields[0]), fields[1:]
+}
+
+func parseFloats(fields []string, n int) ([]float64, error) {
+	if len(fields) != n {
+		return nil, fmt.Errorf("expected %d numbers, got %d", n, len(fields))
+	}
+	values := make([]float64, n)
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", field)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// lookupName mencari nama model untuk operator atau metode FCL
+func lookupName(m map[string]string, name, skip, what string) (string, error) {
+	key, ok := lookup(m, name, skip)
+	if !ok {
+		return "", fmt.Errorf("unsupported %s %q", what, name)
+	}
+	return key, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package fcl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-tsukamoto/internal/modules/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile membaca dan mengompilasi berkas definisi model: FCL untuk ekstensi
+// .fcl, selain itu YAML atau JSON
+func LoadFile(path string) (*model.Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var def model.Definition
+	if strings.EqualFold(filepath.Ext(path), ".fcl") {
+		if def, err = Import(data); err != nil {
+			return nil, fmt.Errorf("invalid FCL file: %v", err)
+		}
+	} else if err := yaml.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("invalid model file: %v", err)
+	}
+	return model.Compile(def)
+}
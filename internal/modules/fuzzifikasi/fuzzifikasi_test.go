@@ -0,0 +1,244 @@
+package fuzzifikasi
+
+import (
+	"fmt"
+	"testing"
+
+	"go-tsukamoto/internal/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Setiap nilai bulat pada semesta harus termasuk ke minimal satu himpunan.
+// Aktivitas tidak diperiksa karena fungsi lama tidak mencakup tepat 5 aktivitas.
+func TestIntegerInputsAreCovered(t *testing.T) {
+	for count := 0; count <= 10; count++ {
+		assert.Greater(t, sum(RepeatedCourses.Fuzzify(float64(count))), 0.0, "mata kuliah ulang %d", count)
+	}
+	for semester := 1; semester <= 14; semester++ {
+		assert.Greater(t, sum(StudyDuration.Fuzzify(float64(semester))), 0.0, "semester %d", semester)
+	}
+	for ipk := 0.0; ipk <= 4.0; ipk += 0.05 {
+		assert.Greater(t, sum(IPK.Fuzzify(ipk)), 0.0, "ipk %.2f", ipk)
+	}
+}
+
+func TestRepeatedCoursesTerms(t *testing.T) {
+	assert.Equal(t, 1.0, RepeatedCourses.Fuzzify(0)["SangatRendah"])
+	assert.Equal(t, 1.0, RepeatedCourses.Fuzzify(1)["Rendah"])
+	assert.Equal(t, 1.0, RepeatedCourses.Fuzzify(2)["Sedang"])
+	assert.Equal(t, 1.0, RepeatedCourses.Fuzzify(3)["Sedang"])
+	assert.Equal(t, 1.0, RepeatedCourses.Fuzzify(4)["Tinggi"])
+	assert.Equal(t, 1.0, RepeatedCourses.Fuzzify(5)["SangatTinggi"])
+}
+
+// baseline adalah fungsi keanggotaan lama yang dibangun langsung dari
+// utils.LinearMembershipDown dan utils.LinearMembershipUp
+var baseline = map[string]func(x float64, level string) Memberships{
+	"ipk": func(x float64, _ string) Memberships {
+		m := Memberships{
+			"SangatRendah": utils.LinearMembershipDown(x, 0.00, 2.00),
+			"SangatTinggi": utils.LinearMembershipUp(x, 3.50, 4.00),
+		}
+		m["Rendah"], m["Sedang"], m["Tinggi"] = 0, 0, 0
+		if x > 1.50 && x < 2.75 {
+			m["Rendah"] = utils.LinearMembershipDown(x, 1.50, 2.75)
+		}
+		if x > 2.00 && x < 3.50 {
+			if x <= 2.75 {
+				m["Sedang"] = utils.LinearMembershipUp(x, 2.00, 2.75)
+			} else {
+				m["Sedang"] = utils.LinearMembershipDown(x, 2.75, 3.50)
+			}
+		}
+		if x > 3.00 && x < 3.75 {
+			m["Tinggi"] = utils.LinearMembershipUp(x, 3.00, 3.75)
+		}
+		return m
+	},
+	"studyDuration": func(x float64, _ string) Memberships {
+		m := Memberships{
+			"SangatCepat": utils.LinearMembershipDown(x, 6, 8),
+			"Cepat":       utils.LinearMembershipDown(x, 7, 9),
+			"Sedang":      0,
+			"Lama":        utils.LinearMembershipUp(x, 9, 11),
+			"SangatLama":  utils.LinearMembershipUp(x, 11, 14),
+		}
+		if x >= 8 && x <= 10 {
+			if x <= 9 {
+				m["Sedang"] = utils.LinearMembershipUp(x, 8, 9)
+			} else {
+				m["Sedang"] = utils.LinearMembershipDown(x, 9, 10)
+			}
+		}
+		return m
+	},
+	// Rendah dan Sedang sengaja tidak dibandingkan: keduanya diperbaiki
+	"repeatedCourses": func(x float64, _ string) Memberships {
+		return Memberships{
+			"SangatRendah": utils.LinearMembershipDown(x, 0, 1),
+			"Tinggi":       utils.LinearMembershipUp(x, 3, 4),
+			"SangatTinggi": utils.LinearMembershipUp(x, 4, 5),
+		}
+	},
+	"achievement": func(x float64, level string) Memberships {
+		m := Memberships{"SangatTinggi": 0, "Tinggi": 0, "Sedang": 0, "Rendah": 0, "SangatRendah": 0}
+		if level == LevelInternasional {
+			m["SangatTinggi"] = utils.LinearMembershipDown(x, 1, 3)
+		}
+		if level == LevelInternasional || level == LevelNasional {
+			m["Tinggi"] = utils.LinearMembershipDown(x, 1, 5)
+		}
+		if level == LevelNasional {
+			m["Sedang"] = utils.LinearMembershipDown(x, 1, 10)
+		}
+		if level == LevelInternal {
+			m["Rendah"] = utils.LinearMembershipDown(x, 1, 10)
+		}
+		if level == LevelInternal || x > 10 {
+			m["SangatRendah"] = 1
+		}
+		return m
+	},
+	"thesis": func(x float64, level string) Memberships {
+		m := Memberships{"SangatTinggi": 0, "Tinggi": 0, "Sedang": 0, "Rendah": 0, "SangatRendah": 0}
+		switch level {
+		case LevelInternasional:
+			m["SangatTinggi"] = utils.LinearMembershipUp(x, 5, 7)
+			m["Tinggi"] = utils.LinearMembershipUp(x, 3, 5)
+		case LevelNasional:
+			m["Sedang"] = utils.LinearMembershipUp(x, 1, 3)
+			if x < 1 {
+				m["Rendah"] = utils.LinearMembershipDown(x, 0, 1)
+			}
+		case LevelInternal:
+			m["SangatRendah"] = 1
+		}
+		return m
+	},
+	"activity": func(x float64, _ string) Memberships {
+		m := Memberships{
+			"SangatRendah": utils.LinearMembershipDown(x, 0, 1),
+			"Rendah":       utils.LinearMembershipDown(x, 1, 3),
+			"Sedang":       0,
+			"Tinggi":       utils.LinearMembershipUp(x, 5, 7),
+			"SangatTinggi": utils.LinearMembershipUp(x, 6, 10),
+		}
+		if x > 1 && x < 5 {
+			if x <= 3 {
+				m["Sedang"] = utils.LinearMembershipUp(x, 1, 3)
+			} else {
+				m["Sedang"] = utils.LinearMembershipDown(x, 3, 5)
+			}
+		}
+		return m
+	},
+}
+
+// Himpunan yang dibangun ulang di atas pustaka fungsi keanggotaan harus sama
+// dengan fungsi lama pada setiap nilai bulat (IPK setiap 0.25)
+func TestBaselineMemberships(t *testing.T) {
+	points := func(min, max, step float64) []float64 {
+		var xs []float64
+		for x := min; x <= max+1e-9; x += step {
+			xs = append(xs, x)
+		}
+		return xs
+	}
+	tests := []struct {
+		variable *LinguisticVariable
+		xs       []float64
+		levels   []string
+	}{
+		{IPK, points(0, 4, 0.25), []string{""}},
+		{StudyDuration, points(0, 16, 1), []string{""}},
+		{RepeatedCourses, points(0, 25, 1), []string{""}},
+		{Achievement, points(0, 30, 1), []string{"", LevelInternasional, LevelNasional, LevelInternal}},
+		{Thesis, points(0, 12, 1), []string{"", LevelInternasional, LevelNasional, LevelInternal}},
+		{Activity, points(0, 25, 1), []string{""}},
+	}
+
+	for _, tt := range tests {
+		for _, level := range tt.levels {
+			for _, x := range tt.xs {
+				t.Run(fmt.Sprintf("%s %s %g", tt.variable.Name, level, x), func(t *testing.T) {
+					actual := tt.variable.FuzzifyLevel(x, level)
+					for term, expected := range baseline[tt.variable.Name](x, level) {
+						assert.InDelta(t, expected, actual[term], 1e-9, term)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestCategoricalPreconditions(t *testing.T) {
+	assert.Equal(t, 0.0, Achievement.FuzzifyLevel(1, LevelNasional)["SangatTinggi"])
+	assert.Equal(t, 1.0, Achievement.FuzzifyLevel(1, LevelInternasional)["SangatTinggi"])
+	assert.Equal(t, 1.0, Achievement.FuzzifyLevel(4, LevelInternal)["SangatRendah"])
+	assert.Equal(t, 1.0, Achievement.FuzzifyLevel(12, LevelNasional)["SangatRendah"])
+	assert.Equal(t, 0.0, Achievement.Fuzzify(1)["Tinggi"])
+	assert.Equal(t, 0.0, Thesis.FuzzifyLevel(6, LevelNasional)["SangatTinggi"])
+	assert.Equal(t, 0.5, Thesis.FuzzifyLevel(6, "Internasional")["SangatTinggi"])
+}
+
+func TestLevels(t *testing.T) {
+	assert.Equal(t, []string{LevelInternasional, LevelNasional, LevelInternal}, Achievement.Levels())
+	assert.Empty(t, IPK.Levels())
+	assert.True(t, Achievement.AcceptsLevel("Nasional"))
+	assert.False(t, Achievement.AcceptsLevel("regional"))
+	assert.False(t, IPK.AcceptsLevel(LevelNasional))
+}
+
+func TestLinguisticVariable(t *testing.T) {
+	v := &LinguisticVariable{
+		Name: "sks", Min: 0, Max: 24,
+		Terms: []Term{
+			{Name: "Rendah", Function: down(12, 16)},
+			{Name: "Tinggi", Function: up(16, 21)},
+			{Name: "Tinggi", Function: constant(1), Levels: []string{"unggulan"}},
+		},
+	}
+
+	assert.Equal(t, []string{"Rendah", "Tinggi"}, v.TermNames())
+	assert.True(t, v.HasTerm("Tinggi"))
+	assert.True(t, v.Categorical())
+	assert.Equal(t, Memberships{"Rendah": 0.5, "Tinggi": 0}, v.Fuzzify(14))
+	assert.Equal(t, Memberships{"Rendah": 0.5, "Tinggi": 1}, v.FuzzifyLevel(14, "Unggulan"))
+
+	_, ok := Lookup("ipk")
+	assert.True(t, ok)
+	_, ok = Lookup("sks")
+	assert.False(t, ok)
+}
+
+func TestFuzzifyInterval(t *testing.T) {
+	// Anggota komisi berbeda pendapat apakah IPK "Tinggi" dimulai dari 3.0
+	// atau 3.25
+	v := &LinguisticVariable{
+		Name: "ipk", Min: 0, Max: 4,
+		Terms: []Term{
+			{Name: "Sedang", Function: triangle(2.00, 2.75, 3.50)},
+			{Name: "Tinggi", Function: up(3.00, 3.75), Lower: up(3.25, 3.75)},
+		},
+	}
+	assert.True(t, v.TypeTwo())
+	assert.False(t, IPK.TypeTwo())
+
+	lower, upper := v.FuzzifyInterval(3.25, "")
+	assert.Equal(t, Memberships{"Sedang": 1.0 / 3, "Tinggi": 0}, lower)
+	assert.Equal(t, Memberships{"Sedang": 1.0 / 3, "Tinggi": 1.0 / 3}, upper)
+
+	// Himpunan tipe-1 menghasilkan interval dengan lebar nol
+	lower, upper = IPK.FuzzifyInterval(3.25, "")
+	assert.Equal(t, IPK.Fuzzify(3.25), lower)
+	assert.Equal(t, lower, upper)
+}
+
+func sum(memberships Memberships) float64 {
+	total := 0.0
+	for _, degree := range memberships {
+		total += degree
+	}
+	return total
+}
@@ -0,0 +1,160 @@
+package fuzzifikasi
+
+import (
+	"strings"
+
+	"go-tsukamoto/internal/modules/utils"
+)
+
+// Memberships adalah derajat keanggotaan masukan pada setiap himpunan
+type Memberships map[string]float64
+
+// Term adalah himpunan fuzzy bernama pada sebuah variabel linguistik.
+// Levels adalah prasyarat tegas untuk masukan kategoris (misalnya level
+// prestasi): jika diisi, himpunan hanya aktif bila level masukan termasuk di
+// dalamnya. Nama himpunan boleh dideklarasikan lebih dari sekali dengan
+// prasyarat berbeda; derajatnya adalah nilai maksimum dari deklarasi yang aktif.
+//
+// Lower menjadikan himpunan interval tipe-2: Function adalah fungsi
+// keanggotaan atas dan Lower fungsi keanggotaan bawah, sehingga derajat
+// keanggotaan berupa interval [Lower(x), Function(x)] (footprint of
+// uncertainty). Lower nil berarti himpunan tipe-1.
+type Term struct {
+	Name     string
+	Function utils.MembershipFunction
+	Lower    utils.MembershipFunction
+	Levels   []string
+}
+
+// LinguisticVariable adalah variabel linguistik dengan semesta [Min, Max].
+// OpenMax menandakan batas atas terbuka, misalnya jumlah aktivitas: nilai di
+// atas Max tetap sah dan diperlakukan sama dengan Max, tempat setiap himpunan
+// sudah mendatar.
+type LinguisticVariable struct {
+	Name    string
+	Min     float64
+	Max     float64
+	OpenMax bool
+	Terms   []Term
+}
+
+// Fuzzify menghitung derajat keanggotaan x pada setiap himpunan tanpa level
+func (v *LinguisticVariable) Fuzzify(x float64) Memberships {
+	return v.FuzzifyLevel(x, "")
+}
+
+// FuzzifyLevel menghitung derajat keanggotaan x dengan level kategoris masukan
+func (v *LinguisticVariable) FuzzifyLevel(x float64, level string) Memberships {
+	memberships := make(Memberships, len(v.Terms))
+	for _, term := range v.Terms {
+		degree := 0.0
+		if term.accepts(level) {
+			degree = term.Function.Degree(x)
+		}
+		memberships[term.Name] = max(memberships[term.Name], degree)
+	}
+	return memberships
+}
+
+// FuzzifyInterval menghitung interval derajat keanggotaan x dengan level
+// kategoris masukan: derajat fungsi bawah dan fungsi atas setiap himpunan.
+// Pada himpunan tipe-1 kedua derajat sama.
+func (v *LinguisticVariable) FuzzifyInterval(x float64, level string) (Memberships, Memberships) {
+	lower := make(Memberships, len(v.Terms))
+	upper := make(Memberships, len(v.Terms))
+	for _, term := range v.Terms {
+		lo, hi := 0.0, 0.0
+		if term.accepts(level) {
+			hi = term.Function.Degree(x)
+			lo = hi
+			if term.Lower != nil {
+				lo = min(term.Lower.Degree(x), hi)
+			}
+		}
+		lower[term.Name] = max(lower[term.Name], lo)
+		upper[term.Name] = max(upper[term.Name], hi)
+	}
+	return lower, upper
+}
+
+// TypeTwo menandakan variabel memiliki himpunan interval tipe-2
+func (v *LinguisticVariable) TypeTwo() bool {
+	for _, term := range v.Terms {
+		if term.Lower != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// TermNames mengembalikan nama himpunan sesuai urutan deklarasi, tanpa duplikat
+func (v *LinguisticVariable) TermNames() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, term := range v.Terms {
+		if !seen[term.Name] {
+			seen[term.Name] = true
+			names = append(names, term.Name)
+		}
+	}
+	return names
+}
+
+// HasTerm memeriksa apakah variabel memiliki himpunan bernama name
+func (v *LinguisticVariable) HasTerm(name string) bool {
+	for _, term := range v.Terms {
+		if term.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Categorical menandakan variabel memiliki himpunan dengan prasyarat level
+func (v *LinguisticVariable) Categorical() bool {
+	for _, term := range v.Terms {
+		if len(term.Levels) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Levels mengembalikan level prasyarat seluruh himpunan sesuai urutan
+// deklarasi, tanpa duplikat; kosong untuk variabel non-kategoris
+func (v *LinguisticVariable) Levels() []string {
+	var levels []string
+	seen := map[string]bool{}
+	for _, term := range v.Terms {
+		for _, level := range term.Levels {
+			if !seen[level] {
+				seen[level] = true
+				levels = append(levels, level)
+			}
+		}
+	}
+	return levels
+}
+
+// AcceptsLevel memeriksa apakah level termasuk prasyarat salah satu himpunan,
+// tanpa membedakan huruf besar-kecil
+func (v *LinguisticVariable) AcceptsLevel(level string) bool {
+	for _, term := range v.Terms {
+		if len(term.Levels) > 0 && term.accepts(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Term) accepts(level string) bool {
+	if len(t.Levels) == 0 {
+		return true
+	}
+	for _, l := range t.Levels {
+		if strings.EqualFold(l, level) {
+			return true
+		}
+	}
+	return false
+}
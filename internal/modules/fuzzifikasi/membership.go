@@ -0,0 +1,32 @@
+package fuzzifikasi
+
+import "go-tsukamoto/internal/modules/utils"
+
+// down adalah bahu kiri: 1 sampai a, turun linear hingga 0 di b
+func down(a, b float64) utils.MembershipFunction {
+	return utils.Must(utils.NewPiecewiseLinear([]utils.Point{{X: a, Y: 1}, {X: b, Y: 0}}))
+}
+
+// up adalah bahu kanan: 0 sampai a, naik linear hingga 1 di b
+func up(a, b float64) utils.MembershipFunction {
+	return utils.Must(utils.NewPiecewiseLinear([]utils.Point{{X: a, Y: 0}, {X: b, Y: 1}}))
+}
+
+// piecewise membuat fungsi dari pasangan x, y; dua titik dengan x sama
+// adalah lompatan
+func piecewise(pairs ...float64) utils.MembershipFunction {
+	return utils.Must(utils.NewMembershipFunction(utils.KindPiecewise, pairs))
+}
+
+func triangle(a, b, c float64) utils.MembershipFunction {
+	return utils.Must(utils.NewTriangular(a, b, c))
+}
+
+func trapezoid(a, b, c, d float64) utils.MembershipFunction {
+	return utils.Must(utils.NewTrapezoidal(a, b, c, d))
+}
+
+// constant bernilai tetap pada seluruh semesta
+func constant(degree float64) utils.MembershipFunction {
+	return utils.Must(utils.NewPiecewiseLinear([]utils.Point{{X: 0, Y: degree}, {X: 1, Y: degree}}))
+}
@@ -0,0 +1,116 @@
+package fuzzifikasi
+
+import log "github.com/sirupsen/logrus"
+
+// Level prestasi dan publikasi yang menjadi prasyarat himpunan
+const (
+	LevelInternasional = "internasional"
+	LevelNasional      = "nasional"
+	LevelInternal      = "internal"
+)
+
+// IPK pada semesta [0, 4]. Rendah dan Tinggi mempertahankan bentuk fungsi
+// lama yang terpotong: Rendah melompat ke 1 tepat setelah 1.50 dan Tinggi
+// kembali 0 mulai 3.75, tempat SangatTinggi mengambil alih.
+var IPK = &LinguisticVariable{
+	Name: "ipk", Min: 0, Max: 4,
+	Terms: []Term{
+		{Name: "SangatRendah", Function: down(0.00, 2.00)},
+		{Name: "Rendah", Function: piecewise(1.50, 0, 1.50, 1, 2.75, 0)},
+		{Name: "Sedang", Function: triangle(2.00, 2.75, 3.50)},
+		{Name: "Tinggi", Function: piecewise(3.00, 0, 3.75, 1, 3.75, 0)},
+		{Name: "SangatTinggi", Function: up(3.50, 4.00)},
+	},
+}
+
+// StudyDuration adalah lama studi dalam semester
+var StudyDuration = &LinguisticVariable{
+	Name: "studyDuration", Min: 1, Max: 14, OpenMax: true,
+	Terms: []Term{
+		{Name: "SangatCepat", Function: down(6, 8)},
+		{Name: "Cepat", Function: down(7, 9)},
+		{Name: "Sedang", Function: triangle(8, 9, 10)},
+		{Name: "Lama", Function: up(9, 11)},
+		{Name: "SangatLama", Function: up(11, 14)},
+	},
+}
+
+// RepeatedCourses adalah jumlah mata kuliah mengulang. Rendah dan Sedang
+// saling beririsan sehingga setiap jumlah memiliki derajat pada minimal satu
+// himpunan; himpunan lain sama dengan fungsi lama.
+var RepeatedCourses = &LinguisticVariable{
+	Name: "repeatedCourses", Min: 0, Max: 20, OpenMax: true,
+	Terms: []Term{
+		{Name: "SangatRendah", Function: down(0, 1)},
+		{Name: "Rendah", Function: triangle(0, 1, 2)},
+		{Name: "Sedang", Function: trapezoid(1, 2, 3, 4)},
+		{Name: "Tinggi", Function: up(3, 4)},
+		{Name: "SangatTinggi", Function: up(4, 5)},
+	},
+}
+
+// Achievement adalah peringkat prestasi terbaik dengan level sebagai prasyarat
+var Achievement = &LinguisticVariable{
+	Name: "achievement", Min: 1, Max: 20, OpenMax: true,
+	Terms: []Term{
+		{Name: "SangatTinggi", Function: down(1, 3), Levels: []string{LevelInternasional}},
+		{Name: "Tinggi", Function: down(1, 5), Levels: []string{LevelInternasional, LevelNasional}},
+		{Name: "Sedang", Function: down(1, 10), Levels: []string{LevelNasional}},
+		{Name: "Rendah", Function: down(1, 10), Levels: []string{LevelInternal}},
+		{Name: "SangatRendah", Function: constant(1), Levels: []string{LevelInternal}},
+		{Name: "SangatRendah", Function: up(10, 11)},
+	},
+}
+
+// Thesis adalah impact factor publikasi skripsi dengan level sebagai prasyarat
+var Thesis = &LinguisticVariable{
+	Name: "thesis", Min: 0, Max: 10, OpenMax: true,
+	Terms: []Term{
+		{Name: "SangatTinggi", Function: up(5, 7), Levels: []string{LevelInternasional}},
+		{Name: "Tinggi", Function: up(3, 5), Levels: []string{LevelInternasional}},
+		{Name: "Sedang", Function: up(1, 3), Levels: []string{LevelNasional}},
+		{Name: "Rendah", Function: down(0, 1), Levels: []string{LevelNasional}},
+		{Name: "SangatRendah", Function: constant(1), Levels: []string{LevelInternal}},
+	},
+}
+
+// Activity adalah jumlah aktivitas organisasi
+var Activity = &LinguisticVariable{
+	Name: "activity", Min: 0, Max: 20, OpenMax: true,
+	Terms: []Term{
+		{Name: "SangatRendah", Function: down(0, 1)},
+		{Name: "Rendah", Function: down(1, 3)},
+		{Name: "Sedang", Function: triangle(1, 3, 5)},
+		{Name: "Tinggi", Function: up(5, 7)},
+		{Name: "SangatTinggi", Function: up(6, 10)},
+	},
+}
+
+// Variables adalah seluruh variabel masukan yang dapat dirujuk aturan
+var Variables = []*LinguisticVariable{IPK, StudyDuration, RepeatedCourses, Achievement, Thesis, Activity}
+
+// Lookup mencari variabel masukan berdasarkan nama
+func Lookup(name string) (*LinguisticVariable, bool) {
+	for _, v := range Variables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// FuzzifyAll memfuzzifikasi nilai tegas setiap variabel bawaan; levels berisi
+// level kategoris untuk variabel yang memilikinya
+func FuzzifyAll(crisp map[string]float64, levels map[string]string) map[string]Memberships {
+	return FuzzifyVariables(Variables, crisp, levels)
+}
+
+// FuzzifyVariables memfuzzifikasi nilai tegas pada variabel yang diberikan
+func FuzzifyVariables(variables []*LinguisticVariable, crisp map[string]float64, levels map[string]string) map[string]Memberships {
+	result := make(map[string]Memberships, len(variables))
+	for _, v := range variables {
+		result[v.Name] = v.FuzzifyLevel(crisp[v.Name], levels[v.Name])
+		log.Infof("Fuzzifikasi %s: %+v", v.Name, result[v.Name])
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package inferensia
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-tsukamoto/internal/modules/defuzzifikasi"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+)
+
+// Engine adalah metode inferensi yang dijalankan atas variabel linguistik
+// dan basis aturan yang sama
+type Engine string
+
+const (
+	// EngineTsukamoto menginversikan α pada himpunan keluaran monoton lalu
+	// merata-ratakan z secara terbobot
+	EngineTsukamoto Engine = "tsukamoto"
+	// EngineMamdani memotong himpunan keluaran pada α, mengagregasi dengan max
+	// dan mendefuzzifikasi dengan centroid
+	EngineMamdani Engine = "mamdani"
+	// EngineLarsen adalah Mamdani dengan implikasi product (himpunan diskalakan)
+	EngineLarsen Engine = "larsen"
+	// EngineSugeno menghitung keluaran setiap aturan dari konsekuen konstanta
+	// atau linear terhadap masukan tegas lalu merata-ratakannya secara terbobot
+	EngineSugeno Engine = "sugeno"
+)
+
+// ErrUnknownEngine dikembalikan untuk nama metode inferensi yang tidak dikenal
+var ErrUnknownEngine = errors.New("unknown inference engine")
+
+// Engines adalah seluruh metode inferensi yang tersedia
+var Engines = []Engine{EngineTsukamoto, EngineMamdani, EngineLarsen, EngineSugeno}
+
+var defaultEngine = EngineTsukamoto
+
+// DefaultEngine mengembalikan metode inferensi yang dipakai jika permintaan
+// tidak memilih metode
+func DefaultEngine() Engine {
+	return defaultEngine
+}
+
+// SetDefaultEngine mengganti metode inferensi bawaan
+func SetDefaultEngine(engine Engine) {
+	defaultEngine = engine
+}
+
+// ParseEngine membaca nama metode inferensi; nama kosong berarti metode bawaan
+func ParseEngine(name string) (Engine, error) {
+	if name == "" {
+		return DefaultEngine(), nil
+	}
+	for _, engine := range Engines {
+		if strings.EqualFold(name, string(engine)) {
+			return engine, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownEngine, name)
+}
+
+// aggregates bernilai true untuk metode yang mengagregasi himpunan keluaran
+// sehingga memakai defuzzifier model
+func (e Engine) aggregates() bool {
+	return e == EngineMamdani || e == EngineLarsen
+}
+
+// defuzzify menghitung nilai tegas aktivasi aturan dengan metode engine
+func (e Engine) defuzzify(m *model.Model, firings []rules.Firing, crisp map[string]float64, verbose bool) defuzzifikasi.Result {
+	output := m.Output
+	activations := make([]defuzzifikasi.Activation, 0, len(firings))
+	for _, firing := range firings {
+		activations = append(activations, defuzzifikasi.Activation{Predicate: firing.Predicate, Alpha: firing.Strength})
+	}
+
+	switch e {
+	case EngineSugeno:
+		return output.WeightedAverage(activations, sugenoOutputs(output, firings, crisp))
+	case EngineMamdani:
+		return output.Mamdani(activations, defuzzifikasi.ImplicationMin, m.Defuzzifier)
+	case EngineLarsen:
+		return output.Mamdani(activations, defuzzifikasi.ImplicationProduct, m.Defuzzifier)
+	default:
+		if verbose {
+			return output.Evaluate(activations)
+		}
+		return output.Compute(activations)
+	}
+}
+
+// defuzzifyInterval menghitung interval skor dari interval penyulutan aturan
+// dengan reduksi tipe Karnik-Mendel sesuai metode engine
+func (e Engine) defuzzifyInterval(m *model.Model, firings []rules.IntervalFiring, crisp map[string]float64) defuzzifikasi.Result {
+	output := m.Output
+	activations := make([]defuzzifikasi.IntervalActivation, len(firings))
+	plain := make([]rules.Firing, len(firings))
+	for i, firing := range firings {
+		activations[i] = defuzzifikasi.IntervalActivation{Predicate: firing.Predicate, Lower: firing.Lower, Upper: firing.Upper}
+		plain[i] = firing.Firing
+	}
+
+	switch e {
+	case EngineSugeno:
+		outputs := sugenoOutputs(output, plain, crisp)
+		return output.IntervalWeightedAverage(activations, outputs, outputs)
+	case EngineMamdani:
+		return output.IntervalMamdani(activations, defuzzifikasi.ImplicationMin)
+	case EngineLarsen:
+		return output.IntervalMamdani(activations, defuzzifikasi.ImplicationProduct)
+	default:
+		return output.IntervalCompute(activations)
+	}
+}
+
+// sugenoOutputs menghitung keluaran z_i setiap aturan; aturan tanpa konsekuen
+// Sugeno memakai titik tengah himpunan keluaran predikatnya (orde nol)
+func sugenoOutputs(output *defuzzifikasi.Output, firings []rules.Firing, crisp map[string]float64) []float64 {
+	outputs := make([]float64, len(firings))
+	for i, firing := range firings {
+		if firing.Output != nil {
+			outputs[i] = firing.Output.Value(crisp)
+		} else if set, ok := output.Sets[firing.Predicate]; ok {
+			outputs[i] = set.Center()
+		}
+	}
+	return outputs
+}
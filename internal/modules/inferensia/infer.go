@@ -0,0 +1,136 @@
+package inferensia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go-tsukamoto/internal/modules/defuzzifikasi"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+)
+
+// StudentProfile adalah masukan inferensi satu mahasiswa
+type StudentProfile = rules.StudentProfile
+
+var (
+	// ErrNoModelSource dikembalikan jika versi model diminta tanpa sumber model
+	ErrNoModelSource = errors.New("model version requested without a model source")
+	// ErrModelVersionMismatch dikembalikan jika model yang diberikan bukan
+	// versi yang diminta
+	ErrModelVersionMismatch = errors.New("model version mismatch")
+)
+
+// ModelSource memuat model fuzzy yang dipublikasikan atau versi tertentu;
+// dipenuhi oleh layanan versi model
+type ModelSource interface {
+	GetPublishedModel(ctx context.Context) (*model.Model, error)
+	CompileModel(ctx context.Context, version int) (*model.Model, error)
+}
+
+// Result adalah hasil inferensi satu mahasiswa. Uncertainty hanya terisi pada
+// model interval tipe-2 dan Trace hanya terisi pada mode penjelasan.
+type Result struct {
+	Predicate    string
+	Score        float64
+	Band         defuzzifikasi.Band
+	ModelVersion int
+	Engine       Engine
+	Uncertainty  *defuzzifikasi.ScoreInterval
+	Trace        *Trace
+}
+
+// Option mengatur satu pemanggilan Infer
+type Option func(*inferOptions)
+
+type inferOptions struct {
+	engine  Engine
+	model   *model.Model
+	source  ModelSource
+	version int
+	explain bool
+}
+
+// WithEngine memilih metode inferensi; kosong berarti metode bawaan
+func WithEngine(engine Engine) Option {
+	return func(o *inferOptions) { o.engine = engine }
+}
+
+// WithModel menjalankan model yang sudah dikompilasi
+func WithModel(m *model.Model) Option {
+	return func(o *inferOptions) { o.model = m }
+}
+
+// WithModelSource memuat model dari sumber model; tanpa WithModelVersion
+// model yang dipublikasikan yang dipakai
+func WithModelSource(source ModelSource) Option {
+	return func(o *inferOptions) { o.source = source }
+}
+
+// WithModelVersion memilih versi model. Versi dimuat dari WithModelSource,
+// atau dicocokkan dengan versi model pada WithModel.
+func WithModelVersion(version int) Option {
+	return func(o *inferOptions) { o.version = version }
+}
+
+// WithExplain menyertakan jejak lengkap pada hasil dan mencatat langkah
+// inferensi ke log
+func WithExplain(explain bool) Option {
+	return func(o *inferOptions) { o.explain = explain }
+}
+
+// Infer menjalankan inferensi atas profil mahasiswa. Tanpa opsi, model dan
+// metode inferensi bawaan yang dipakai. Profil di luar domain model ditolak
+// dengan *ValidationError (lihat Validate).
+func Infer(ctx context.Context, profile StudentProfile, opts ...Option) (*Result, error) {
+	o := inferOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	engine, err := ParseEngine(string(o.engine))
+	if err != nil {
+		return nil, err
+	}
+	m, err := o.resolveModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	crisp, levels := profile.Inputs()
+	trace := explain(m, engine, crisp, levels, o.explain)
+	if trace.Err != nil {
+		return nil, trace.Err
+	}
+	result := &Result{
+		Predicate:    trace.Predicate,
+		Score:        trace.Score,
+		Band:         trace.Band,
+		ModelVersion: trace.ModelVersion,
+		Engine:       trace.Engine,
+		Uncertainty:  trace.Uncertainty,
+	}
+	if o.explain {
+		result.Trace = trace
+	}
+	return result, nil
+}
+
+// resolveModel memilih model menurut urutan: model yang diberikan, sumber
+// model, lalu model bawaan
+func (o inferOptions) resolveModel(ctx context.Context) (*model.Model, error) {
+	switch {
+	case o.model != nil:
+		if o.version != 0 && o.model.Version != o.version {
+			return nil, fmt.Errorf("%w: model is version %d, requested %d", ErrModelVersionMismatch, o.model.Version, o.version)
+		}
+		return o.model, nil
+	case o.source != nil && o.version != 0:
+		return o.source.CompileModel(ctx, o.version)
+	case o.source != nil:
+		return o.source.GetPublishedModel(ctx)
+	case o.version != 0:
+		return nil, fmt.Errorf("%w: version %d", ErrNoModelSource, o.version)
+	default:
+		return model.Default(), nil
+	}
+}
@@ -0,0 +1,105 @@
+package inferensia
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-tsukamoto/internal/modules/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource adalah sumber model dengan satu model per versi; versi 0 adalah
+// model yang dipublikasikan
+type fakeSource map[int]*model.Model
+
+func (s fakeSource) GetPublishedModel(ctx context.Context) (*model.Model, error) {
+	return s.CompileModel(ctx, 0)
+}
+
+func (s fakeSource) CompileModel(ctx context.Context, version int) (*model.Model, error) {
+	m, ok := s[version]
+	if !ok {
+		return nil, errors.New("fuzzy model not found")
+	}
+	return m, nil
+}
+
+func versioned(t *testing.T, version int) *model.Model {
+	m, err := model.Compile(model.Default().Definition())
+	require.NoError(t, err)
+	m.Version = version
+	return m
+}
+
+var profile = StudentProfile{
+	IPK:                3.6,
+	CompletedSemester:  8,
+	RepeatedCourses:    1,
+	AchievementRank:    3,
+	AchievementLevel:   "nasional",
+	ThesisImpactFactor: 3,
+	ThesisLevel:        "nasional",
+	ActivityCount:      3,
+}
+
+func TestInfer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Defaults", func(t *testing.T) {
+		result, err := Infer(ctx, profile)
+		require.NoError(t, err)
+		trace := Explain(model.Default(), DefaultEngine(), 3.6, 8, 1, 3, "nasional", 3, "nasional", 3)
+		assert.Equal(t, trace.Predicate, result.Predicate)
+		assert.Equal(t, trace.Score, result.Score)
+		assert.Equal(t, trace.Band, result.Band)
+		assert.Equal(t, DefaultEngine(), result.Engine)
+		assert.Nil(t, result.Trace)
+	})
+
+	t.Run("Engine And Explain", func(t *testing.T) {
+		for _, engine := range Engines {
+			result, err := Infer(ctx, profile, WithEngine(engine), WithExplain(true))
+			require.NoError(t, err)
+			assert.Equal(t, engine, result.Engine)
+			require.NotNil(t, result.Trace)
+			assert.Equal(t, result.Predicate, result.Trace.Predicate)
+			assert.Equal(t, 3.6, result.Trace.Inputs["ipk"])
+			assert.NotEmpty(t, result.Trace.Rules)
+		}
+	})
+
+	t.Run("Model Source", func(t *testing.T) {
+		source := fakeSource{0: versioned(t, 3), 2: versioned(t, 2)}
+
+		result, err := Infer(ctx, profile, WithModelSource(source))
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.ModelVersion)
+
+		result, err = Infer(ctx, profile, WithModelSource(source), WithModelVersion(2))
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.ModelVersion)
+
+		_, err = Infer(ctx, profile, WithModelSource(source), WithModelVersion(5))
+		assert.EqualError(t, err, "fuzzy model not found")
+	})
+
+	t.Run("Model Version", func(t *testing.T) {
+		result, err := Infer(ctx, profile, WithModel(versioned(t, 4)), WithModelVersion(4))
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.ModelVersion)
+
+		_, err = Infer(ctx, profile, WithModel(versioned(t, 4)), WithModelVersion(2))
+		assert.ErrorIs(t, err, ErrModelVersionMismatch)
+
+		_, err = Infer(ctx, profile, WithModelVersion(2))
+		assert.ErrorIs(t, err, ErrNoModelSource)
+	})
+
+	t.Run("Unknown Engine", func(t *testing.T) {
+		_, err := Infer(ctx, profile, WithEngine("fuzzy"))
+		assert.ErrorIs(t, err, ErrUnknownEngine)
+	})
+}
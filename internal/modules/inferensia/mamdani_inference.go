@@ -0,0 +1,20 @@
+package inferensia
+
+import (
+	"go-tsukamoto/internal/modules/model"
+)
+
+// MamdaniInference menjalankan proses inferensi menggunakan metode Fuzzy Mamdani
+// atas variabel dan basis aturan bawaan.
+//
+// Deprecated: gunakan Infer dengan WithEngine(EngineMamdani).
+func MamdaniInference(ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) string {
+	return MamdaniExplain(model.Default(), ipk, completedSemester, repeatedCourses, achievementRank, achievementLevel, thesisImpactFactor, thesisLevel, activityCount).Predicate
+}
+
+// MamdaniExplain menjalankan inferensi Mamdani dan mengembalikan jejak lengkapnya.
+//
+// Deprecated: gunakan Infer dengan WithModel, WithEngine(EngineMamdani) dan WithExplain.
+func MamdaniExplain(m *model.Model, ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) *Trace {
+	return Explain(m, EngineMamdani, ipk, completedSemester, repeatedCourses, achievementRank, achievementLevel, thesisImpactFactor, thesisLevel, activityCount)
+}
@@ -0,0 +1,20 @@
+package inferensia
+
+import (
+	"go-tsukamoto/internal/modules/model"
+)
+
+// SugenoInference menjalankan proses inferensi menggunakan metode Fuzzy Sugeno
+// (Takagi-Sugeno-Kang) atas variabel dan basis aturan bawaan.
+//
+// Deprecated: gunakan Infer dengan WithEngine(EngineSugeno).
+func SugenoInference(ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) string {
+	return SugenoExplain(model.Default(), ipk, completedSemester, repeatedCourses, achievementRank, achievementLevel, thesisImpactFactor, thesisLevel, activityCount).Predicate
+}
+
+// SugenoExplain menjalankan inferensi Sugeno dan mengembalikan jejak lengkapnya.
+//
+// Deprecated: gunakan Infer dengan WithModel, WithEngine(EngineSugeno) dan WithExplain.
+func SugenoExplain(m *model.Model, ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) *Trace {
+	return Explain(m, EngineSugeno, ipk, completedSemester, repeatedCourses, achievementRank, achievementLevel, thesisImpactFactor, thesisLevel, activityCount)
+}
@@ -2,24 +2,204 @@ package inferensia
 
 import (
 	"go-tsukamoto/internal/modules/defuzzifikasi"
-	"go-tsukamoto/internal/modules/rules"
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+	"go-tsukamoto/internal/modules/model"
 
 	log "github.com/sirupsen/logrus"
 )
 
-// TsukamotoInference menjalankan proses inferensi menggunakan metode Fuzzy Tsukamoto
+// RuleTrace adalah jejak satu aturan: kekuatan penyulutan, keluaran z dan
+// bobot ternormalisasinya. Pada Tsukamoto z adalah hasil inversi α, pada
+// Mamdani z adalah centroid himpunan keluaran aturan tersebut dan pada Sugeno
+// z adalah nilai konsekuennya.
+//
+// Pada model interval tipe-2 Lower dan Upper adalah interval penyulutan dan
+// Strength titik tengahnya.
+type RuleTrace struct {
+	Rule       string
+	Predicate  string
+	Strength   float64
+	Lower      float64
+	Upper      float64
+	Output     float64
+	Normalized float64
+}
+
+// Trace adalah jejak lengkap satu proses inferensi. Pada model interval
+// tipe-2 Memberships adalah derajat fungsi atas, LowerMemberships derajat
+// fungsi bawah dan Uncertainty interval skor hasil reduksi tipe; keduanya
+// kosong pada model tipe-1.
+//
+// Err berisi *ValidationError jika masukan berada di luar domain model; jejak
+// tersebut tidak memiliki skor maupun predikat.
+type Trace struct {
+	ModelVersion     int
+	Engine           Engine
+	Defuzzifier      string
+	Inputs           map[string]float64
+	Memberships      map[string]fuzzifikasi.Memberships
+	LowerMemberships map[string]fuzzifikasi.Memberships
+	Rules            []RuleTrace
+	Score            float64
+	Band             defuzzifikasi.Band
+	Predicate        string
+	Uncertainty      *defuzzifikasi.ScoreInterval
+	Err              error
+}
+
+// TsukamotoInference menjalankan proses inferensi menggunakan metode Fuzzy
+// Tsukamoto. Masukan di luar domain model menghasilkan predikat kosong.
+//
+// Deprecated: gunakan Infer dengan StudentProfile agar argumen tidak tertukar.
 func TsukamotoInference(ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) string {
-	// Mengambil hasil aturan Fuzzy Tsukamoto
-	ruleResults := rules.TsukamotoRules(ipk, completedSemester, repeatedCourses, achievementRank, achievementLevel, thesisImpactFactor, thesisLevel, activityCount)
+	return TsukamotoInferenceModel(model.Default(), ipk, completedSemester, repeatedCourses, achievementRank, achievementLevel, thesisImpactFactor, thesisLevel, activityCount)
+}
+
+// TsukamotoInferenceModel menjalankan inferensi Tsukamoto dengan model fuzzy tertentu.
+//
+// Deprecated: gunakan Infer dengan WithModel.
+func TsukamotoInferenceModel(m *model.Model, ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) string {
+	return TsukamotoExplain(m, ipk, completedSemester, repeatedCourses, achievementRank, achievementLevel, thesisImpactFactor, thesisLevel, activityCount).Predicate
+}
+
+// TsukamotoExplain menjalankan inferensi Tsukamoto dan mengembalikan jejak lengkapnya.
+//
+// Deprecated: gunakan Infer dengan WithModel dan WithExplain.
+func TsukamotoExplain(m *model.Model, ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) *Trace {
+	return Explain(m, EngineTsukamoto, ipk, completedSemester, repeatedCourses, achievementRank, achievementLevel, thesisImpactFactor, thesisLevel, activityCount)
+}
+
+// Explain menjalankan inferensi dengan metode engine dan mengembalikan jejak lengkapnya.
+//
+// Deprecated: gunakan Infer dengan WithModel, WithEngine dan WithExplain.
+func Explain(m *model.Model, engine Engine, ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) *Trace {
+	crisp, levels := StudentProfile{
+		IPK:                ipk,
+		CompletedSemester:  completedSemester,
+		RepeatedCourses:    repeatedCourses,
+		AchievementRank:    achievementRank,
+		AchievementLevel:   achievementLevel,
+		ThesisImpactFactor: thesisImpactFactor,
+		ThesisLevel:        thesisLevel,
+		ActivityCount:      activityCount,
+	}.Inputs()
+	return explain(m, engine, crisp, levels, true)
+}
+
+// Evaluate menjalankan inferensi terhadap nilai tegas setiap variabel tanpa
+// mencatat log; dipakai untuk analisis yang mengevaluasi model berulang kali.
+// Masukan di luar domain model tidak dievaluasi dan dilaporkan pada Trace.Err.
+func Evaluate(m *model.Model, crisp map[string]float64, levels map[string]string) *Trace {
+	return EvaluateEngine(m, DefaultEngine(), crisp, levels)
+}
+
+// EvaluateEngine seperti Evaluate dengan metode inferensi tertentu
+func EvaluateEngine(m *model.Model, engine Engine, crisp map[string]float64, levels map[string]string) *Trace {
+	return explain(m, engine, crisp, levels, false)
+}
 
-	// Log hasil aturan fuzzy
-	log.Infof("Hasil Aturan Fuzzy: %+v", ruleResults)
+func explain(m *model.Model, engine Engine, crisp map[string]float64, levels map[string]string, verbose bool) *Trace {
+	if err := Validate(m, crisp, levels); err != nil {
+		if verbose {
+			log.Warnf("Masukan fuzzy tidak valid (model versi %d): %v", m.Version, err)
+		}
+		return &Trace{ModelVersion: m.Version, Engine: engine, Inputs: crisp, Err: err}
+	}
+	crisp = clamp(m, crisp)
+	if m.TypeTwo() {
+		return explainInterval(m, engine, crisp, levels, verbose)
+	}
+
+	// Fuzzifikasi input dan evaluasi basis aturan model
+	var fuzzy map[string]fuzzifikasi.Memberships
+	if verbose {
+		fuzzy = fuzzifikasi.FuzzifyVariables(m.Variables, crisp, levels)
+	} else {
+		fuzzy = make(map[string]fuzzifikasi.Memberships, len(m.Variables))
+		for _, v := range m.Variables {
+			fuzzy[v.Name] = v.FuzzifyLevel(crisp[v.Name], levels[v.Name])
+		}
+	}
+	firings := m.RuleBase.Evaluate(fuzzy, crisp)
 
 	// Defuzzifikasi hasil untuk mendapatkan output final
-	finalResult := defuzzifikasi.Defuzzify(ruleResults)
+	if verbose {
+		// Log hasil aturan fuzzy
+		log.Infof("Hasil Aturan Fuzzy (model versi %d, metode %s): %+v", m.Version, engine, firings)
+	}
+	result := engine.defuzzify(m, firings, crisp, verbose)
+	if verbose && engine != EngineTsukamoto {
+		log.Infof("Hasil Defuzzifikasi %s: %s (skor %f)", engine, result.Predicate, result.Score)
+	}
+
+	trace := newTrace(m, engine, crisp, fuzzy, result)
+	if engine.aggregates() && m.Defuzzifier != nil {
+		trace.Defuzzifier = m.Defuzzifier.Name()
+	}
+	for i, firing := range firings {
+		trace.Rules = append(trace.Rules, RuleTrace{
+			Rule:       firing.Rule,
+			Predicate:  firing.Predicate,
+			Strength:   firing.Strength,
+			Output:     result.Contributions[i].Z,
+			Normalized: result.Contributions[i].Normalized,
+		})
+	}
+	return trace
+}
 
-	// Log hasil defuzzifikasi
-	log.Infof("Hasil Defuzzifikasi: %s", finalResult)
+// explainInterval menjalankan inferensi pada model interval tipe-2: derajat
+// keanggotaan bawah dan atas dibawa melalui basis aturan sebagai interval
+// penyulutan, lalu direduksi dengan Karnik-Mendel menjadi interval skor.
+// Skor dan predikat diambil dari titik tengah interval tersebut.
+func explainInterval(m *model.Model, engine Engine, crisp map[string]float64, levels map[string]string, verbose bool) *Trace {
+	lower := make(map[string]fuzzifikasi.Memberships, len(m.Variables))
+	upper := make(map[string]fuzzifikasi.Memberships, len(m.Variables))
+	for _, v := range m.Variables {
+		lower[v.Name], upper[v.Name] = v.FuzzifyInterval(crisp[v.Name], levels[v.Name])
+		if verbose {
+			log.Infof("Fuzzifikasi %s: bawah %+v, atas %+v", v.Name, lower[v.Name], upper[v.Name])
+		}
+	}
+	firings := m.RuleBase.EvaluateInterval(lower, upper, crisp)
+	if verbose {
+		log.Infof("Hasil Aturan Fuzzy Tipe-2 (model versi %d, metode %s): %+v", m.Version, engine, firings)
+	}
+	result := engine.defuzzifyInterval(m, firings, crisp)
+	if verbose && result.Interval != nil {
+		log.Infof("Hasil Reduksi Tipe %s: %s (skor %f, interval [%f, %f])", engine, result.Predicate, result.Score, result.Interval.Lower, result.Interval.Upper)
+	}
+
+	trace := newTrace(m, engine, crisp, upper, result)
+	trace.LowerMemberships = lower
+	trace.Uncertainty = result.Interval
+	for i, firing := range firings {
+		trace.Rules = append(trace.Rules, RuleTrace{
+			Rule:       firing.Rule,
+			Predicate:  firing.Predicate,
+			Strength:   firing.Strength,
+			Lower:      firing.Lower,
+			Upper:      firing.Upper,
+			Output:     result.Contributions[i].Z,
+			Normalized: result.Contributions[i].Normalized,
+		})
+	}
+	return trace
+}
 
-	return finalResult
+func newTrace(m *model.Model, engine Engine, crisp map[string]float64, fuzzy map[string]fuzzifikasi.Memberships, result defuzzifikasi.Result) *Trace {
+	trace := &Trace{
+		ModelVersion: m.Version,
+		Engine:       engine,
+		Defuzzifier:  defuzzifikasi.DefuzzifierWeightedAverage,
+		Inputs:       crisp,
+		Memberships:  fuzzy,
+		Score:        result.Score,
+		Band:         result.Band,
+		Predicate:    result.Predicate,
+	}
+	if engine.aggregates() {
+		trace.Defuzzifier = defuzzifikasi.DefuzzifierCentroid
+	}
+	return trace
 }
@@ -0,0 +1,154 @@
+package inferensia
+
+import (
+	"testing"
+
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTsukamotoExplain(t *testing.T) {
+	trace := TsukamotoExplain(model.Default(), 3.95, 7, 0, 1, "internasional", 5, "internasional", 8)
+
+	assert.Equal(t, TsukamotoInference(3.95, 7, 0, 1, "internasional", 5, "internasional", 8), trace.Predicate)
+	assert.Equal(t, trace.Predicate, trace.Band.Predicate)
+	assert.GreaterOrEqual(t, trace.Score, trace.Band.MinScore)
+	assert.Contains(t, trace.Memberships, "ipk")
+	assert.Equal(t, 3.95, trace.Inputs["ipk"])
+
+	require.NotEmpty(t, trace.Rules)
+	total := 0.0
+	for _, rule := range trace.Rules {
+		total += rule.Normalized
+	}
+	assert.InDelta(t, 1, total, 1e-9)
+}
+
+func TestTsukamotoInference(t *testing.T) {
+	assert.Equal(t, "Memuaskan", TsukamotoInference(1.5, 14, 15, 0, "", 0, "", 0))
+}
+
+func TestExplainEngines(t *testing.T) {
+	m := model.Default()
+	for _, engine := range Engines {
+		t.Run(string(engine), func(t *testing.T) {
+			trace := Explain(m, engine, 3.6, 8, 1, 3, "nasional", 3, "nasional", 3)
+			assert.Equal(t, engine, trace.Engine)
+			assert.Equal(t, trace.Predicate, trace.Band.Predicate)
+			assert.GreaterOrEqual(t, trace.Score, trace.Band.MinScore)
+			require.NotEmpty(t, trace.Rules)
+		})
+	}
+
+	assert.Equal(t, MamdaniExplain(m, 3.6, 8, 1, 3, "nasional", 3, "nasional", 3).Predicate, MamdaniInference(3.6, 8, 1, 3, "nasional", 3, "nasional", 3))
+}
+
+func TestParseEngine(t *testing.T) {
+	engine, err := ParseEngine("Mamdani")
+	assert.NoError(t, err)
+	assert.Equal(t, EngineMamdani, engine)
+
+	engine, err = ParseEngine("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultEngine(), engine)
+
+	_, err = ParseEngine("unknown")
+	assert.ErrorIs(t, err, ErrUnknownEngine)
+}
+
+func TestSugeno(t *testing.T) {
+	m := model.Default()
+
+	t.Run("Orde nol memakai titik tengah himpunan keluaran", func(t *testing.T) {
+		trace := SugenoExplain(m, 3.6, 8, 1, 3, "nasional", 3, "nasional", 3)
+		for _, rule := range trace.Rules {
+			assert.Equal(t, m.Output.Sets[rule.Predicate].Center(), rule.Output)
+		}
+		assert.Equal(t, trace.Predicate, SugenoInference(3.6, 8, 1, 3, "nasional", 3, "nasional", 3))
+	})
+
+	t.Run("Orde satu", func(t *testing.T) {
+		// Salin aturan dan fallback agar basis aturan aktif tidak ikut berubah
+		def := m.Definition()
+		def.Rules = append([]rules.RuleSpec(nil), def.Rules...)
+		for i := range def.Rules {
+			def.Rules[i].Output = &rules.Consequent{Constant: 0.5, Coefficients: map[string]float64{"ipk": 0.8}}
+		}
+		spec := *def.Fallback
+		spec.Output = &rules.Consequent{Constant: 1}
+		def.Fallback = &spec
+		linear, err := model.Compile(def)
+		require.NoError(t, err)
+
+		// Semua aturan menghasilkan 0.5 + 0.8*ipk sehingga skor hanya
+		// bergeser karena porsi aturan fallback (z = 1)
+		trace := SugenoExplain(linear, 3.5, 8, 0, 0, "", 1, "", 0)
+		fallback := trace.Rules[len(trace.Rules)-1]
+		expected := (1-fallback.Normalized)*(0.5+0.8*3.5) + fallback.Normalized*1
+		assert.InDelta(t, expected, trace.Score, 1e-9)
+		assert.Equal(t, EngineSugeno, trace.Engine)
+	})
+}
+
+func TestModelDefuzzifier(t *testing.T) {
+	def := model.Default().Definition()
+	scores := map[string]float64{}
+	for _, name := range []string{"centroid", "som", "lom"} {
+		def.Defuzzifier = name
+		m, err := model.Compile(def)
+		require.NoError(t, err)
+
+		trace := MamdaniExplain(m, 3.6, 8, 1, 3, "nasional", 3, "nasional", 3)
+		assert.Equal(t, name, trace.Defuzzifier)
+		scores[name] = trace.Score
+
+		// Tsukamoto tidak mengagregasi himpunan sehingga tidak terpengaruh
+		tsukamoto := TsukamotoExplain(m, 3.6, 8, 1, 3, "nasional", 3, "nasional", 3)
+		assert.Equal(t, "weighted_average", tsukamoto.Defuzzifier)
+	}
+	assert.LessOrEqual(t, scores["som"], scores["lom"])
+}
+
+func TestTypeTwo(t *testing.T) {
+	def := model.Default().Definition()
+	require.Nil(t, Explain(model.Default(), EngineTsukamoto, 3.5, 8, 1, 3, "nasional", 3, "nasional", 3).Uncertainty)
+
+	// Fungsi bawah yang sama dengan fungsi atas tidak mengubah hasil
+	tinggi := &def.Variables[0].Terms[3]
+	tinggi.Lower = &model.FunctionSpec{Type: tinggi.Type, Params: tinggi.Params}
+	same, err := model.Compile(def)
+	require.NoError(t, err)
+	for _, engine := range []Engine{EngineTsukamoto, EngineSugeno} {
+		expected := Explain(model.Default(), engine, 3.5, 8, 1, 3, "nasional", 3, "nasional", 3)
+		trace := Explain(same, engine, 3.5, 8, 1, 3, "nasional", 3, "nasional", 3)
+		require.NotNil(t, trace.Uncertainty)
+		assert.InDelta(t, expected.Score, trace.Score, 1e-9)
+		assert.InDelta(t, trace.Uncertainty.Lower, trace.Uncertainty.Upper, 1e-9)
+	}
+
+	// Batas bawah IPK "SangatTinggi" diperdebatkan antara 3.5 dan 3.75
+	tinggi.Lower = nil
+	def.Variables[0].Terms[4].Lower = &model.FunctionSpec{Type: "piecewise", Params: []float64{3.75, 0, 4, 1}}
+	m, err := model.Compile(def)
+	require.NoError(t, err)
+	for _, engine := range Engines {
+		t.Run(string(engine), func(t *testing.T) {
+			trace := Explain(m, engine, 3.9, 7, 0, 1, "internasional", 5, "internasional", 8)
+			require.NotNil(t, trace.Uncertainty)
+			assert.Less(t, trace.Uncertainty.Lower, trace.Uncertainty.Upper)
+			assert.InDelta(t, (trace.Uncertainty.Lower+trace.Uncertainty.Upper)/2, trace.Score, 1e-9)
+			assert.Equal(t, m.Output.Category(trace.Score), trace.Predicate)
+			assert.Less(t, trace.LowerMemberships["ipk"]["SangatTinggi"], trace.Memberships["ipk"]["SangatTinggi"])
+			for _, rule := range trace.Rules {
+				assert.LessOrEqual(t, rule.Lower, rule.Upper)
+			}
+
+			// Di luar wilayah ketidakpastian interval tidak melebar
+			outside := Explain(m, engine, 2.5, 8, 1, 3, "nasional", 3, "nasional", 3)
+			assert.InDelta(t, outside.Uncertainty.Lower, outside.Uncertainty.Upper, 1e-6)
+		})
+	}
+}
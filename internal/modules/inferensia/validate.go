@@ -0,0 +1,103 @@
+package inferensia
+
+import (
+	"fmt"
+	"strings"
+
+	"go-tsukamoto/internal/modules/model"
+)
+
+// ErrOutOfUniverse dikembalikan jika nilai tegas sebuah variabel berada di
+// luar semesta [Min, Max]
+type ErrOutOfUniverse struct {
+	Variable string
+	Value    float64
+	Min      float64
+	Max      float64
+}
+
+func (e *ErrOutOfUniverse) Error() string {
+	return fmt.Sprintf("%s %g is outside the universe [%g, %g]", e.Variable, e.Value, e.Min, e.Max)
+}
+
+// ErrInvalidLevel dikembalikan jika level kategoris sebuah variabel tidak
+// dikenal; Levels kosong berarti variabel tidak menerima level
+type ErrInvalidLevel struct {
+	Variable string
+	Level    string
+	Levels   []string
+}
+
+func (e *ErrInvalidLevel) Error() string {
+	if len(e.Levels) == 0 {
+		return fmt.Sprintf("%s does not take a level, got %q", e.Variable, e.Level)
+	}
+	return fmt.Sprintf("%s level %q is not one of %s", e.Variable, e.Level, strings.Join(e.Levels, ", "))
+}
+
+// ValidationError menghimpun seluruh pelanggaran domain pada satu masukan.
+// Setiap pelanggaran dapat diperiksa dengan errors.As.
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid fuzzy input: %s", strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// Validate memeriksa masukan terhadap domain variabel model: nilai tegas harus
+// berada di semesta variabel dan level harus salah satu prasyarat himpunannya
+// (tanpa membedakan huruf besar-kecil). Nilai di atas Max variabel dengan
+// OpenMax tetap sah; Evaluate memotongnya ke Max. Variabel kategoris tanpa
+// level berarti mahasiswa tidak memiliki data tersebut, sehingga nilainya
+// tidak diperiksa. Variabel yang tidak ada pada crisp dilewati.
+func Validate(m *model.Model, crisp map[string]float64, levels map[string]string) error {
+	var errs []error
+	for _, v := range m.Variables {
+		level := levels[v.Name]
+		if level != "" && !v.AcceptsLevel(level) {
+			errs = append(errs, &ErrInvalidLevel{Variable: v.Name, Level: level, Levels: v.Levels()})
+		}
+
+		value, ok := crisp[v.Name]
+		if !ok || (level == "" && v.Categorical()) {
+			continue
+		}
+		// Ditulis sebagai negasi agar NaN juga ditolak
+		if !(value >= v.Min && (value <= v.Max || v.OpenMax)) {
+			errs = append(errs, &ErrOutOfUniverse{Variable: v.Name, Value: value, Min: v.Min, Max: v.Max})
+		}
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// clamp memotong nilai di atas Max variabel dengan OpenMax ke Max. Peta asal
+// tidak diubah; salinan hanya dibuat jika ada nilai yang dipotong.
+func clamp(m *model.Model, crisp map[string]float64) map[string]float64 {
+	clamped, copied := crisp, false
+	for _, v := range m.Variables {
+		value, ok := crisp[v.Name]
+		if !ok || !v.OpenMax || value <= v.Max {
+			continue
+		}
+		if !copied {
+			clamped, copied = make(map[string]float64, len(crisp)), true
+			for name, value := range crisp {
+				clamped[name] = value
+			}
+		}
+		clamped[v.Name] = v.Max
+	}
+	return clamped
+}
@@ -0,0 +1,119 @@
+package inferensia
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"go-tsukamoto/internal/modules/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	m := model.Default()
+	valid := func() (map[string]float64, map[string]string) {
+		return profile.Inputs()
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		crisp, levels := valid()
+		assert.NoError(t, Validate(m, crisp, levels))
+
+		// Tanpa prestasi: peringkat 0 tanpa level tidak diperiksa
+		crisp["achievement"], levels["achievement"] = 0, ""
+		// Level tidak membedakan huruf besar-kecil
+		levels["thesis"] = "Nasional"
+		assert.NoError(t, Validate(m, crisp, levels))
+	})
+
+	t.Run("Out Of Universe", func(t *testing.T) {
+		crisp, levels := valid()
+		crisp["ipk"] = 5.2
+		crisp["studyDuration"] = 0
+		crisp["activity"] = math.NaN()
+
+		err := Validate(m, crisp, levels)
+		var invalid *ValidationError
+		require.ErrorAs(t, err, &invalid)
+		require.Len(t, invalid.Errors, 3)
+		assert.Equal(t, &ErrOutOfUniverse{Variable: "ipk", Value: 5.2, Min: 0, Max: 4}, invalid.Errors[0])
+		assert.Equal(t, &ErrOutOfUniverse{Variable: "studyDuration", Value: 0, Min: 1, Max: 14}, invalid.Errors[1])
+		assert.EqualError(t, invalid.Errors[2], "activity NaN is outside the universe [0, 20]")
+
+		var outOfUniverse *ErrOutOfUniverse
+		require.ErrorAs(t, err, &outOfUniverse)
+		assert.Equal(t, "ipk", outOfUniverse.Variable)
+	})
+
+	t.Run("Open Upper Bound", func(t *testing.T) {
+		// Jumlah aktivitas dan peringkat tidak memiliki batas atas alami
+		crisp, levels := valid()
+		crisp["activity"] = 25
+		crisp["achievement"], levels["achievement"] = 30, "internal"
+		require.NoError(t, Validate(m, crisp, levels))
+
+		saturated, _ := valid()
+		saturated["activity"] = 20
+		saturated["achievement"] = 20
+		trace := Evaluate(m, crisp, levels)
+		require.NoError(t, trace.Err)
+		expected := Evaluate(m, saturated, levels)
+		assert.Equal(t, expected.Predicate, trace.Predicate)
+		assert.Equal(t, expected.Score, trace.Score)
+		assert.Equal(t, 20.0, trace.Inputs["activity"])
+		assert.Equal(t, 25.0, crisp["activity"], "masukan pemanggil tidak diubah")
+
+		// Batas bawah dan IPK tetap tertutup
+		crisp["activity"] = -1
+		crisp["ipk"] = 4.01
+		err := Validate(m, crisp, levels)
+		var invalid *ValidationError
+		require.ErrorAs(t, err, &invalid)
+		require.Len(t, invalid.Errors, 2)
+		assert.Equal(t, &ErrOutOfUniverse{Variable: "ipk", Value: 4.01, Min: 0, Max: 4}, invalid.Errors[0])
+		assert.Equal(t, &ErrOutOfUniverse{Variable: "activity", Value: -1, Min: 0, Max: 20}, invalid.Errors[1])
+	})
+
+	t.Run("Invalid Level", func(t *testing.T) {
+		crisp, levels := valid()
+		levels["achievement"] = "regional"
+		levels["ipk"] = "nasional"
+		crisp["achievement"] = 0
+
+		err := Validate(m, crisp, levels)
+		assert.EqualError(t, err, `invalid fuzzy input: ipk does not take a level, got "nasional"; `+
+			`achievement level "regional" is not one of internasional, nasional, internal; achievement 0 is outside the universe [1, 20]`)
+		var invalidLevel *ErrInvalidLevel
+		require.ErrorAs(t, err, &invalidLevel)
+		assert.Equal(t, "ipk", invalidLevel.Variable)
+	})
+
+	t.Run("Evaluate", func(t *testing.T) {
+		crisp, levels := valid()
+		crisp["ipk"] = 5.2
+		for _, engine := range Engines {
+			trace := EvaluateEngine(m, engine, crisp, levels)
+			var outOfUniverse *ErrOutOfUniverse
+			require.ErrorAs(t, trace.Err, &outOfUniverse, engine)
+			assert.Empty(t, trace.Predicate)
+			assert.Empty(t, trace.Rules)
+		}
+
+		assert.Empty(t, TsukamotoInference(3.6, 8, 1, 3, "regional", 3, "nasional", 3))
+		assert.Error(t, Explain(m, EngineMamdani, 3.6, 0, 1, 3, "nasional", 3, "nasional", 3).Err)
+		crisp, levels = valid()
+		assert.NoError(t, Evaluate(m, crisp, levels).Err)
+	})
+
+	t.Run("Infer", func(t *testing.T) {
+		bad := profile
+		bad.IPK = -0.5
+		result, err := Infer(context.Background(), bad)
+		assert.Nil(t, result)
+		var outOfUniverse *ErrOutOfUniverse
+		require.ErrorAs(t, err, &outOfUniverse)
+		assert.Equal(t, -0.5, outOfUniverse.Value)
+	})
+}
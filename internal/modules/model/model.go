@@ -0,0 +1,261 @@
+package model
+
+import (
+	"fmt"
+
+	"go-tsukamoto/internal/modules/defuzzifikasi"
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+	"go-tsukamoto/internal/modules/rules"
+	"go-tsukamoto/internal/modules/utils"
+)
+
+// TermSpec adalah definisi satu himpunan fuzzy. Lower menjadikannya
+// himpunan interval tipe-2: Type dan Params adalah fungsi keanggotaan atas,
+// Lower fungsi keanggotaan bawah yang tidak boleh melebihinya.
+type TermSpec struct {
+	Name   string        `yaml:"name" json:"name"`
+	Type   string        `yaml:"type" json:"type"`
+	Params []float64     `yaml:"params" json:"params"`
+	Lower  *FunctionSpec `yaml:"lower,omitempty" json:"lower,omitempty"`
+	Levels []string      `yaml:"levels,omitempty" json:"levels,omitempty"`
+}
+
+// FunctionSpec adalah jenis dan parameter sebuah fungsi keanggotaan
+type FunctionSpec struct {
+	Type   string    `yaml:"type" json:"type"`
+	Params []float64 `yaml:"params" json:"params"`
+}
+
+// VariableSpec adalah definisi satu variabel linguistik masukan. OpenMax
+// menerima nilai di atas Max dan memotongnya ke Max, untuk jumlah yang tidak
+// memiliki batas atas alami.
+type VariableSpec struct {
+	Name    string     `yaml:"name" json:"name"`
+	Min     float64    `yaml:"min" json:"min"`
+	Max     float64    `yaml:"max" json:"max"`
+	OpenMax bool       `yaml:"open_max,omitempty" json:"open_max,omitempty"`
+	Terms   []TermSpec `yaml:"terms" json:"terms"`
+}
+
+// OutputSpec adalah himpunan keluaran monoton dan pita skor [MinScore,
+// MaxScore) sebuah predikat. MaxScore boleh dikosongkan; nilainya diambil
+// dari skor minimum pita di atasnya.
+type OutputSpec struct {
+	Predicate  string  `yaml:"predicate" json:"predicate"`
+	Low        float64 `yaml:"low" json:"low"`
+	High       float64 `yaml:"high" json:"high"`
+	Increasing bool    `yaml:"increasing" json:"increasing"`
+	MinScore   float64 `yaml:"min_score" json:"min_score"`
+	MaxScore   float64 `yaml:"max_score,omitempty" json:"max_score,omitempty"`
+}
+
+// Definition adalah definisi lengkap model fuzzy: variabel, himpunan, aturan,
+// bobot faktor, variabel keluaran dan metode defuzzifikasi Mamdani
+type Definition struct {
+	Variables          []VariableSpec `yaml:"variables" json:"variables"`
+	rules.RuleBaseSpec `yaml:",inline"`
+	Outputs            []OutputSpec `yaml:"outputs" json:"outputs"`
+	Defuzzifier        string       `yaml:"defuzzifier,omitempty" json:"defuzzifier,omitempty"`
+}
+
+// Model adalah model fuzzy yang siap dijalankan. Defuzzifier dipakai metode
+// inferensi yang mengagregasi himpunan keluaran (Mamdani); Tsukamoto dan
+// Sugeno selalu memakai rata-rata terbobot.
+type Model struct {
+	Version     int
+	Variables   []*fuzzifikasi.LinguisticVariable
+	RuleBase    *rules.RuleBase
+	Output      *defuzzifikasi.Output
+	Defuzzifier defuzzifikasi.Defuzzifier
+}
+
+// Default mengembalikan model bawaan: variabel pada paket fuzzifikasi,
+// basis aturan aktif dan variabel keluaran bawaan
+func Default() *Model {
+	return &Model{
+		Variables:   fuzzifikasi.Variables,
+		RuleBase:    rules.Active(),
+		Output:      defuzzifikasi.DefaultOutput,
+		Defuzzifier: defuzzifikasi.Centroid{},
+	}
+}
+
+// Compile membangun model dari definisi dan mengembalikan kesalahan pertama
+func Compile(def Definition) (*Model, error) {
+	m, problems := compile(def)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s", problems[0])
+	}
+	return m, nil
+}
+
+// Validate mengembalikan seluruh masalah pada definisi; kosong jika valid
+func Validate(def Definition) []string {
+	_, problems := compile(def)
+	return problems
+}
+
+func compile(def Definition) (*Model, []string) {
+	var problems []string
+	m := &Model{}
+
+	if len(def.Variables) == 0 {
+		problems = append(problems, "model has no variables")
+	}
+	seen := map[string]bool{}
+	for _, vs := range def.Variables {
+		if seen[vs.Name] {
+			problems = append(problems, fmt.Sprintf("variable %q is defined more than once", vs.Name))
+			continue
+		}
+		seen[vs.Name] = true
+		v, err := compileVariable(vs)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		m.Variables = append(m.Variables, v)
+	}
+
+	output, err := compileOutput(def.Outputs)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("outputs: %v", err))
+	} else {
+		m.Output = output
+	}
+
+	defuzzifier, err := defuzzifikasi.NewDefuzzifier(def.Defuzzifier)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	m.Defuzzifier = defuzzifier
+
+	if len(problems) == 0 {
+		rb, err := rules.CompileWith(def.RuleBaseSpec, m.Variables, output.Predicates())
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+		m.RuleBase = rb
+	}
+	return m, problems
+}
+
+func compileVariable(vs VariableSpec) (*fuzzifikasi.LinguisticVariable, error) {
+	if vs.Name == "" {
+		return nil, fmt.Errorf("variable name is required")
+	}
+	if vs.Min >= vs.Max {
+		return nil, fmt.Errorf("variable %s: universe needs min < max", vs.Name)
+	}
+	if len(vs.Terms) == 0 {
+		return nil, fmt.Errorf("variable %s has no terms", vs.Name)
+	}
+	v := &fuzzifikasi.LinguisticVariable{Name: vs.Name, Min: vs.Min, Max: vs.Max, OpenMax: vs.OpenMax}
+	for _, ts := range vs.Terms {
+		if ts.Name == "" {
+			return nil, fmt.Errorf("variable %s: term name is required", vs.Name)
+		}
+		mf, err := utils.NewMembershipFunction(ts.Type, ts.Params)
+		if err != nil {
+			return nil, fmt.Errorf("variable %s term %s: %v", vs.Name, ts.Name, err)
+		}
+		term := fuzzifikasi.Term{Name: ts.Name, Function: mf, Levels: ts.Levels}
+		if ts.Lower != nil {
+			if term.Lower, err = compileLower(vs, ts, mf); err != nil {
+				return nil, fmt.Errorf("variable %s term %s: %v", vs.Name, ts.Name, err)
+			}
+		}
+		v.Terms = append(v.Terms, term)
+	}
+	return v, nil
+}
+
+// lowerSamples adalah jumlah selang pada semesta variabel yang diperiksa
+// untuk memastikan fungsi bawah tidak melebihi fungsi atas
+const lowerSamples = 1000
+
+// compileLower membangun fungsi keanggotaan bawah himpunan interval tipe-2
+func compileLower(vs VariableSpec, ts TermSpec, upper utils.MembershipFunction) (utils.MembershipFunction, error) {
+	lower, err := utils.NewMembershipFunction(ts.Lower.Type, ts.Lower.Params)
+	if err != nil {
+		return nil, fmt.Errorf("lower: %v", err)
+	}
+	step := (vs.Max - vs.Min) / lowerSamples
+	for k := 0; k <= lowerSamples; k++ {
+		x := vs.Min + step*float64(k)
+		if lower.Degree(x) > upper.Degree(x)+1e-9 {
+			return nil, fmt.Errorf("lower membership exceeds upper membership at %g", x)
+		}
+	}
+	return lower, nil
+}
+
+func compileOutput(specs []OutputSpec) (*defuzzifikasi.Output, error) {
+	sets := make(map[string]defuzzifikasi.OutputSet, len(specs))
+	bands := make([]defuzzifikasi.Band, 0, len(specs))
+	for _, os := range specs {
+		if _, ok := sets[os.Predicate]; ok {
+			return nil, fmt.Errorf("predicate %q is defined more than once", os.Predicate)
+		}
+		sets[os.Predicate] = defuzzifikasi.OutputSet{Low: os.Low, High: os.High, Increasing: os.Increasing}
+		bands = append(bands, defuzzifikasi.Band{Predicate: os.Predicate, MinScore: os.MinScore, MaxScore: os.MaxScore})
+	}
+	return defuzzifikasi.NewOutput(sets, bands)
+}
+
+// TypeTwo menandakan model memiliki himpunan interval tipe-2 sehingga
+// inferensi menyebarkan footprint of uncertainty hingga skor akhir
+func (m *Model) TypeTwo() bool {
+	for _, v := range m.Variables {
+		if v.TypeTwo() {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup mencari variabel linguistik model berdasarkan namanya
+func (m *Model) Lookup(name string) (*fuzzifikasi.LinguisticVariable, bool) {
+	for _, v := range m.Variables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Definition menguraikan model kembali menjadi definisinya
+func (m *Model) Definition() Definition {
+	def := Definition{RuleBaseSpec: m.RuleBase.Spec}
+	if m.Defuzzifier != nil {
+		def.Defuzzifier = m.Defuzzifier.Name()
+	}
+	for _, v := range m.Variables {
+		vs := VariableSpec{Name: v.Name, Min: v.Min, Max: v.Max, OpenMax: v.OpenMax}
+		for _, term := range v.Terms {
+			ts := TermSpec{
+				Name:   term.Name,
+				Type:   term.Function.Kind(),
+				Params: term.Function.Params(),
+				Levels: term.Levels,
+			}
+			if term.Lower != nil {
+				ts.Lower = &FunctionSpec{Type: term.Lower.Kind(), Params: term.Lower.Params()}
+			}
+			vs.Terms = append(vs.Terms, ts)
+		}
+		def.Variables = append(def.Variables, vs)
+	}
+	for _, band := range m.Output.Bands {
+		set := m.Output.Sets[band.Predicate]
+		def.Outputs = append(def.Outputs, OutputSpec{
+			Predicate:  band.Predicate,
+			Low:        set.Low,
+			High:       set.High,
+			Increasing: set.Increasing,
+			MinScore:   band.MinScore,
+			MaxScore:   band.MaxScore,
+		})
+	}
+	return def
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinitionRoundTrip(t *testing.T) {
+	def := Default().Definition()
+
+	m, err := Compile(def)
+	require.NoError(t, err)
+	assert.Equal(t, def, m.Definition())
+	assert.Len(t, m.Variables, len(Default().Variables))
+	assert.Equal(t, Default().Output.Bands, m.Output.Bands)
+}
+
+func TestDefuzzifier(t *testing.T) {
+	def := Default().Definition()
+	assert.Equal(t, "centroid", def.Defuzzifier)
+
+	def.Defuzzifier = "bisector"
+	m, err := Compile(def)
+	require.NoError(t, err)
+	assert.Equal(t, "bisector", m.Defuzzifier.Name())
+	assert.Equal(t, def, m.Definition())
+
+	// Definisi lama tanpa defuzzifier memakai centroid
+	def.Defuzzifier = ""
+	m, err = Compile(def)
+	require.NoError(t, err)
+	assert.Equal(t, "centroid", m.Defuzzifier.Name())
+}
+
+func TestTypeTwo(t *testing.T) {
+	def := Default().Definition()
+	assert.False(t, Default().TypeTwo())
+
+	// IPK "Tinggi" dimulai dari 3.0 menurut sebagian anggota komisi dan dari
+	// 3.25 menurut yang lain
+	def.Variables[0].Terms[3].Lower = &FunctionSpec{Type: "piecewise", Params: []float64{3.25, 0, 3.75, 1, 3.75, 0}}
+	m, err := Compile(def)
+	require.NoError(t, err)
+	assert.True(t, m.TypeTwo())
+	assert.Equal(t, def, m.Definition())
+
+	lower, upper := m.Variables[0].FuzzifyInterval(3.5, "")
+	assert.InDelta(t, 0.5, lower["Tinggi"], 1e-9)
+	assert.InDelta(t, 2.0/3, upper["Tinggi"], 1e-9)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(def *Definition)
+	}{
+		{"Tanpa variabel", func(def *Definition) { def.Variables = nil }},
+		{"Variabel ganda", func(def *Definition) { def.Variables = append(def.Variables, def.Variables[0]) }},
+		{"Semesta terbalik", func(def *Definition) { def.Variables[0].Min = 5 }},
+		{"Fungsi keanggotaan tidak valid", func(def *Definition) { def.Variables[0].Terms[0].Params = []float64{1} }},
+		{"Keluaran tanpa pita", func(def *Definition) { def.Outputs = nil }},
+		{"Pita tidak terurut", func(def *Definition) { def.Outputs[0].MinScore = 0.5 }},
+		{"Celah antarpita", func(def *Definition) { def.Outputs[1].MaxScore = 3.5 }},
+		{"Pita tumpang tindih", func(def *Definition) { def.Outputs[2].MaxScore = 3.5 }},
+		{"Defuzzifier tidak dikenal", func(def *Definition) { def.Defuzzifier = "median" }},
+		{"Fungsi bawah tidak valid", func(def *Definition) {
+			def.Variables[0].Terms[0].Lower = &FunctionSpec{Type: "triangular", Params: []float64{1}}
+		}},
+		{"Fungsi bawah melebihi fungsi atas", func(def *Definition) {
+			def.Variables[0].Terms[3].Lower = &FunctionSpec{Type: "triangular", Params: []float64{2.9, 3.75, 4}}
+		}},
+		{"Aturan merujuk predikat tidak dikenal", func(def *Definition) { def.Outputs = def.Outputs[:5]; def.Outputs[4].MinScore = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := Default().Definition()
+			tt.mutate(&def)
+			assert.NotEmpty(t, Validate(def))
+			_, err := Compile(def)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package pelatihan
+
+import "sort"
+
+// Koefisien Nelder-Mead baku: refleksi, ekspansi, kontraksi dan penyusutan
+const (
+	reflection  = 1.0
+	expansion   = 2.0
+	contraction = 0.5
+	shrink      = 0.5
+)
+
+// minimize mencari titik dengan nilai loss terkecil menggunakan metode
+// simpleks Nelder-Mead yang tidak membutuhkan turunan. Simpleks awal dibentuk
+// dari start dengan langkah steps pada setiap koordinat. Pencarian berhenti
+// setelah maxEvaluations evaluasi atau jika selisih loss pada simpleks di
+// bawah tolerance. Mengembalikan titik terbaik, loss-nya dan jumlah evaluasi.
+func minimize(loss func([]float64) float64, start, steps []float64, maxEvaluations int, tolerance float64) ([]float64, float64, int) {
+	n := len(start)
+	evaluations := 0
+	evaluate := func(x []float64) float64 {
+		evaluations++
+		return loss(x)
+	}
+
+	type vertex struct {
+		x     []float64
+		value float64
+	}
+	simplex := make([]vertex, n+1)
+	simplex[0] = vertex{x: copyVector(start), value: evaluate(start)}
+	for i := 0; i < n; i++ {
+		x := copyVector(start)
+		x[i] += steps[i]
+		simplex[i+1] = vertex{x: x, value: evaluate(x)}
+	}
+
+	for evaluations < maxEvaluations {
+		sort.SliceStable(simplex, func(i, j int) bool { return simplex[i].value < simplex[j].value })
+		best, worst := simplex[0], simplex[n]
+		if worst.value-best.value <= tolerance {
+			break
+		}
+
+		// Titik berat semua titik kecuali yang terburuk
+		centroid := make([]float64, n)
+		for _, v := range simplex[:n] {
+			for i := range centroid {
+				centroid[i] += v.x[i] / float64(n)
+			}
+		}
+
+		reflected := towards(centroid, worst.x, -reflection)
+		reflectedValue := evaluate(reflected)
+		switch {
+		case reflectedValue < best.value:
+			expanded := towards(centroid, worst.x, -expansion)
+			if expandedValue := evaluate(expanded); expandedValue < reflectedValue {
+				simplex[n] = vertex{x: expanded, value: expandedValue}
+			} else {
+				simplex[n] = vertex{x: reflected, value: reflectedValue}
+			}
+		case reflectedValue < simplex[n-1].value:
+			simplex[n] = vertex{x: reflected, value: reflectedValue}
+		default:
+			contracted := towards(centroid, worst.x, contraction)
+			if contractedValue := evaluate(contracted); contractedValue < worst.value {
+				simplex[n] = vertex{x: contracted, value: contractedValue}
+				continue
+			}
+			for i := 1; i <= n; i++ {
+				x := towards(best.x, simplex[i].x, shrink)
+				simplex[i] = vertex{x: x, value: evaluate(x)}
+			}
+		}
+	}
+
+	sort.SliceStable(simplex, func(i, j int) bool { return simplex[i].value < simplex[j].value })
+	return simplex[0].x, simplex[0].value, evaluations
+}
+
+// towards mengembalikan from + t·(to − from)
+func towards(from, to []float64, t float64) []float64 {
+	x := make([]float64, len(from))
+	for i := range x {
+		x[i] = from[i] + t*(to[i]-from[i])
+	}
+	return x
+}
+
+func copyVector(x []float64) []float64 {
+	return append([]float64(nil), x...)
+}
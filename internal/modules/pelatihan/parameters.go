@@ -0,0 +1,235 @@
+package pelatihan
+
+import (
+	"math"
+	"sort"
+
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+	"go-tsukamoto/internal/modules/utils"
+)
+
+// Langkah simpleks awal relatif terhadap lebar semesta variabel (titik
+// potong) atau terhadap nilai parameter (lebar fungsi dan bobot)
+const (
+	positionStep = 0.1
+	scaleStep    = 0.2
+	minimumGap   = 1e-6
+)
+
+// parameter adalah satu koordinat vektor yang dioptimasi: titik potong atau
+// lebar fungsi keanggotaan pada sebuah himpunan, atau bobot faktor variabel
+type parameter struct {
+	variable int
+	term     int
+	index    int
+	kind     string
+	lo, hi   float64
+}
+
+const (
+	kindPosition = "position"
+	kindScale    = "scale"
+	kindSlope    = "slope"
+	kindWeight   = "weight"
+)
+
+// space memetakan definisi model ke vektor parameter dan sebaliknya
+type space struct {
+	base       model.Definition
+	parameters []parameter
+	weights    []string
+}
+
+// newSpace menyusun ruang parameter dari variabel yang dilatih. Bobot faktor
+// hanya dilatih jika operator AND adalah rata-rata terbobot, karena operator
+// lain tidak memakainya.
+func newSpace(def model.Definition, variables map[string]bool) *space {
+	s := &space{base: def}
+	for vi, vs := range def.Variables {
+		if len(variables) > 0 && !variables[vs.Name] {
+			continue
+		}
+		// Batas titik potong mencakup semesta dan parameter awal, karena bahu
+		// himpunan boleh berada di luar semesta
+		lo, hi := vs.Min, vs.Max
+		for _, ts := range vs.Terms {
+			for _, index := range positions(ts) {
+				lo, hi = math.Min(lo, ts.Params[index]), math.Max(hi, ts.Params[index])
+			}
+		}
+		for ti, ts := range vs.Terms {
+			for _, index := range positions(ts) {
+				s.parameters = append(s.parameters, parameter{variable: vi, term: ti, index: index, kind: kindPosition, lo: lo, hi: hi})
+			}
+			for _, index := range scales(ts) {
+				s.parameters = append(s.parameters, parameter{variable: vi, term: ti, index: index, kind: kindScale})
+			}
+			if ts.Type == utils.KindSigmoid {
+				s.parameters = append(s.parameters, parameter{variable: vi, term: ti, index: 0, kind: kindSlope})
+			}
+		}
+		if def.And == rules.TNormWeightedMean {
+			if _, ok := def.Weights[vs.Name]; ok {
+				s.weights = append(s.weights, vs.Name)
+				s.parameters = append(s.parameters, parameter{variable: vi, kind: kindWeight})
+			}
+		}
+	}
+	return s
+}
+
+// positions mengembalikan indeks parameter yang berupa titik pada semesta
+func positions(ts model.TermSpec) []int {
+	switch ts.Type {
+	case utils.KindTriangular, utils.KindTrapezoidal:
+		indexes := make([]int, len(ts.Params))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes
+	case utils.KindPiecewise:
+		var indexes []int
+		for i := 0; i < len(ts.Params); i += 2 {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	case utils.KindGaussian:
+		return []int{0}
+	case utils.KindBell:
+		return []int{2}
+	case utils.KindSigmoid:
+		return []int{1}
+	}
+	return nil
+}
+
+// scales mengembalikan indeks parameter lebar yang harus positif
+func scales(ts model.TermSpec) []int {
+	switch ts.Type {
+	case utils.KindGaussian:
+		return []int{1}
+	case utils.KindBell:
+		return []int{0, 1}
+	}
+	return nil
+}
+
+// start mengembalikan vektor awal dan langkah simpleks awalnya
+func (s *space) start() ([]float64, []float64) {
+	x := make([]float64, len(s.parameters))
+	steps := make([]float64, len(s.parameters))
+	for i, p := range s.parameters {
+		if p.kind == kindWeight {
+			x[i] = s.base.Weights[s.base.Variables[p.variable].Name]
+			steps[i] = math.Max(scaleStep*x[i], 0.05)
+			continue
+		}
+		x[i] = s.base.Variables[p.variable].Terms[p.term].Params[p.index]
+		switch p.kind {
+		case kindPosition:
+			steps[i] = positionStep * (p.hi - p.lo)
+		default:
+			steps[i] = scaleStep * math.Max(math.Abs(x[i]), minimumGap)
+		}
+	}
+	return x, steps
+}
+
+// definition membangun definisi dari vektor parameter. Setiap vektor
+// menghasilkan definisi yang valid: titik potong dibatasi pada semesta dan
+// diurutkan, lebar dibuat positif, tanda kemiringan sigmoid dipertahankan dan
+// bobot tidak negatif serta dinormalkan ke jumlah bobot awal.
+func (s *space) definition(x []float64) model.Definition {
+	def := s.base
+	def.Variables = make([]model.VariableSpec, len(s.base.Variables))
+	for vi, vs := range s.base.Variables {
+		terms := make([]model.TermSpec, len(vs.Terms))
+		for ti, ts := range vs.Terms {
+			ts.Params = append([]float64(nil), ts.Params...)
+			terms[ti] = ts
+		}
+		vs.Terms = terms
+		def.Variables[vi] = vs
+	}
+
+	weights := map[string]float64{}
+	for i, p := range s.parameters {
+		if p.kind == kindWeight {
+			weights[s.base.Variables[p.variable].Name] = math.Abs(x[i])
+			continue
+		}
+		params := def.Variables[p.variable].Terms[p.term].Params
+		switch p.kind {
+		case kindPosition:
+			params[p.index] = math.Max(p.lo, math.Min(p.hi, x[i]))
+		case kindScale:
+			params[p.index] = math.Max(math.Abs(x[i]), minimumGap)
+		case kindSlope:
+			original := s.base.Variables[p.variable].Terms[p.term].Params[p.index]
+			params[p.index] = math.Copysign(math.Max(math.Abs(x[i]), minimumGap), original)
+		}
+	}
+	for vi := range def.Variables {
+		for ti := range def.Variables[vi].Terms {
+			orderPositions(&def.Variables[vi].Terms[ti])
+		}
+	}
+
+	if len(s.weights) > 0 {
+		def.Weights = normalizeWeights(s.base.Weights, weights)
+	}
+	return def
+}
+
+// orderPositions mengurutkan titik potong sebuah himpunan; ujung
+// segitiga/trapesium dijaga agar tidak berimpit, begitu pula titik fungsi
+// linear sepotong-sepotong kecuali pasangan lompatan yang sudah ada
+func orderPositions(ts *model.TermSpec) {
+	indexes := positions(*ts)
+	if ts.Type != utils.KindTriangular && ts.Type != utils.KindTrapezoidal && ts.Type != utils.KindPiecewise {
+		return
+	}
+	values := make([]float64, len(indexes))
+	for i, index := range indexes {
+		values[i] = ts.Params[index]
+	}
+	sort.Float64s(values)
+	if ts.Type == utils.KindPiecewise {
+		for i := 1; i < len(values); i++ {
+			gap := minimumGap
+			if values[i] == values[i-1] && (i < 2 || values[i-1] != values[i-2]) {
+				gap = 0
+			}
+			values[i] = math.Max(values[i], values[i-1]+gap)
+		}
+	}
+	if last := len(values) - 1; values[last] <= values[0] {
+		values[last] = values[0] + minimumGap
+	}
+	for i, index := range indexes {
+		ts.Params[index] = values[i]
+	}
+}
+
+// normalizeWeights menyalin bobot awal, mengganti bobot yang dilatih dan
+// menormalkan jumlahnya agar sama dengan jumlah bobot awal
+func normalizeWeights(base, trained map[string]float64) map[string]float64 {
+	weights := map[string]float64{}
+	baseTotal, total := 0.0, 0.0
+	for name, weight := range base {
+		if value, ok := trained[name]; ok {
+			weight = value
+		}
+		weights[name] = weight
+		baseTotal += base[name]
+		total += weight
+	}
+	if total == 0 {
+		return base
+	}
+	for name := range weights {
+		weights[name] *= baseTotal / total
+	}
+	return weights
+}
@@ -0,0 +1,208 @@
+// Package pelatihan menyetel titik potong fungsi keanggotaan dan bobot
+// faktor model fuzzy dari data lulusan yang predikat resminya diketahui.
+// Karena predikat berupa kelas diskret, loss tidak dapat diturunkan sehingga
+// dipakai optimasi tanpa turunan (Nelder-Mead) dan k-fold cross-validation
+// untuk memperkirakan akurasi pada data baru.
+package pelatihan
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+)
+
+// Sample adalah satu lulusan berlabel: masukan tegas, level kategoris dan
+// predikat resmi yang diberikan
+type Sample struct {
+	Crisp     map[string]float64
+	Levels    map[string]string
+	Predicate string
+}
+
+// Options mengatur pelatihan. Variables membatasi variabel yang dilatih
+// (kosong berarti semua variabel); MaxEvaluations adalah batas evaluasi loss
+// untuk setiap optimasi, baik pada setiap fold maupun pada pelatihan akhir.
+type Options struct {
+	Folds          int
+	MaxEvaluations int
+	Variables      []string
+	Engine         inferensia.Engine
+	Seed           int64
+}
+
+// Fold adalah hasil satu fold cross-validation: akurasi model awal dan model
+// hasil pelatihan pada data uji fold tersebut
+type Fold struct {
+	Train            int
+	Test             int
+	TrainAccuracy    float64
+	TestAccuracy     float64
+	BaselineAccuracy float64
+}
+
+// Result adalah hasil pelatihan. Definition dilatih pada seluruh sampel;
+// CrossValidatedAccuracy dan BaselineCrossValidated adalah rata-rata akurasi
+// uji model terlatih dan model awal pada seluruh fold.
+type Result struct {
+	Definition             model.Definition
+	Parameters             int
+	Evaluations            int
+	BaselineAccuracy       float64
+	TrainAccuracy          float64
+	Folds                  []Fold
+	CrossValidatedAccuracy float64
+	BaselineCrossValidated float64
+}
+
+const (
+	defaultFolds          = 5
+	defaultMaxEvaluations = 1500
+	tolerance             = 1e-9
+
+	// bandPenalty membobot jarak skor ke pita predikat resmi. Jarak ini
+	// membuat loss tidak datar di antara dua kesalahan klasifikasi sehingga
+	// simpleks tetap mendapat arah; bobotnya kecil agar tidak mengalahkan
+	// tingkat kesalahan.
+	bandPenalty = 0.1
+)
+
+// Train menyetel definisi model dari sampel berlabel, menjalankan k-fold
+// cross-validation lalu melatih ulang pada seluruh sampel. Struktur model
+// (himpunan, aturan dan pita predikat) tidak berubah.
+func Train(def model.Definition, samples []Sample, opts Options) (*Result, error) {
+	if opts.Folds == 0 {
+		opts.Folds = defaultFolds
+	}
+	if opts.MaxEvaluations <= 0 {
+		opts.MaxEvaluations = defaultMaxEvaluations
+	}
+	if opts.Engine == "" {
+		opts.Engine = inferensia.DefaultEngine()
+	}
+	if opts.Folds < 2 {
+		return nil, fmt.Errorf("cross-validation needs at least 2 folds, got %d", opts.Folds)
+	}
+	if len(samples) < opts.Folds {
+		return nil, fmt.Errorf("need at least %d labeled samples for %d folds, got %d", opts.Folds, opts.Folds, len(samples))
+	}
+
+	base, err := model.Compile(def)
+	if err != nil {
+		return nil, err
+	}
+	for i, sample := range samples {
+		if _, ok := base.Output.Sets[sample.Predicate]; !ok {
+			return nil, fmt.Errorf("sample %d: unknown predicate %q", i+1, sample.Predicate)
+		}
+	}
+	variables := map[string]bool{}
+	for _, name := range opts.Variables {
+		found := false
+		for _, v := range base.Variables {
+			found = found || v.Name == name
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown variable %q", name)
+		}
+		variables[name] = true
+	}
+	s := newSpace(def, variables)
+	if len(s.parameters) == 0 {
+		return nil, fmt.Errorf("model has no trainable parameters")
+	}
+
+	result := &Result{Parameters: len(s.parameters)}
+	order := rand.New(rand.NewSource(opts.Seed)).Perm(len(samples))
+	for k := 0; k < opts.Folds; k++ {
+		var train, test []Sample
+		for i, index := range order {
+			if i%opts.Folds == k {
+				test = append(test, samples[index])
+			} else {
+				train = append(train, samples[index])
+			}
+		}
+		trained, evaluations := fit(s, train, opts)
+		result.Evaluations += evaluations
+		fold := Fold{
+			Train:            len(train),
+			Test:             len(test),
+			TrainAccuracy:    accuracy(trained, train, opts.Engine),
+			TestAccuracy:     accuracy(trained, test, opts.Engine),
+			BaselineAccuracy: accuracy(base, test, opts.Engine),
+		}
+		result.Folds = append(result.Folds, fold)
+		result.CrossValidatedAccuracy += fold.TestAccuracy / float64(opts.Folds)
+		result.BaselineCrossValidated += fold.BaselineAccuracy / float64(opts.Folds)
+	}
+
+	trained, evaluations := fit(s, samples, opts)
+	result.Evaluations += evaluations
+	result.Definition = trained.Definition()
+	result.BaselineAccuracy = accuracy(base, samples, opts.Engine)
+	result.TrainAccuracy = accuracy(trained, samples, opts.Engine)
+	return result, nil
+}
+
+// fit mengoptimasi parameter pada sampel latih dan mengembalikan model
+// terbaik. Model awal dipertahankan jika optimasi tidak memperbaikinya.
+func fit(s *space, samples []Sample, opts Options) (*model.Model, int) {
+	loss := func(x []float64) float64 {
+		m, err := model.Compile(s.definition(x))
+		if err != nil {
+			return math.Inf(1)
+		}
+		return lossOf(m, samples, opts.Engine)
+	}
+	start, steps := s.start()
+	best, _, evaluations := minimize(loss, start, steps, opts.MaxEvaluations, tolerance)
+	m, err := model.Compile(s.definition(best))
+	if err != nil {
+		m, _ = model.Compile(s.base)
+	}
+	return m, evaluations
+}
+
+// lossOf adalah tingkat kesalahan klasifikasi ditambah rata-rata jarak skor
+// ke pita predikat resmi, dinormalkan dengan lebar semesta keluaran
+func lossOf(m *model.Model, samples []Sample, engine inferensia.Engine) float64 {
+	lo, hi := m.Output.Universe()
+	errors, distance := 0.0, 0.0
+	for _, sample := range samples {
+		trace := inferensia.EvaluateEngine(m, engine, sample.Crisp, sample.Levels)
+		if trace.Predicate == sample.Predicate {
+			continue
+		}
+		errors++
+		for _, band := range m.Output.Bands {
+			if band.Predicate != sample.Predicate {
+				continue
+			}
+			switch {
+			case trace.Score < band.MinScore:
+				distance += band.MinScore - trace.Score
+			case trace.Score > band.MaxScore:
+				distance += trace.Score - band.MaxScore
+			}
+		}
+	}
+	n := float64(len(samples))
+	return errors/n + bandPenalty*distance/(n*(hi-lo))
+}
+
+// accuracy adalah proporsi sampel yang predikatnya tepat
+func accuracy(m *model.Model, samples []Sample, engine inferensia.Engine) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	correct := 0
+	for _, sample := range samples {
+		if inferensia.EvaluateEngine(m, engine, sample.Crisp, sample.Levels).Predicate == sample.Predicate {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(samples))
+}
@@ -0,0 +1,154 @@
+package pelatihan
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// labeledSamples membuat lulusan acak yang diberi label oleh model truth
+func labeledSamples(t *testing.T, truth model.Definition, n int) []Sample {
+	m, err := model.Compile(truth)
+	require.NoError(t, err)
+
+	random := rand.New(rand.NewSource(3))
+	levels := []string{"internasional", "nasional", "internal"}
+	impacts := map[string]float64{"internasional": 5, "nasional": 3, "internal": 1}
+	samples := make([]Sample, 0, n)
+	for i := 0; i < n; i++ {
+		// Rentang masukan mengikuti lulusan pada umumnya
+		thesis := levels[random.Intn(len(levels))]
+		crisp, levelInputs := rules.Inputs(
+			2.75+random.Float64()*1.25,
+			7+random.Intn(4),
+			random.Intn(4),
+			1+random.Intn(10),
+			levels[random.Intn(len(levels))],
+			impacts[thesis],
+			thesis,
+			random.Intn(8),
+		)
+		sample := Sample{Crisp: crisp, Levels: levelInputs}
+		sample.Predicate = inferensia.Evaluate(m, sample.Crisp, sample.Levels).Predicate
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+func TestMinimize(t *testing.T) {
+	// Fungsi Rosenbrock dengan minimum 0 pada (1, 1)
+	rosenbrock := func(x []float64) float64 {
+		return math.Pow(1-x[0], 2) + 100*math.Pow(x[1]-x[0]*x[0], 2)
+	}
+	best, value, evaluations := minimize(rosenbrock, []float64{-1.2, 1}, []float64{0.5, 0.5}, 5000, 1e-14)
+
+	assert.InDelta(t, 1, best[0], 1e-3)
+	assert.InDelta(t, 1, best[1], 1e-3)
+	assert.Less(t, value, 1e-6)
+	assert.LessOrEqual(t, evaluations, 5000)
+}
+
+func TestSpaceDefinition(t *testing.T) {
+	def := model.Default().Definition()
+	s := newSpace(def, nil)
+	start, steps := s.start()
+	require.Len(t, steps, len(start))
+
+	// Vektor awal menghasilkan definisi semula
+	assert.Equal(t, def, s.definition(start))
+
+	// Vektor sembarang tetap menghasilkan definisi yang valid
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		x := make([]float64, len(start))
+		for j := range x {
+			x[j] = start[j] + (random.Float64()*2-1)*10*steps[j]
+		}
+		trained := s.definition(x)
+		require.Empty(t, model.Validate(trained))
+
+		total := 0.0
+		for _, weight := range trained.Weights {
+			assert.GreaterOrEqual(t, weight, 0.0)
+			total += weight
+		}
+		assert.InDelta(t, 1.0, total, 1e-9)
+	}
+}
+
+func TestTrain(t *testing.T) {
+	// Titik potong IPK "sebenarnya" lebih ketat daripada model bawaan
+	truth := model.Default().Definition()
+	for i := range truth.Variables {
+		if truth.Variables[i].Name != "ipk" {
+			continue
+		}
+		for j := range truth.Variables[i].Terms {
+			params := truth.Variables[i].Terms[j].Params
+			for k := 0; k < len(params); k += 2 {
+				params[k] = math.Min(params[k]+0.2, 4)
+			}
+		}
+	}
+	samples := labeledSamples(t, truth, 120)
+
+	result, err := Train(model.Default().Definition(), samples, Options{
+		Folds:          3,
+		MaxEvaluations: 300,
+		Variables:      []string{"ipk"},
+		Seed:           1,
+	})
+
+	require.NoError(t, err)
+	assert.Less(t, result.BaselineAccuracy, 1.0)
+	assert.Greater(t, result.TrainAccuracy, result.BaselineAccuracy)
+	assert.Len(t, result.Folds, 3)
+	for _, fold := range result.Folds {
+		assert.Equal(t, 120, fold.Train+fold.Test)
+		assert.GreaterOrEqual(t, fold.TrainAccuracy, 0.0)
+	}
+	assert.Greater(t, result.CrossValidatedAccuracy, 0.0)
+	assert.LessOrEqual(t, result.Evaluations, 4*300+4*result.Parameters)
+	require.Empty(t, model.Validate(result.Definition))
+
+	// Hanya variabel yang dipilih yang berubah
+	base := model.Default().Definition()
+	for i, vs := range result.Definition.Variables {
+		if vs.Name != "ipk" {
+			assert.Equal(t, base.Variables[i], vs)
+		}
+	}
+	assert.Equal(t, base.Rules, result.Definition.Rules)
+	assert.Equal(t, base.Outputs, result.Definition.Outputs)
+}
+
+func TestTrainErrors(t *testing.T) {
+	def := model.Default().Definition()
+	samples := labeledSamples(t, def, 4)
+
+	tests := []struct {
+		name    string
+		samples []Sample
+		opts    Options
+		errMsg  string
+	}{
+		{"Too Few Samples", samples[:2], Options{Folds: 3}, "need at least 3 labeled samples for 3 folds, got 2"},
+		{"One Fold", samples, Options{Folds: 1}, "cross-validation needs at least 2 folds, got 1"},
+		{"Unknown Predicate", append([]Sample{{Predicate: "Istimewa"}}, samples...), Options{Folds: 2}, `sample 1: unknown predicate "Istimewa"`},
+		{"Unknown Variable", samples, Options{Folds: 2, Variables: []string{"gpa"}}, `unknown variable "gpa"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Train(def, tt.samples, tt.opts)
+			assert.EqualError(t, err, tt.errMsg)
+			assert.Nil(t, result)
+		})
+	}
+}
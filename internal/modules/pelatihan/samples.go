@@ -0,0 +1,69 @@
+package pelatihan
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go-tsukamoto/internal/modules/model"
+)
+
+const (
+	predicateColumn = "predicate"
+	levelSuffix     = "_level"
+)
+
+// ReadSamples membaca sampel berlabel dari CSV. Baris pertama adalah header
+// berisi nama setiap variabel model (nilai tegas), kolom "<variabel>_level"
+// opsional untuk level kategoris dan kolom "predicate"; kolom lain diabaikan.
+func ReadSamples(r io.Reader, m *model.Model) ([]Sample, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %v", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns[predicateColumn]; !ok {
+		return nil, fmt.Errorf("missing column %q", predicateColumn)
+	}
+	for _, v := range m.Variables {
+		if _, ok := columns[v.Name]; !ok {
+			return nil, fmt.Errorf("missing column %q", v.Name)
+		}
+	}
+
+	var samples []Sample
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return samples, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		sample := Sample{
+			Crisp:     map[string]float64{},
+			Levels:    map[string]string{},
+			Predicate: strings.TrimSpace(record[columns[predicateColumn]]),
+		}
+		for _, v := range m.Variables {
+			value, err := strconv.ParseFloat(strings.TrimSpace(record[columns[v.Name]]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s value %q", line, v.Name, record[columns[v.Name]])
+			}
+			sample.Crisp[v.Name] = value
+			if i, ok := columns[v.Name+levelSuffix]; ok {
+				if level := strings.TrimSpace(record[i]); level != "" {
+					sample.Levels[v.Name] = level
+				}
+			}
+		}
+		samples = append(samples, sample)
+	}
+}
@@ -0,0 +1,215 @@
+package pelatihan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+)
+
+// Status perbandingan aturan tulisan tangan dengan data
+const (
+	RuleSupported    = "supported"
+	RuleContradicted = "contradicted"
+	RuleUnsupported  = "unsupported"
+)
+
+// MinedRule adalah aturan hasil Wang-Mendel. Terms memuat satu himpunan per
+// variabel sesuai urutan variabel model; Degree adalah derajat tertinggi
+// sampel pendukungnya (hasil kali derajat keanggotaan), Support adalah jumlah
+// sampel dengan anteseden dan predikat yang sama, dan Conflicting adalah
+// jumlah sampel dengan anteseden sama tetapi predikat lain.
+type MinedRule struct {
+	Terms       []string
+	Predicate   string
+	Degree      float64
+	Support     int
+	Conflicting int
+	Text        string
+}
+
+// RuleComparison membandingkan satu aturan yang sudah ada dengan data:
+// Supporting adalah jumlah sampel yang paling kuat menyulut aturan tersebut
+// dengan predikat yang sama dan Contradicting dengan predikat lain
+type RuleComparison struct {
+	Rule          string
+	Predicate     string
+	Supporting    int
+	Contradicting int
+	Status        string
+}
+
+// MiningOptions mengatur penambangan aturan. MinSupport membuang aturan yang
+// didukung kurang dari jumlah sampel tersebut (bawaan 1).
+type MiningOptions struct {
+	MinSupport int
+}
+
+// MiningReport adalah hasil penambangan aturan. Spec adalah basis aturan
+// siap pakai dengan bobot, operator dan fallback dari model asal; Skipped
+// adalah jumlah sampel yang tidak tercakup himpunan mana pun pada sebuah
+// variabel.
+type MiningReport struct {
+	Spec        rules.RuleBaseSpec
+	Rules       []MinedRule
+	Comparisons []RuleComparison
+	Samples     int
+	Skipped     int
+}
+
+type region struct {
+	terms   []string
+	degrees map[string]float64
+	counts  map[string]int
+}
+
+// MineRules menyusun basis aturan dari sampel berlabel dengan prosedur
+// Wang-Mendel: setiap masukan dipetakan ke himpunan dengan derajat tertinggi
+// pada setiap variabel sehingga membentuk satu aturan berderajat hasil kali
+// derajat tersebut. Aturan dengan anteseden sama tetapi predikat berbeda
+// diselesaikan dengan mempertahankan aturan berderajat tertinggi. Aturan
+// yang sudah ada pada model dibandingkan dengan data yang sama.
+func MineRules(m *model.Model, samples []Sample, opts MiningOptions) (*MiningReport, error) {
+	if opts.MinSupport <= 0 {
+		opts.MinSupport = 1
+	}
+	ranks := map[string]int{}
+	for i, band := range m.Output.Bands {
+		ranks[band.Predicate] = i
+	}
+
+	report := &MiningReport{Samples: len(samples)}
+	regions := map[string]*region{}
+	var keys []string
+	for i, sample := range samples {
+		if _, ok := ranks[sample.Predicate]; !ok {
+			return nil, fmt.Errorf("sample %d: unknown predicate %q", i+1, sample.Predicate)
+		}
+		terms, degree := strongestTerms(m, sample)
+		if degree == 0 {
+			report.Skipped++
+			continue
+		}
+		key := strings.Join(terms, "\x00")
+		r, ok := regions[key]
+		if !ok {
+			r = &region{terms: terms, degrees: map[string]float64{}, counts: map[string]int{}}
+			regions[key] = r
+			keys = append(keys, key)
+		}
+		r.degrees[sample.Predicate] = max(r.degrees[sample.Predicate], degree)
+		r.counts[sample.Predicate]++
+	}
+
+	for _, key := range keys {
+		r := regions[key]
+		rule := MinedRule{Terms: r.terms}
+		total := 0
+		for predicate, degree := range r.degrees {
+			total += r.counts[predicate]
+			// Derajat sama diselesaikan dengan dukungan lalu predikat tertinggi
+			if degree > rule.Degree ||
+				(degree == rule.Degree && (r.counts[predicate] > rule.Support ||
+					(r.counts[predicate] == rule.Support && ranks[predicate] < ranks[rule.Predicate]))) {
+				rule.Predicate, rule.Degree, rule.Support = predicate, degree, r.counts[predicate]
+			}
+		}
+		rule.Conflicting = total - rule.Support
+		if rule.Support < opts.MinSupport {
+			continue
+		}
+		rule.Text = ruleText(m, rule)
+		report.Rules = append(report.Rules, rule)
+	}
+	sort.SliceStable(report.Rules, func(i, j int) bool {
+		a, b := report.Rules[i], report.Rules[j]
+		if ranks[a.Predicate] != ranks[b.Predicate] {
+			return ranks[a.Predicate] < ranks[b.Predicate]
+		}
+		return a.Support > b.Support
+	})
+
+	report.Spec = rules.RuleBaseSpec{
+		Weights:  m.RuleBase.Spec.Weights,
+		And:      m.RuleBase.Spec.And,
+		Or:       m.RuleBase.Spec.Or,
+		Fallback: m.RuleBase.Spec.Fallback,
+	}
+	for _, rule := range report.Rules {
+		report.Spec.Rules = append(report.Spec.Rules, rules.RuleSpec{Rule: rule.Text})
+	}
+	report.Comparisons = compareRules(m, samples)
+	return report, nil
+}
+
+// strongestTerms memilih himpunan dengan derajat tertinggi pada setiap
+// variabel; derajat sama dimenangkan himpunan yang dideklarasikan lebih dulu
+func strongestTerms(m *model.Model, sample Sample) ([]string, float64) {
+	terms := make([]string, len(m.Variables))
+	degree := 1.0
+	for i, v := range m.Variables {
+		memberships := v.FuzzifyLevel(sample.Crisp[v.Name], sample.Levels[v.Name])
+		best := 0.0
+		for _, term := range v.TermNames() {
+			if memberships[term] > best {
+				terms[i], best = term, memberships[term]
+			}
+		}
+		degree *= best
+	}
+	return terms, degree
+}
+
+func ruleText(m *model.Model, rule MinedRule) string {
+	clauses := make([]string, len(m.Variables))
+	for i, v := range m.Variables {
+		clauses[i] = v.Name + " IS " + rule.Terms[i]
+	}
+	return "IF " + strings.Join(clauses, " AND ") + " THEN " + rule.Predicate
+}
+
+// compareRules membagikan setiap sampel ke aturan model yang tersulut
+// paling kuat olehnya: sampel mendukung aturan tersebut jika predikatnya sama
+// dan bertentangan jika berbeda. Aturan yang tidak pernah menjadi aturan
+// terkuat tidak didukung data.
+func compareRules(m *model.Model, samples []Sample) []RuleComparison {
+	comparisons := make([]RuleComparison, len(m.RuleBase.Rules))
+	for i, rule := range m.RuleBase.Rules {
+		comparisons[i] = RuleComparison{Rule: rule.Text, Predicate: rule.Consequent}
+	}
+	for _, sample := range samples {
+		fuzzy := make(map[string]fuzzifikasi.Memberships, len(m.Variables))
+		for _, v := range m.Variables {
+			fuzzy[v.Name] = v.FuzzifyLevel(sample.Crisp[v.Name], sample.Levels[v.Name])
+		}
+		strongest, strength := -1, 0.0
+		for i, firing := range m.RuleBase.Evaluate(fuzzy, sample.Crisp)[:len(m.RuleBase.Rules)] {
+			if firing.Strength > strength {
+				strongest, strength = i, firing.Strength
+			}
+		}
+		if strongest < 0 {
+			continue
+		}
+		if sample.Predicate == comparisons[strongest].Predicate {
+			comparisons[strongest].Supporting++
+		} else {
+			comparisons[strongest].Contradicting++
+		}
+	}
+	for i := range comparisons {
+		comparison := &comparisons[i]
+		switch {
+		case comparison.Supporting == 0 && comparison.Contradicting == 0:
+			comparison.Status = RuleUnsupported
+		case comparison.Supporting >= comparison.Contradicting:
+			comparison.Status = RuleSupported
+		default:
+			comparison.Status = RuleContradicted
+		}
+	}
+	return comparisons
+}
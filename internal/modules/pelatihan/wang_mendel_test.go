@@ -0,0 +1,136 @@
+package pelatihan
+
+import (
+	"strings"
+	"testing"
+
+	"go-tsukamoto/internal/modules/inferensia"
+	"go-tsukamoto/internal/modules/model"
+	"go-tsukamoto/internal/modules/rules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMineRules(t *testing.T) {
+	m := model.Default()
+	samples := labeledSamples(t, m.Definition(), 300)
+
+	report, err := MineRules(m, samples, MiningOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 300, report.Samples)
+	require.NotEmpty(t, report.Rules)
+
+	// Setiap sampel yang tercakup masuk ke tepat satu wilayah
+	total := 0
+	for _, rule := range report.Rules {
+		total += rule.Support + rule.Conflicting
+		assert.Len(t, rule.Terms, len(m.Variables))
+		assert.Greater(t, rule.Degree, 0.0)
+		assert.True(t, strings.HasPrefix(rule.Text, "IF ipk IS "))
+	}
+	assert.Equal(t, 300-report.Skipped, total)
+
+	// Berkas hasil dapat dibaca ulang oleh mesin aturan
+	data, err := yaml.Marshal(report.Spec)
+	require.NoError(t, err)
+	rb, err := rules.Parse(data)
+	require.NoError(t, err)
+	assert.Len(t, rb.Rules, len(report.Rules))
+	assert.Equal(t, m.RuleBase.And, rb.And)
+
+	// Basis aturan hasil penambangan mereproduksi sebagian besar label
+	// sampel yang tercakup; operator AND kompensatoris membuat aturan lain
+	// ikut tersulut sehingga akurasinya tidak sempurna
+	var covered []Sample
+	for _, sample := range samples {
+		if _, degree := strongestTerms(m, sample); degree > 0 {
+			covered = append(covered, sample)
+		}
+	}
+	mined := model.Default()
+	mined.RuleBase = rb
+	assert.Greater(t, accuracy(mined, covered, inferensia.DefaultEngine()), 0.6)
+
+	require.Len(t, report.Comparisons, len(m.RuleBase.Rules))
+	assigned := 0
+	for _, comparison := range report.Comparisons {
+		assigned += comparison.Supporting + comparison.Contradicting
+		assert.Contains(t, []string{RuleSupported, RuleContradicted, RuleUnsupported}, comparison.Status)
+	}
+	assert.LessOrEqual(t, assigned, 300)
+}
+
+func TestMineRulesConflict(t *testing.T) {
+	m := model.Default()
+	var sample Sample
+	for _, candidate := range labeledSamples(t, m.Definition(), 20) {
+		if _, degree := strongestTerms(m, candidate); degree > 0 {
+			sample = candidate
+			break
+		}
+	}
+	weaker := Sample{Crisp: map[string]float64{}, Levels: sample.Levels, Predicate: "Memuaskan"}
+	for name, value := range sample.Crisp {
+		weaker.Crisp[name] = value
+	}
+	terms, degree := strongestTerms(m, sample)
+	require.Positive(t, degree)
+
+	// Geser IPK ke arah batas himpunan agar derajatnya lebih rendah namun
+	// himpunan terkuatnya tetap sama
+	for _, delta := range []float64{0.01, -0.01, 0.02, -0.02} {
+		weaker.Crisp["ipk"] = sample.Crisp["ipk"] + delta
+		if candidate, d := strongestTerms(m, weaker); d < degree && strings.Join(candidate, ",") == strings.Join(terms, ",") {
+			break
+		}
+	}
+	_, weakerDegree := strongestTerms(m, weaker)
+	require.Less(t, weakerDegree, degree)
+	if sample.Predicate == weaker.Predicate {
+		weaker.Predicate = "Sangat Memuaskan"
+	}
+
+	report, err := MineRules(m, []Sample{weaker, weaker, sample}, MiningOptions{})
+	require.NoError(t, err)
+	require.Len(t, report.Rules, 1)
+	rule := report.Rules[0]
+	assert.Equal(t, sample.Predicate, rule.Predicate)
+	assert.Equal(t, 1, rule.Support)
+	assert.Equal(t, 2, rule.Conflicting)
+
+	// Aturan dengan dukungan kurang dari batas dibuang
+	report, err = MineRules(m, []Sample{weaker, weaker, sample}, MiningOptions{MinSupport: 2})
+	require.NoError(t, err)
+	assert.Empty(t, report.Rules)
+}
+
+func TestMineRulesUnknownPredicate(t *testing.T) {
+	report, err := MineRules(model.Default(), []Sample{{Predicate: "Istimewa"}}, MiningOptions{})
+	assert.EqualError(t, err, `sample 1: unknown predicate "Istimewa"`)
+	assert.Nil(t, report)
+}
+
+func TestReadSamples(t *testing.T) {
+	m := model.Default()
+	data := "ipk,studyDuration,repeatedCourses,achievement,achievement_level,thesis,thesis_level,activity,predicate,nim\n" +
+		"3.8,8,0,1,internasional,5,internasional,4,Cum Laude,123\n" +
+		"3.1, 9, 2, 10, , 1, internal, 0, Memuaskan,124\n"
+
+	samples, err := ReadSamples(strings.NewReader(data), m)
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, "Cum Laude", samples[0].Predicate)
+	assert.Equal(t, 3.8, samples[0].Crisp["ipk"])
+	assert.Equal(t, "internasional", samples[0].Levels["achievement"])
+	assert.Equal(t, 9.0, samples[1].Crisp["studyDuration"])
+	assert.NotContains(t, samples[1].Levels, "achievement")
+	assert.Equal(t, "internal", samples[1].Levels["thesis"])
+
+	_, err = ReadSamples(strings.NewReader("ipk,predicate\n3.5,Cum Laude\n"), m)
+	assert.EqualError(t, err, `missing column "studyDuration"`)
+
+	_, err = ReadSamples(strings.NewReader(strings.Replace(data, "3.8", "tinggi", 1)), m)
+	assert.EqualError(t, err, `line 2: invalid ipk value "tinggi"`)
+}
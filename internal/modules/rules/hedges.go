@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"math"
+	"strings"
+)
+
+// Nama hedge (pengubah linguistik) pada klausa anteseden, misalnya
+// "ipk IS very Tinggi"
+const (
+	HedgeVery      = "very"
+	HedgeSomewhat  = "somewhat"
+	HedgeExtremely = "extremely"
+	HedgeNot       = "not"
+)
+
+// Hedge mengubah derajat keanggotaan sebuah himpunan
+type Hedge func(mu float64) float64
+
+var hedges = map[string]Hedge{
+	// Konsentrasi: μ²
+	HedgeVery: func(mu float64) float64 { return mu * mu },
+	// Dilatasi: √μ
+	HedgeSomewhat: math.Sqrt,
+	// Konsentrasi kuat: μ³
+	HedgeExtremely: func(mu float64) float64 { return mu * mu * mu },
+	// Komplemen: 1 - μ
+	HedgeNot: func(mu float64) float64 { return 1 - mu },
+}
+
+// Hedges mengembalikan nama seluruh hedge, terurut
+func Hedges() []string {
+	return sortedNames(hedges)
+}
+
+func isHedge(word string) bool {
+	_, ok := hedges[strings.ToLower(word)]
+	return ok
+}
+
+// applyHedges menerapkan hedge pada derajat mu mulai dari yang paling dekat
+// dengan himpunan, sehingga "not very Tinggi" berarti 1 - μ²
+func applyHedges(names []string, mu float64) float64 {
+	for i := len(names) - 1; i >= 0; i-- {
+		mu = hedges[names[i]](mu)
+	}
+	return mu
+}
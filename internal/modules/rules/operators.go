@@ -0,0 +1,135 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Nama operator AND (t-norm). TNormWeightedMean adalah perilaku kompensatoris
+// bawaan: rata-rata terbobot derajat klausa memakai bobot faktor variabel.
+const (
+	TNormWeightedMean = "weighted_mean"
+	TNormMin          = "min"
+	TNormProduct      = "product"
+	TNormLukasiewicz  = "lukasiewicz"
+	TNormEinstein     = "einstein"
+)
+
+// Nama operator OR (s-norm)
+const (
+	SNormMax         = "max"
+	SNormProbSum     = "probabilistic_sum"
+	SNormBoundedSum  = "bounded_sum"
+	SNormEinsteinSum = "einstein_sum"
+)
+
+// TNorm menggabungkan derajat operand AND; weights adalah bobot faktor setiap
+// operand dan hanya dipakai oleh rata-rata terbobot
+type TNorm func(values, weights []float64) float64
+
+// SNorm menggabungkan dua derajat operand OR
+type SNorm func(a, b float64) float64
+
+var tNorms = map[string]TNorm{
+	TNormWeightedMean: weightedMean,
+	TNormMin:          fold(math.Min),
+	TNormProduct:      fold(func(a, b float64) float64 { return a * b }),
+	TNormLukasiewicz:  fold(func(a, b float64) float64 { return math.Max(0, a+b-1) }),
+	TNormEinstein: fold(func(a, b float64) float64 {
+		return a * b / (2 - (a + b - a*b))
+	}),
+}
+
+var sNorms = map[string]SNorm{
+	SNormMax:         math.Max,
+	SNormProbSum:     func(a, b float64) float64 { return a + b - a*b },
+	SNormBoundedSum:  func(a, b float64) float64 { return math.Min(1, a+b) },
+	SNormEinsteinSum: func(a, b float64) float64 { return (a + b) / (1 + a*b) },
+}
+
+// duals adalah pasangan s-norm setiap t-norm, dipakai jika OR tidak dipilih
+var duals = map[string]string{
+	TNormWeightedMean: SNormMax,
+	TNormMin:          SNormMax,
+	TNormProduct:      SNormProbSum,
+	TNormLukasiewicz:  SNormBoundedSum,
+	TNormEinstein:     SNormEinsteinSum,
+}
+
+// TNorms mengembalikan nama seluruh operator AND, terurut
+func TNorms() []string {
+	return sortedNames(tNorms)
+}
+
+// SNorms mengembalikan nama seluruh operator OR, terurut
+func SNorms() []string {
+	return sortedNames(sNorms)
+}
+
+// Dual mengembalikan s-norm pasangan sebuah t-norm
+func Dual(tNorm string) string {
+	return duals[tNorm]
+}
+
+// operators adalah operator yang dipakai saat mengevaluasi anteseden
+type operators struct {
+	weights map[string]float64
+	and     TNorm
+	or      SNorm
+}
+
+// resolveOperators mencari operator AND dan OR berdasarkan nama. AND kosong
+// berarti rata-rata terbobot dan OR kosong berarti pasangan dual AND.
+func resolveOperators(and, or string) (string, string, error) {
+	if and == "" {
+		and = TNormWeightedMean
+	}
+	if _, ok := tNorms[and]; !ok {
+		return "", "", fmt.Errorf("unknown AND operator %q", and)
+	}
+	if or == "" {
+		or = duals[and]
+	}
+	if _, ok := sNorms[or]; !ok {
+		return "", "", fmt.Errorf("unknown OR operator %q", or)
+	}
+	return and, or, nil
+}
+
+// weightedMean adalah rata-rata derajat yang dibobot bobot faktor variabel
+func weightedMean(values, weights []float64) float64 {
+	sum := 0.0
+	totalWeight := 0.0
+	for i, value := range values {
+		sum += weights[i] * value
+		totalWeight += weights[i]
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+	return sum / totalWeight
+}
+
+// fold menerapkan t-norm biner berurutan pada seluruh operand
+func fold(norm func(a, b float64) float64) TNorm {
+	return func(values, _ []float64) float64 {
+		if len(values) == 0 {
+			return 0
+		}
+		result := values[0]
+		for _, value := range values[1:] {
+			result = norm(result, value)
+		}
+		return result
+	}
+}
+
+func sortedNames[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"testing"
+
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTNorms(t *testing.T) {
+	values := []float64{0.8, 0.5}
+	weights := []float64{3, 1}
+
+	tests := []struct {
+		name     string
+		expected float64
+	}{
+		{TNormWeightedMean, (3*0.8 + 1*0.5) / 4},
+		{TNormMin, 0.5},
+		{TNormProduct, 0.4},
+		{TNormLukasiewicz, 0.3},
+		{TNormEinstein, 0.4 / (2 - (1.3 - 0.4))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tNorms[tt.name](values, weights), 1e-9)
+		})
+	}
+	assert.Len(t, TNorms(), len(tests))
+}
+
+func TestSNorms(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected float64
+	}{
+		{SNormMax, 0.8},
+		{SNormProbSum, 0.9},
+		{SNormBoundedSum, 1},
+		{SNormEinsteinSum, 1.3 / 1.4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, sNorms[tt.name](0.8, 0.5), 1e-9)
+		})
+	}
+}
+
+// TestDuality memeriksa hukum De Morgan S(a, b) = 1 - T(1 - a, 1 - b) untuk
+// setiap pasangan t-norm dan s-norm dual
+func TestDuality(t *testing.T) {
+	for _, and := range TNorms() {
+		if and == TNormWeightedMean {
+			continue
+		}
+		or := Dual(and)
+		for _, a := range []float64{0, 0.2, 0.7, 1} {
+			for _, b := range []float64{0, 0.4, 0.9, 1} {
+				expected := 1 - tNorms[and]([]float64{1 - a, 1 - b}, nil)
+				assert.InDelta(t, expected, sNorms[or](a, b), 1e-9, "%s/%s(%v, %v)", and, or, a, b)
+			}
+		}
+	}
+}
+
+func TestRuleBaseOperators(t *testing.T) {
+	fuzzy := map[string]fuzzifikasi.Memberships{
+		"ipk":      {"Tinggi": 0.8},
+		"activity": {"Sedang": 0.2, "Tinggi": 0.4},
+	}
+	spec := RuleBaseSpec{
+		Weights: map[string]float64{"ipk": 0.75, "activity": 0.25},
+		Rules:   []RuleSpec{{Rule: "IF ipk IS Tinggi AND (activity IS Sedang OR activity IS Tinggi) THEN Cum Laude [0.5]"}},
+	}
+
+	tests := []struct {
+		and, or  string
+		expected float64
+	}{
+		// 0.5 * (0.75*0.8 + 0.25*max(0.2, 0.4))
+		{"", "", 0.5 * (0.75*0.8 + 0.25*0.4)},
+		// Tsukamoto klasik: 0.5 * min(0.8, max(0.2, 0.4))
+		{TNormMin, "", 0.5 * 0.4},
+		// 0.5 * 0.8 * (0.2 + 0.4 - 0.08)
+		{TNormProduct, "", 0.5 * 0.8 * 0.52},
+		// Operator OR dapat dipilih terpisah: 0.5 * min(0.8, min(1, 0.6))
+		{TNormMin, SNormBoundedSum, 0.5 * 0.6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.and+"/"+tt.or, func(t *testing.T) {
+			spec.And, spec.Or = tt.and, tt.or
+			rb, err := Compile(spec)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, rb.Evaluate(fuzzy, nil)[0].Strength, 1e-9)
+		})
+	}
+
+	rb, err := Compile(RuleBaseSpec{And: TNormLukasiewicz, Rules: spec.Rules})
+	require.NoError(t, err)
+	assert.Equal(t, SNormBoundedSum, rb.Or)
+
+	_, err = Compile(RuleBaseSpec{And: "median", Rules: spec.Rules})
+	assert.Error(t, err)
+	_, err = Compile(RuleBaseSpec{Or: "xor", Rules: spec.Rules})
+	assert.Error(t, err)
+}
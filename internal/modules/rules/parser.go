@@ -0,0 +1,352 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokCompare
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize memecah teks aturan menjadi token
+func tokenize(text string) ([]token, error) {
+	var tokens []token
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")"})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{tokLBracket, "["})
+			i++
+		case r == ']':
+			tokens = append(tokens, token{tokRBracket, "]"})
+			i++
+		case strings.ContainsRune("<>=!", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("invalid operator %q at position %d", op, i)
+			}
+			tokens = append(tokens, token{tokCompare, op})
+			i += len(op)
+		case unicode.IsDigit(r) || r == '.' || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+	}
+	return append(tokens, token{kind: tokEOF}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword memeriksa apakah token saat ini adalah kata kunci (tanpa membedakan huruf besar/kecil)
+func (p *parser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && strings.EqualFold(t.text, keyword)
+}
+
+// isHedge memeriksa apakah token saat ini adalah hedge. Kata hedge hanya
+// dianggap hedge jika masih diikuti nama himpunan.
+func (p *parser) isHedge() bool {
+	t := p.peek()
+	if t.kind != tokIdent || !isHedge(t.text) {
+		return false
+	}
+	following := p.tokens[p.pos+1]
+	return following.kind == tokIdent && !isReserved(following.text)
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if !p.isKeyword(keyword) {
+		return fmt.Errorf("expected %s, got %q", keyword, p.peek().text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) expectIdent(what string) (string, error) {
+	t := p.next()
+	if t.kind != tokIdent || isReserved(t.text) {
+		return "", fmt.Errorf("expected %s, got %q", what, t.text)
+	}
+	return t.text, nil
+}
+
+func isReserved(word string) bool {
+	switch strings.ToUpper(word) {
+	case "IF", "THEN", "AND", "OR", "IS":
+		return true
+	}
+	return false
+}
+
+// parseRule mengurai "IF <anteseden> THEN <predikat> [bobot]"
+func parseRule(text string) (*Rule, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	if err := p.expectKeyword("IF"); err != nil {
+		return nil, err
+	}
+	antecedent, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("THEN"); err != nil {
+		return nil, err
+	}
+
+	var words []string
+	for p.peek().kind == tokIdent {
+		words = append(words, p.next().text)
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("missing consequent after THEN")
+	}
+
+	weight := 1.0
+	if p.peek().kind == tokLBracket {
+		p.next()
+		t := p.next()
+		if t.kind != tokNumber {
+			return nil, fmt.Errorf("expected rule weight, got %q", t.text)
+		}
+		weight, err = strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule weight %q: %v", t.text, err)
+		}
+		if p.next().kind != tokRBracket {
+			return nil, fmt.Errorf("expected ] after rule weight")
+		}
+	}
+	if p.peek().kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q after consequent", p.peek().text)
+	}
+
+	return &Rule{
+		Text:       text,
+		Antecedent: antecedent,
+		Consequent: strings.Join(words, " "),
+		Weight:     weight,
+	}, nil
+}
+
+// parseExpr: or := and { OR and }
+func (p *parser) parseExpr() (*Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	operands := []*Expr{left}
+	for p.isKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &Expr{Op: OpOr, Operands: operands}, nil
+}
+
+// parseAnd: and := clause { AND clause }
+func (p *parser) parseAnd() (*Expr, error) {
+	left, err := p.parseClause()
+	if err != nil {
+		return nil, err
+	}
+	operands := []*Expr{left}
+	for p.isKeyword("AND") {
+		p.next()
+		right, err := p.parseClause()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &Expr{Op: OpAnd, Operands: operands}, nil
+}
+
+// parseClause: clause := "(" expr ")" | variabel IS { hedge } himpunan
+func (p *parser) parseClause() (*Expr, error) {
+	if p.peek().kind == tokLParen {
+		p.next()
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokRParen {
+			return nil, fmt.Errorf("expected )")
+		}
+		return expr, nil
+	}
+
+	variable, err := p.expectIdent("variable")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("IS"); err != nil {
+		return nil, err
+	}
+	var modifiers []string
+	for p.isHedge() {
+		modifiers = append(modifiers, strings.ToLower(p.next().text))
+	}
+	term, err := p.expectIdent("term")
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{Variable: variable, Hedges: modifiers, Term: term}, nil
+}
+
+// parseCondition mengurai syarat tegas seperti "ipk >= 3.90 AND repeatedCourses == 0"
+func parseCondition(text string) (*Condition, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	cond, err := p.parseConditionOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q in condition", p.peek().text)
+	}
+	return cond, nil
+}
+
+func (p *parser) parseConditionOr() (*Condition, error) {
+	left, err := p.parseConditionAnd()
+	if err != nil {
+		return nil, err
+	}
+	operands := []*Condition{left}
+	for p.isKeyword("OR") {
+		p.next()
+		right, err := p.parseConditionAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &Condition{Op: OpOr, Operands: operands}, nil
+}
+
+func (p *parser) parseConditionAnd() (*Condition, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	operands := []*Condition{left}
+	for p.isKeyword("AND") {
+		p.next()
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &Condition{Op: OpAnd, Operands: operands}, nil
+}
+
+func (p *parser) parseComparison() (*Condition, error) {
+	if p.peek().kind == tokLParen {
+		p.next()
+		cond, err := p.parseConditionOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokRParen {
+			return nil, fmt.Errorf("expected )")
+		}
+		return cond, nil
+	}
+
+	variable, err := p.expectIdent("variable")
+	if err != nil {
+		return nil, err
+	}
+	op := p.next()
+	if op.kind != tokCompare {
+		return nil, fmt.Errorf("expected comparison operator after %s, got %q", variable, op.text)
+	}
+	value := p.next()
+	if value.kind != tokNumber {
+		return nil, fmt.Errorf("expected number after %s %s, got %q", variable, op.text, value.text)
+	}
+	number, err := strconv.ParseFloat(value.text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q: %v", value.text, err)
+	}
+	return &Condition{Op: op.text, Variable: variable, Value: number}, nil
+}
@@ -0,0 +1,492 @@
+package rules
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"go-tsukamoto/internal/modules/defuzzifikasi"
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Operator logika pada pohon anteseden dan syarat
+const (
+	OpAnd = "AND"
+	OpOr  = "OR"
+)
+
+//go:embed default_rules.yaml
+var defaultRuleFile []byte
+
+// RuleSpec adalah satu aturan dalam berkas basis aturan
+type RuleSpec struct {
+	Rule   string      `yaml:"rule" json:"rule"`
+	When   string      `yaml:"when,omitempty" json:"when,omitempty"`
+	Output *Consequent `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// FallbackSpec adalah predikat bawaan yang selalu aktif dengan kekuatan tetap
+type FallbackSpec struct {
+	Predicate string      `yaml:"predicate" json:"predicate"`
+	Strength  float64     `yaml:"strength" json:"strength"`
+	Output    *Consequent `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// Consequent adalah keluaran aturan pada metode Sugeno (TSK):
+// z = Constant + Σ Coefficients[v]·x_v dengan x_v nilai tegas variabel v.
+// Tanpa koefisien konsekuen berorde nol (konstanta).
+type Consequent struct {
+	Constant     float64            `yaml:"constant" json:"constant"`
+	Coefficients map[string]float64 `yaml:"coefficients,omitempty" json:"coefficients,omitempty"`
+}
+
+// Value menghitung keluaran konsekuen dari nilai tegas masukan
+func (c *Consequent) Value(crisp map[string]float64) float64 {
+	value := c.Constant
+	for variable, coefficient := range c.Coefficients {
+		value += coefficient * crisp[variable]
+	}
+	return value
+}
+
+// Order adalah orde konsekuen: 0 untuk konstanta, 1 untuk fungsi linear
+func (c *Consequent) Order() int {
+	if len(c.Coefficients) == 0 {
+		return 0
+	}
+	return 1
+}
+
+// RuleBaseSpec adalah isi berkas basis aturan (YAML atau JSON). And dan Or
+// memilih t-norm dan s-norm untuk seluruh aturan; kosong berarti rata-rata
+// terbobot dan pasangan dualnya.
+type RuleBaseSpec struct {
+	Weights  map[string]float64 `yaml:"weights" json:"weights"`
+	And      string             `yaml:"and,omitempty" json:"and,omitempty"`
+	Or       string             `yaml:"or,omitempty" json:"or,omitempty"`
+	Rules    []RuleSpec         `yaml:"rules" json:"rules"`
+	Fallback *FallbackSpec      `yaml:"fallback,omitempty" json:"fallback,omitempty"`
+}
+
+// Expr adalah simpul pohon anteseden. Op kosong berarti klausa
+// "Variable IS [Hedges] Term"; selain itu Operands digabung dengan AND atau OR.
+// Hedges tersimpan sesuai urutan penulisan (huruf kecil).
+type Expr struct {
+	Op       string
+	Operands []*Expr
+	Variable string
+	Hedges   []string
+	Term     string
+}
+
+// Condition adalah simpul syarat tegas. Op berisi AND, OR atau operator
+// pembanding (>=, <=, >, <, ==, !=) terhadap nilai tegas Variable.
+type Condition struct {
+	Op       string
+	Operands []*Condition
+	Variable string
+	Value    float64
+}
+
+// Rule adalah aturan yang sudah dikompilasi
+type Rule struct {
+	Text       string
+	Antecedent *Expr
+	Guard      *Condition
+	Consequent string
+	Weight     float64
+	Output     *Consequent
+}
+
+// RuleBase adalah basis aturan yang sudah dikompilasi. And dan Or adalah
+// nama operator yang dipakai setelah nilai bawaan diterapkan.
+type RuleBase struct {
+	Spec      RuleBaseSpec
+	Weights   map[string]float64
+	And       string
+	Or        string
+	Rules     []*Rule
+	Fallback  *FallbackSpec
+	operators operators
+}
+
+// Firing adalah kekuatan penyulutan (α) satu aturan beserta konsekuen
+// Sugeno-nya jika ada
+type Firing struct {
+	Rule      string
+	Predicate string
+	Strength  float64
+	Output    *Consequent
+}
+
+var active = mustParse(defaultRuleFile)
+
+// Default mengembalikan basis aturan bawaan yang disertakan pada binary
+func Default() *RuleBase {
+	return mustParse(defaultRuleFile)
+}
+
+// Active mengembalikan basis aturan yang sedang dipakai
+func Active() *RuleBase {
+	return active
+}
+
+// SetActive mengganti basis aturan yang dipakai inferensi
+func SetActive(rb *RuleBase) {
+	active = rb
+}
+
+// LoadFile membaca, mengompilasi dan mengaktifkan basis aturan dari berkas
+func LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading rule file: %v", err)
+	}
+	rb, err := Parse(data)
+	if err != nil {
+		return fmt.Errorf("error compiling rule file %s: %v", path, err)
+	}
+	SetActive(rb)
+	log.Infof("Basis aturan dimuat dari %s (%d aturan)", path, len(rb.Rules))
+	return nil
+}
+
+// Parse mengurai berkas basis aturan YAML atau JSON lalu mengompilasinya
+func Parse(data []byte) (*RuleBase, error) {
+	var spec RuleBaseSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("invalid rule file: %v", err)
+	}
+	return Compile(spec)
+}
+
+func mustParse(data []byte) *RuleBase {
+	rb, err := Parse(data)
+	if err != nil {
+		panic(err)
+	}
+	return rb
+}
+
+// Compile mengompilasi basis aturan terhadap variabel dan predikat bawaan
+func Compile(spec RuleBaseSpec) (*RuleBase, error) {
+	return CompileWith(spec, fuzzifikasi.Variables, defuzzifikasi.Predicates())
+}
+
+// CompileWith mengurai teks setiap aturan dan memvalidasi variabel, himpunan,
+// predikat serta bobotnya terhadap variabel dan predikat yang diberikan
+func CompileWith(spec RuleBaseSpec, variables []*fuzzifikasi.LinguisticVariable, predicates []string) (*RuleBase, error) {
+	c := &compiler{variables: map[string]*fuzzifikasi.LinguisticVariable{}, predicates: map[string]bool{}}
+	for _, v := range variables {
+		c.variables[v.Name] = v
+	}
+	for _, predicate := range predicates {
+		c.predicates[predicate] = true
+	}
+
+	for variable, weight := range spec.Weights {
+		if _, ok := c.variables[variable]; !ok {
+			return nil, fmt.Errorf("weight for unknown variable %q", variable)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("weight for %s must not be negative", variable)
+		}
+	}
+	if len(spec.Rules) == 0 {
+		return nil, fmt.Errorf("rule base has no rules")
+	}
+	and, or, err := resolveOperators(spec.And, spec.Or)
+	if err != nil {
+		return nil, err
+	}
+
+	rb := &RuleBase{
+		Spec:      spec,
+		Weights:   spec.Weights,
+		And:       and,
+		Or:        or,
+		Fallback:  spec.Fallback,
+		operators: operators{weights: spec.Weights, and: tNorms[and], or: sNorms[or]},
+	}
+	for i, rs := range spec.Rules {
+		rule, err := parseRule(rs.Rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %v", i+1, err)
+		}
+		if err := c.validateExpr(rule.Antecedent); err != nil {
+			return nil, fmt.Errorf("rule %d: %v", i+1, err)
+		}
+		if !c.predicates[rule.Consequent] {
+			return nil, fmt.Errorf("rule %d: unknown predicate %q", i+1, rule.Consequent)
+		}
+		if rule.Weight < 0 || rule.Weight > 1 {
+			return nil, fmt.Errorf("rule %d: weight must be between 0 and 1", i+1)
+		}
+		if rs.When != "" {
+			guard, err := parseCondition(rs.When)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d guard: %v", i+1, err)
+			}
+			if err := c.validateCondition(guard); err != nil {
+				return nil, fmt.Errorf("rule %d guard: %v", i+1, err)
+			}
+			rule.Guard = guard
+		}
+		if err := c.validateConsequent(rs.Output); err != nil {
+			return nil, fmt.Errorf("rule %d output: %v", i+1, err)
+		}
+		rule.Output = rs.Output
+		rb.Rules = append(rb.Rules, rule)
+	}
+
+	if spec.Fallback != nil {
+		if !c.predicates[spec.Fallback.Predicate] {
+			return nil, fmt.Errorf("fallback: unknown predicate %q", spec.Fallback.Predicate)
+		}
+		if spec.Fallback.Strength < 0 || spec.Fallback.Strength > 1 {
+			return nil, fmt.Errorf("fallback: strength must be between 0 and 1")
+		}
+		if err := c.validateConsequent(spec.Fallback.Output); err != nil {
+			return nil, fmt.Errorf("fallback output: %v", err)
+		}
+	}
+	return rb, nil
+}
+
+type compiler struct {
+	variables  map[string]*fuzzifikasi.LinguisticVariable
+	predicates map[string]bool
+}
+
+func (c *compiler) validateExpr(e *Expr) error {
+	if e.Op != "" {
+		for _, operand := range e.Operands {
+			if err := c.validateExpr(operand); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	variable, ok := c.variables[e.Variable]
+	if !ok {
+		return fmt.Errorf("unknown variable %q", e.Variable)
+	}
+	if !variable.HasTerm(e.Term) {
+		return fmt.Errorf("unknown term %q for variable %s", e.Term, e.Variable)
+	}
+	return nil
+}
+
+func (c *compiler) validateConsequent(output *Consequent) error {
+	if output == nil {
+		return nil
+	}
+	for variable := range output.Coefficients {
+		if _, ok := c.variables[variable]; !ok {
+			return fmt.Errorf("coefficient for unknown variable %q", variable)
+		}
+	}
+	return nil
+}
+
+func (c *compiler) validateCondition(cond *Condition) error {
+	if cond.Op == OpAnd || cond.Op == OpOr {
+		for _, operand := range cond.Operands {
+			if err := c.validateCondition(operand); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, ok := c.variables[cond.Variable]; !ok {
+		return fmt.Errorf("unknown variable %q", cond.Variable)
+	}
+	return nil
+}
+
+// Evaluate menghitung kekuatan penyulutan setiap aturan dari derajat
+// keanggotaan (fuzzy) dan nilai tegas masukan (crisp)
+func (rb *RuleBase) Evaluate(fuzzy map[string]fuzzifikasi.Memberships, crisp map[string]float64) []Firing {
+	firings := make([]Firing, 0, len(rb.Rules)+1)
+	for _, rule := range rb.Rules {
+		strength := 0.0
+		if rule.Guard == nil || rule.Guard.holds(crisp) {
+			strength = rule.Weight * rule.Antecedent.evaluate(fuzzy, rb.operators)
+		}
+		firings = append(firings, Firing{Rule: rule.Text, Predicate: rule.Consequent, Strength: strength, Output: rule.Output})
+	}
+	if rb.Fallback != nil {
+		firings = append(firings, Firing{Rule: "fallback", Predicate: rb.Fallback.Predicate, Strength: rb.Fallback.Strength, Output: rb.Fallback.Output})
+	}
+	return firings
+}
+
+// IntervalFiring adalah kekuatan penyulutan satu aturan pada himpunan
+// interval tipe-2: interval [Lower, Upper] dengan Strength titik tengahnya
+type IntervalFiring struct {
+	Firing
+	Lower float64
+	Upper float64
+}
+
+// EvaluateInterval seperti Evaluate untuk derajat keanggotaan interval:
+// lower dan upper adalah derajat fungsi bawah dan atas setiap himpunan.
+// Operator AND dan OR monoton sehingga batas bawah dihitung dari derajat
+// bawah dan batas atas dari derajat atas; hedge not menukar keduanya.
+func (rb *RuleBase) EvaluateInterval(lower, upper map[string]fuzzifikasi.Memberships, crisp map[string]float64) []IntervalFiring {
+	firings := make([]IntervalFiring, 0, len(rb.Rules)+1)
+	for _, rule := range rb.Rules {
+		lo, hi := 0.0, 0.0
+		if rule.Guard == nil || rule.Guard.holds(crisp) {
+			lo, hi = rule.Antecedent.evaluateInterval(lower, upper, rb.operators)
+			lo, hi = rule.Weight*lo, rule.Weight*hi
+		}
+		firings = append(firings, IntervalFiring{
+			Firing: Firing{Rule: rule.Text, Predicate: rule.Consequent, Strength: (lo + hi) / 2, Output: rule.Output},
+			Lower:  lo,
+			Upper:  hi,
+		})
+	}
+	if rb.Fallback != nil {
+		firings = append(firings, IntervalFiring{
+			Firing: Firing{Rule: "fallback", Predicate: rb.Fallback.Predicate, Strength: rb.Fallback.Strength, Output: rb.Fallback.Output},
+			Lower:  rb.Fallback.Strength,
+			Upper:  rb.Fallback.Strength,
+		})
+	}
+	return firings
+}
+
+// evaluateInterval menghitung interval derajat anteseden
+func (e *Expr) evaluateInterval(lower, upper map[string]fuzzifikasi.Memberships, ops operators) (float64, float64) {
+	switch e.Op {
+	case OpAnd:
+		los := make([]float64, len(e.Operands))
+		his := make([]float64, len(e.Operands))
+		weights := make([]float64, len(e.Operands))
+		for i, operand := range e.Operands {
+			los[i], his[i] = operand.evaluateInterval(lower, upper, ops)
+			weights[i] = operand.weight(ops.weights)
+		}
+		return ops.and(los, weights), ops.and(his, weights)
+	case OpOr:
+		lo, hi := 0.0, 0.0
+		for i, operand := range e.Operands {
+			l, h := operand.evaluateInterval(lower, upper, ops)
+			if i == 0 {
+				lo, hi = l, h
+				continue
+			}
+			lo, hi = ops.or(lo, l), ops.or(hi, h)
+		}
+		return lo, hi
+	default:
+		lo, hi := lower[e.Variable][e.Term], upper[e.Variable][e.Term]
+		for i := len(e.Hedges) - 1; i >= 0; i-- {
+			hedge := hedges[e.Hedges[i]]
+			if e.Hedges[i] == HedgeNot {
+				lo, hi = hedge(hi), hedge(lo)
+			} else {
+				lo, hi = hedge(lo), hedge(hi)
+			}
+		}
+		return lo, hi
+	}
+}
+
+// evaluate menghitung derajat anteseden dengan operator AND (t-norm) dan OR
+// (s-norm) basis aturan
+func (e *Expr) evaluate(fuzzy map[string]fuzzifikasi.Memberships, ops operators) float64 {
+	switch e.Op {
+	case OpAnd:
+		values := make([]float64, len(e.Operands))
+		weights := make([]float64, len(e.Operands))
+		for i, operand := range e.Operands {
+			values[i] = operand.evaluate(fuzzy, ops)
+			weights[i] = operand.weight(ops.weights)
+		}
+		return ops.and(values, weights)
+	case OpOr:
+		result := 0.0
+		for i, operand := range e.Operands {
+			value := operand.evaluate(fuzzy, ops)
+			if i == 0 {
+				result = value
+				continue
+			}
+			result = ops.or(result, value)
+		}
+		return result
+	default:
+		return applyHedges(e.Hedges, fuzzy[e.Variable][e.Term])
+	}
+}
+
+// weight adalah bobot faktor klausa; untuk kelompok klausa dipakai rata-rata
+// bobot anggotanya
+func (e *Expr) weight(weights map[string]float64) float64 {
+	if e.Op == "" {
+		return weights[e.Variable]
+	}
+	sum := 0.0
+	for _, operand := range e.Operands {
+		sum += operand.weight(weights)
+	}
+	return sum / float64(len(e.Operands))
+}
+
+// String menuliskan kembali anteseden dengan sintaks aturan, misalnya
+// "ipk IS very Tinggi AND (activity IS Sedang OR activity IS Tinggi)"
+func (e *Expr) String() string {
+	if e.Op == "" {
+		return strings.Join(append(append([]string{e.Variable, "IS"}, e.Hedges...), e.Term), " ")
+	}
+	operands := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		operands[i] = operand.String()
+		if operand.Op != "" {
+			operands[i] = "(" + operands[i] + ")"
+		}
+	}
+	return strings.Join(operands, " "+e.Op+" ")
+}
+
+func (c *Condition) holds(crisp map[string]float64) bool {
+	switch c.Op {
+	case OpAnd:
+		for _, operand := range c.Operands {
+			if !operand.holds(crisp) {
+				return false
+			}
+		}
+		return true
+	case OpOr:
+		for _, operand := range c.Operands {
+			if operand.holds(crisp) {
+				return true
+			}
+		}
+		return false
+	}
+
+	value := crisp[c.Variable]
+	switch c.Op {
+	case ">=":
+		return value >= c.Value
+	case "<=":
+		return value <= c.Value
+	case ">":
+		return value > c.Value
+	case "<":
+		return value < c.Value
+	case "==":
+		return value == c.Value
+	case "!=":
+		return value != c.Value
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-tsukamoto/internal/modules/fuzzifikasi"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := parseRule("IF ipk IS Tinggi AND (studyDuration IS Cepat OR studyDuration IS Sedang) THEN Magna Cum Laude [0.8]")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Magna Cum Laude", rule.Consequent)
+	assert.Equal(t, 0.8, rule.Weight)
+	assert.Equal(t, OpAnd, rule.Antecedent.Op)
+	require.Len(t, rule.Antecedent.Operands, 2)
+	assert.Equal(t, "ipk", rule.Antecedent.Operands[0].Variable)
+	assert.Equal(t, "Tinggi", rule.Antecedent.Operands[0].Term)
+	assert.Equal(t, OpOr, rule.Antecedent.Operands[1].Op)
+}
+
+func TestExprString(t *testing.T) {
+	text := "ipk IS very Tinggi AND (studyDuration IS Cepat OR studyDuration IS not Sedang)"
+	rule, err := parseRule("IF " + text + " THEN Cum Laude")
+	require.NoError(t, err)
+	assert.Equal(t, text, rule.Antecedent.String())
+
+	reparsed, err := parseRule("IF " + rule.Antecedent.String() + " THEN Cum Laude")
+	require.NoError(t, err)
+	assert.Equal(t, rule.Antecedent, reparsed.Antecedent)
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"Tanpa IF", "ipk IS Tinggi THEN Cum Laude"},
+		{"Tanpa THEN", "IF ipk IS Tinggi"},
+		{"Tanpa konsekuen", "IF ipk IS Tinggi THEN"},
+		{"Tanpa IS", "IF ipk Tinggi THEN Cum Laude"},
+		{"Kurung tidak ditutup", "IF (ipk IS Tinggi THEN Cum Laude"},
+		{"Bobot bukan angka", "IF ipk IS Tinggi THEN Cum Laude [tinggi]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRule(tt.text)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	cond, err := parseCondition("ipk >= 3.90 AND (repeatedCourses == 0 OR activity > 5)")
+	require.NoError(t, err)
+
+	assert.True(t, cond.holds(map[string]float64{"ipk": 3.95, "repeatedCourses": 0}))
+	assert.True(t, cond.holds(map[string]float64{"ipk": 3.95, "repeatedCourses": 2, "activity": 6}))
+	assert.False(t, cond.holds(map[string]float64{"ipk": 3.85, "repeatedCourses": 0}))
+	assert.False(t, cond.holds(map[string]float64{"ipk": 3.95, "repeatedCourses": 1, "activity": 5}))
+}
+
+func TestCompileValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		spec RuleBaseSpec
+	}{
+		{"Tanpa aturan", RuleBaseSpec{}},
+		{"Variabel tidak dikenal", RuleBaseSpec{Rules: []RuleSpec{{Rule: "IF sks IS Tinggi THEN Cum Laude"}}}},
+		{"Himpunan tidak dikenal", RuleBaseSpec{Rules: []RuleSpec{{Rule: "IF ipk IS Luar THEN Cum Laude"}}}},
+		{"Predikat tidak dikenal", RuleBaseSpec{Rules: []RuleSpec{{Rule: "IF ipk IS Tinggi THEN Istimewa"}}}},
+		{"Bobot aturan di luar rentang", RuleBaseSpec{Rules: []RuleSpec{{Rule: "IF ipk IS Tinggi THEN Cum Laude [2]"}}}},
+		{"Syarat tidak valid", RuleBaseSpec{Rules: []RuleSpec{{Rule: "IF ipk IS Tinggi THEN Cum Laude", When: "ipk >="}}}},
+		{"Koefisien Sugeno tidak dikenal", RuleBaseSpec{Rules: []RuleSpec{{
+			Rule:   "IF ipk IS Tinggi THEN Cum Laude",
+			Output: &Consequent{Constant: 1, Coefficients: map[string]float64{"sks": 0.1}},
+		}}}},
+		{"Bobot variabel tidak dikenal", RuleBaseSpec{
+			Weights: map[string]float64{"sks": 1},
+			Rules:   []RuleSpec{{Rule: "IF ipk IS Tinggi THEN Cum Laude"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	rb, err := Compile(RuleBaseSpec{
+		Weights: map[string]float64{"ipk": 0.75, "activity": 0.25},
+		Rules: []RuleSpec{
+			{Rule: "IF ipk IS Tinggi AND (activity IS Sedang OR activity IS Tinggi) THEN Cum Laude [0.5]"},
+			{Rule: "IF ipk IS Tinggi THEN Magna Cum Laude", When: "ipk >= 3.75"},
+		},
+		Fallback: &FallbackSpec{Predicate: "Cukup", Strength: 0.1},
+	})
+	require.NoError(t, err)
+
+	fuzzy := map[string]fuzzifikasi.Memberships{
+		"ipk":      {"Tinggi": 0.8},
+		"activity": {"Sedang": 0.2, "Tinggi": 0.4},
+	}
+	firings := rb.Evaluate(fuzzy, map[string]float64{"ipk": 3.6})
+
+	require.Len(t, firings, 3)
+	// 0.5 * (0.75*0.8 + 0.25*max(0.2, 0.4)) = 0.35
+	assert.InDelta(t, 0.35, firings[0].Strength, 1e-9)
+	assert.Equal(t, 0.0, firings[1].Strength)
+	assert.Equal(t, Firing{Rule: "fallback", Predicate: "Cukup", Strength: 0.1}, firings[2])
+}
+
+func TestEvaluateInterval(t *testing.T) {
+	rb, err := Compile(RuleBaseSpec{
+		And: TNormMin,
+		Rules: []RuleSpec{
+			{Rule: "IF ipk IS Tinggi AND activity IS Sedang THEN Cum Laude"},
+			{Rule: "IF ipk IS not very Tinggi OR activity IS Tinggi THEN Memuaskan [0.5]"},
+		},
+		Fallback: &FallbackSpec{Predicate: "Cukup", Strength: 0.1},
+	})
+	require.NoError(t, err)
+
+	lower := map[string]fuzzifikasi.Memberships{"ipk": {"Tinggi": 0.4}, "activity": {"Sedang": 0.6, "Tinggi": 0.1}}
+	upper := map[string]fuzzifikasi.Memberships{"ipk": {"Tinggi": 0.8}, "activity": {"Sedang": 0.6, "Tinggi": 0.1}}
+	firings := rb.EvaluateInterval(lower, upper, nil)
+
+	require.Len(t, firings, 3)
+	assert.InDelta(t, 0.4, firings[0].Lower, 1e-9)
+	assert.InDelta(t, 0.6, firings[0].Upper, 1e-9)
+	assert.InDelta(t, 0.5, firings[0].Strength, 1e-9)
+	// not very: batas bawah dari derajat atas, 0.5 * max(1 - 0.8², 0.1)
+	assert.InDelta(t, 0.5*0.36, firings[1].Lower, 1e-9)
+	assert.InDelta(t, 0.5*0.84, firings[1].Upper, 1e-9)
+	assert.Equal(t, 0.1, firings[2].Lower)
+	assert.Equal(t, 0.1, firings[2].Upper)
+
+	// Derajat bawah dan atas yang sama menghasilkan Evaluate
+	for i, firing := range rb.EvaluateInterval(upper, upper, nil) {
+		assert.InDelta(t, rb.Evaluate(upper, nil)[i].Strength, firing.Lower, 1e-9)
+		assert.InDelta(t, firing.Lower, firing.Upper, 1e-9)
+	}
+}
+
+func TestConsequent(t *testing.T) {
+	zero := &Consequent{Constant: 3}
+	first := &Consequent{Constant: 1, Coefficients: map[string]float64{"ipk": 0.5, "repeatedCourses": -0.1}}
+	crisp := map[string]float64{"ipk": 3.6, "repeatedCourses": 2}
+
+	assert.Equal(t, 0, zero.Order())
+	assert.Equal(t, 3.0, zero.Value(crisp))
+	assert.Equal(t, 1, first.Order())
+	// 1 + 0.5*3.6 - 0.1*2 = 2.6
+	assert.InDelta(t, 2.6, first.Value(crisp), 1e-9)
+
+	rb, err := Compile(RuleBaseSpec{
+		Rules:    []RuleSpec{{Rule: "IF ipk IS Tinggi THEN Cum Laude", Output: first}},
+		Fallback: &FallbackSpec{Predicate: "Cukup", Strength: 0.1, Output: zero},
+	})
+	require.NoError(t, err)
+	firings := rb.Evaluate(map[string]fuzzifikasi.Memberships{"ipk": {"Tinggi": 1}}, crisp)
+	assert.Same(t, first, firings[0].Output)
+	assert.Same(t, zero, firings[1].Output)
+}
+
+func TestLoadFile(t *testing.T) {
+	defer SetActive(Default())
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	content := `{"weights": {"ipk": 1}, "rules": [{"rule": "IF ipk IS SangatTinggi THEN Summa Cum Laude"}]}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	require.NoError(t, LoadFile(path))
+	assert.Len(t, Active().Rules, 1)
+
+	assert.Error(t, LoadFile(filepath.Join(t.TempDir(), "missing.yaml")))
+}
+
+func TestDefaultRuleBase(t *testing.T) {
+	rb := Default()
+	assert.Len(t, rb.Rules, 5)
+	assert.Equal(t, 0.4, rb.Weights["ipk"])
+	require.NotNil(t, rb.Fallback)
+	assert.Equal(t, "Cukup", rb.Fallback.Predicate)
+}
+
+func TestHedges(t *testing.T) {
+	fuzzy := map[string]fuzzifikasi.Memberships{"ipk": {"Tinggi": 0.64}}
+
+	tests := []struct {
+		clause   string
+		expected float64
+	}{
+		{"ipk IS Tinggi", 0.64},
+		{"ipk IS very Tinggi", 0.64 * 0.64},
+		{"ipk IS somewhat Tinggi", 0.8},
+		{"ipk IS EXTREMELY Tinggi", 0.64 * 0.64 * 0.64},
+		{"ipk IS not Tinggi", 0.36},
+		// Hedge terdekat dengan himpunan diterapkan lebih dulu: 1 - μ²
+		{"ipk IS not very Tinggi", 1 - 0.64*0.64},
+		{"ipk IS very very Tinggi", 0.64 * 0.64 * 0.64 * 0.64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.clause, func(t *testing.T) {
+			rb, err := Compile(RuleBaseSpec{Rules: []RuleSpec{{Rule: "IF " + tt.clause + " THEN Cum Laude"}}})
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, rb.Evaluate(fuzzy, nil)[0].Strength, 1e-9)
+		})
+	}
+
+	rule, err := parseRule("IF ipk IS Not Very Tinggi THEN Cum Laude")
+	require.NoError(t, err)
+	assert.Equal(t, []string{HedgeNot, HedgeVery}, rule.Antecedent.Hedges)
+	assert.Equal(t, "Tinggi", rule.Antecedent.Term)
+
+	// Tanpa nama himpunan, kata hedge dibaca sebagai himpunan yang tidak dikenal
+	_, err = Compile(RuleBaseSpec{Rules: []RuleSpec{{Rule: "IF ipk IS very THEN Cum Laude"}}})
+	assert.Error(t, err)
+	assert.Len(t, Hedges(), 4)
+}
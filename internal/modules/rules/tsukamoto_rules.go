@@ -6,144 +6,69 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-// TsukamotoRules menerapkan aturan Fuzzy Tsukamoto berdasarkan input
-func TsukamotoRules(ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) map[string]float64 {
-	// Fuzzifikasi input
-	ipkFuzzy := fuzzifikasi.FuzzifyIPK(ipk)
-	studyDurationFuzzy := fuzzifikasi.FuzzifyStudyDuration(completedSemester)
-	repeatedCoursesFuzzy := fuzzifikasi.FuzzifyRepeatedCourses(repeatedCourses)
-	achievementFuzzy := fuzzifikasi.FuzzifyAchievement(achievementRank, achievementLevel)
-	thesisFuzzy := fuzzifikasi.FuzzifyThesis(thesisImpactFactor, thesisLevel)
-	activityFuzzy := fuzzifikasi.FuzzifyActivity(activityCount)
-
-	// Log hasil fuzzifikasi
-	log.Infof("Fuzzifikasi IPK: %+v", ipkFuzzy)
-	log.Infof("Fuzzifikasi Durasi Studi: %+v", studyDurationFuzzy)
-	log.Infof("Fuzzifikasi Mata Kuliah Ulang: %+v", repeatedCoursesFuzzy)
-	log.Infof("Fuzzifikasi Prestasi: %+v", achievementFuzzy)
-	log.Infof("Fuzzifikasi Skripsi: %+v", thesisFuzzy)
-	log.Infof("Fuzzifikasi Aktivitas: %+v", activityFuzzy)
-
-	// Bobot faktor
-	weights := map[string]float64{
-		"ipk":             0.4,
-		"studyDuration":   0.15,
-		"repeatedCourses": 0.15,
-		"achievement":     0.15,
-		"thesis":          0.1,
-		"activity":        0.05,
-	}
-
-	rules := map[string]float64{}
-
-	// Summa Cum Laude
-	summaCumLaudeScore := weightedMin(
-		weights["ipk"], ipkFuzzy["SangatTinggi"],
-		weights["studyDuration"], studyDurationFuzzy["SangatCepat"],
-		weights["repeatedCourses"], repeatedCoursesFuzzy["SangatRendah"],
-		weights["achievement"], achievementFuzzy["SangatTinggi"],
-		weights["thesis"], thesisFuzzy["SangatTinggi"],
-		weights["activity"], activityFuzzy["Tinggi"],
-	)
-
-	// Additional hard constraints for Summa Cum Laude
-	if ipk >= 3.90 && repeatedCourses == 0 {
-		rules["Summa Cum Laude"] = summaCumLaudeScore
-	}
-
-	// Magna Cum Laude
-	magnaCumLaudeScore := weightedMin(
-		weights["ipk"], ipkFuzzy["Tinggi"],
-		weights["studyDuration"], max(studyDurationFuzzy["Cepat"], studyDurationFuzzy["Sedang"]),
-		weights["repeatedCourses"], repeatedCoursesFuzzy["Rendah"],
-		weights["achievement"], max(achievementFuzzy["Tinggi"], achievementFuzzy["Sedang"]),
-		weights["thesis"], max(thesisFuzzy["Tinggi"], thesisFuzzy["Sedang"]),
-		weights["activity"], activityFuzzy["Sedang"],
-	)
+// StudentProfile adalah masukan inferensi satu mahasiswa. Field diberi nama
+// agar nilai tidak tertukar urutannya; level kosong berarti tidak ada
+// prestasi atau publikasi pada level mana pun.
+type StudentProfile struct {
+	IPK                float64
+	CompletedSemester  int
+	RepeatedCourses    int
+	AchievementRank    int
+	AchievementLevel   string
+	ThesisImpactFactor float64
+	ThesisLevel        string
+	ActivityCount      int
+}
 
-	// Additional constraints for Magna Cum Laude
-	if ipk >= 3.75 && repeatedCourses <= 1 {
-		rules["Magna Cum Laude"] = magnaCumLaudeScore
+// Inputs memetakan profil mahasiswa ke nilai tegas setiap variabel (juga
+// dipakai untuk syarat tambahan pada aturan) dan level kategorisnya
+func (p StudentProfile) Inputs() (map[string]float64, map[string]string) {
+	crisp := map[string]float64{
+		"ipk":             p.IPK,
+		"studyDuration":   float64(p.CompletedSemester),
+		"repeatedCourses": float64(p.RepeatedCourses),
+		"achievement":     float64(p.AchievementRank),
+		"thesis":          p.ThesisImpactFactor,
+		"activity":        float64(p.ActivityCount),
 	}
-
-	// Cum Laude
-	cumLaudeScore := weightedMin(
-		weights["ipk"], ipkFuzzy["Sedang"],
-		weights["studyDuration"], studyDurationFuzzy["Sedang"],
-		weights["repeatedCourses"], repeatedCoursesFuzzy["Sedang"],
-		weights["achievement"], achievementFuzzy["Sedang"],
-		weights["thesis"], thesisFuzzy["Sedang"],
-		weights["activity"], activityFuzzy["Sedang"],
-	)
-
-	if ipk >= 3.50 {
-		rules["Cum Laude"] = cumLaudeScore
+	levels := map[string]string{
+		"achievement": p.AchievementLevel,
+		"thesis":      p.ThesisLevel,
 	}
+	return crisp, levels
+}
 
-	// Sangat Memuaskan
-	rules["Sangat Memuaskan"] = weightedMin(
-		weights["ipk"], max(ipkFuzzy["Rendah"], ipkFuzzy["Sedang"]),
-		weights["studyDuration"], studyDurationFuzzy["Lama"],
-		weights["repeatedCourses"], repeatedCoursesFuzzy["Tinggi"],
-		weights["achievement"], achievementFuzzy["Rendah"],
-		weights["thesis"], thesisFuzzy["Rendah"],
-		weights["activity"], activityFuzzy["Rendah"],
-	)
-
-	// Memuaskan
-	rules["Memuaskan"] = weightedMin(
-		weights["ipk"], ipkFuzzy["SangatRendah"],
-		weights["studyDuration"], studyDurationFuzzy["SangatLama"],
-		weights["repeatedCourses"], repeatedCoursesFuzzy["SangatTinggi"],
-		weights["achievement"], achievementFuzzy["SangatRendah"],
-		weights["thesis"], thesisFuzzy["SangatRendah"],
-		weights["activity"], activityFuzzy["SangatRendah"],
-	)
-
-	// Cukup (default jika tidak memenuhi kriteria lain)
-	rules["Cukup"] = 0.1
+// TsukamotoRules menerapkan basis aturan aktif terhadap profil mahasiswa dan
+// mengembalikan kekuatan penyulutan setiap aturan
+func TsukamotoRules(profile StudentProfile) []Firing {
+	crisp, levels := profile.Inputs()
 
-	// Normalisasi nilai aturan
-	totalWeight := 0.0
-	for _, weight := range rules {
-		totalWeight += weight
-	}
+	// Fuzzifikasi input
+	fuzzy := fuzzifikasi.FuzzifyAll(crisp, levels)
 
-	if totalWeight > 0 {
-		for category := range rules {
-			rules[category] = rules[category] / totalWeight
-		}
-	}
+	// Nilai α tidak dinormalisasi karena defuzzifikasi Tsukamoto
+	// menginversikan α setiap aturan pada himpunan keluarannya
+	firings := Active().Evaluate(fuzzy, crisp)
 
 	// Log hasil aturan fuzzy
-	log.Infof("Hasil Aturan Fuzzy: %+v", rules)
+	log.Infof("Hasil Aturan Fuzzy: %+v", firings)
 
-	return rules
+	return firings
 }
 
-// Fungsi perhitungan minimum berbobot
-func weightedMin(weightsAndValues ...float64) float64 {
-	sum := 0.0
-	totalWeight := 0.0
-	for i := 0; i < len(weightsAndValues); i += 2 {
-		weight := weightsAndValues[i]
-		value := weightsAndValues[i+1]
-		sum += weight * value
-		totalWeight += weight
-	}
-	return sum / totalWeight
-}
-
-// Fungsi untuk mengambil nilai maksimum
-func max(values ...float64) float64 {
-	if len(values) == 0 {
-		return 0
-	}
-	maxValue := values[0]
-	for _, value := range values {
-		if value > maxValue {
-			maxValue = value
-		}
-	}
-	return maxValue
+// Inputs memetakan masukan mahasiswa ke nilai tegas setiap variabel dan
+// level kategorisnya.
+//
+// Deprecated: gunakan StudentProfile.Inputs agar argumen tidak tertukar.
+func Inputs(ipk float64, completedSemester int, repeatedCourses int, achievementRank int, achievementLevel string, thesisImpactFactor float64, thesisLevel string, activityCount int) (map[string]float64, map[string]string) {
+	return StudentProfile{
+		IPK:                ipk,
+		CompletedSemester:  completedSemester,
+		RepeatedCourses:    repeatedCourses,
+		AchievementRank:    achievementRank,
+		AchievementLevel:   achievementLevel,
+		ThesisImpactFactor: thesisImpactFactor,
+		ThesisLevel:        thesisLevel,
+		ActivityCount:      activityCount,
+	}.Inputs()
 }
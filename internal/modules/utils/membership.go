@@ -0,0 +1,306 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Jenis fungsi keanggotaan yang tersedia
+const (
+	KindTriangular  = "triangular"
+	KindTrapezoidal = "trapezoidal"
+	KindGaussian    = "gaussian"
+	KindBell        = "bell"
+	KindSigmoid     = "sigmoid"
+	KindPiecewise   = "piecewise"
+)
+
+// MembershipFunction adalah fungsi keanggotaan himpunan fuzzy
+type MembershipFunction interface {
+	// Degree menghitung derajat keanggotaan x dalam rentang [0, 1]
+	Degree(x float64) float64
+	// Kind mengembalikan jenis fungsi, misalnya "triangular"
+	Kind() string
+	// Params mengembalikan parameter fungsi sesuai urutan konstruktornya
+	Params() []float64
+}
+
+// NewMembershipFunction membuat fungsi keanggotaan dari jenis dan parameternya.
+// Parameter piecewise ditulis berpasangan: x1, y1, x2, y2, ...
+func NewMembershipFunction(kind string, params []float64) (MembershipFunction, error) {
+	expect := func(n int) error {
+		if len(params) != n {
+			return fmt.Errorf("%s membership function needs %d parameters, got %d", kind, n, len(params))
+		}
+		return nil
+	}
+
+	switch kind {
+	case KindTriangular:
+		if err := expect(3); err != nil {
+			return nil, err
+		}
+		return NewTriangular(params[0], params[1], params[2])
+	case KindTrapezoidal:
+		if err := expect(4); err != nil {
+			return nil, err
+		}
+		return NewTrapezoidal(params[0], params[1], params[2], params[3])
+	case KindGaussian:
+		if err := expect(2); err != nil {
+			return nil, err
+		}
+		return NewGaussian(params[0], params[1])
+	case KindBell:
+		if err := expect(3); err != nil {
+			return nil, err
+		}
+		return NewBell(params[0], params[1], params[2])
+	case KindSigmoid:
+		if err := expect(2); err != nil {
+			return nil, err
+		}
+		return NewSigmoid(params[0], params[1])
+	case KindPiecewise:
+		if len(params)%2 != 0 {
+			return nil, fmt.Errorf("piecewise membership function needs x, y pairs")
+		}
+		points := make([]Point, 0, len(params)/2)
+		for i := 0; i < len(params); i += 2 {
+			points = append(points, Point{X: params[i], Y: params[i+1]})
+		}
+		return NewPiecewiseLinear(points)
+	}
+	return nil, fmt.Errorf("unknown membership function %q", kind)
+}
+
+// Must mengembalikan fungsi keanggotaan atau panic jika parameternya tidak valid.
+// Dipakai untuk deklarasi tingkat paket.
+func Must[T MembershipFunction](mf T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return mf
+}
+
+func finite(values ...float64) error {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("parameter must be finite, got %v", v)
+		}
+	}
+	return nil
+}
+
+// Triangular adalah fungsi segitiga dengan kaki A, C dan puncak B
+type Triangular struct {
+	A, B, C float64
+}
+
+// NewTriangular membuat fungsi segitiga; syarat A <= B <= C dan A < C
+func NewTriangular(a, b, c float64) (*Triangular, error) {
+	if err := finite(a, b, c); err != nil {
+		return nil, err
+	}
+	if a > b || b > c || a == c {
+		return nil, fmt.Errorf("triangular needs a <= b <= c and a < c, got %v, %v, %v", a, b, c)
+	}
+	return &Triangular{A: a, B: b, C: c}, nil
+}
+
+func (t *Triangular) Degree(x float64) float64 {
+	switch {
+	case x == t.B:
+		return 1
+	case x <= t.A || x >= t.C:
+		return 0
+	case x < t.B:
+		return (x - t.A) / (t.B - t.A)
+	default:
+		return (t.C - x) / (t.C - t.B)
+	}
+}
+
+func (t *Triangular) Kind() string { return KindTriangular }
+
+func (t *Triangular) Params() []float64 { return []float64{t.A, t.B, t.C} }
+
+// Trapezoidal adalah fungsi trapesium dengan kaki A, D dan puncak datar B..C
+type Trapezoidal struct {
+	A, B, C, D float64
+}
+
+// NewTrapezoidal membuat fungsi trapesium; syarat A <= B <= C <= D dan A < D
+func NewTrapezoidal(a, b, c, d float64) (*Trapezoidal, error) {
+	if err := finite(a, b, c, d); err != nil {
+		return nil, err
+	}
+	if a > b || b > c || c > d || a == d {
+		return nil, fmt.Errorf("trapezoidal needs a <= b <= c <= d and a < d, got %v, %v, %v, %v", a, b, c, d)
+	}
+	return &Trapezoidal{A: a, B: b, C: c, D: d}, nil
+}
+
+func (t *Trapezoidal) Degree(x float64) float64 {
+	switch {
+	case x >= t.B && x <= t.C:
+		return 1
+	case x <= t.A || x >= t.D:
+		return 0
+	case x < t.B:
+		return (x - t.A) / (t.B - t.A)
+	default:
+		return (t.D - x) / (t.D - t.C)
+	}
+}
+
+func (t *Trapezoidal) Kind() string { return KindTrapezoidal }
+
+func (t *Trapezoidal) Params() []float64 { return []float64{t.A, t.B, t.C, t.D} }
+
+// Gaussian adalah fungsi Gauss dengan pusat Mean dan lebar Sigma
+type Gaussian struct {
+	Mean, Sigma float64
+}
+
+// NewGaussian membuat fungsi Gauss; syarat Sigma > 0
+func NewGaussian(mean, sigma float64) (*Gaussian, error) {
+	if err := finite(mean, sigma); err != nil {
+		return nil, err
+	}
+	if sigma <= 0 {
+		return nil, fmt.Errorf("gaussian needs sigma > 0, got %v", sigma)
+	}
+	return &Gaussian{Mean: mean, Sigma: sigma}, nil
+}
+
+func (g *Gaussian) Degree(x float64) float64 {
+	d := (x - g.Mean) / g.Sigma
+	return math.Exp(-0.5 * d * d)
+}
+
+func (g *Gaussian) Kind() string { return KindGaussian }
+
+func (g *Gaussian) Params() []float64 { return []float64{g.Mean, g.Sigma} }
+
+// Bell adalah fungsi lonceng umum 1 / (1 + |(x - C) / A|^(2B))
+type Bell struct {
+	A, B, C float64
+}
+
+// NewBell membuat fungsi lonceng umum; syarat A > 0 dan B > 0
+func NewBell(a, b, c float64) (*Bell, error) {
+	if err := finite(a, b, c); err != nil {
+		return nil, err
+	}
+	if a <= 0 || b <= 0 {
+		return nil, fmt.Errorf("bell needs a > 0 and b > 0, got %v, %v", a, b)
+	}
+	return &Bell{A: a, B: b, C: c}, nil
+}
+
+func (b *Bell) Degree(x float64) float64 {
+	return 1 / (1 + math.Pow(math.Abs((x-b.C)/b.A), 2*b.B))
+}
+
+func (b *Bell) Kind() string { return KindBell }
+
+func (b *Bell) Params() []float64 { return []float64{b.A, b.B, b.C} }
+
+// Sigmoid adalah fungsi 1 / (1 + e^(-Slope(x - Center))). Slope positif
+// berarti monoton naik, negatif berarti monoton turun.
+type Sigmoid struct {
+	Slope, Center float64
+}
+
+// NewSigmoid membuat fungsi sigmoid; syarat Slope != 0
+func NewSigmoid(slope, center float64) (*Sigmoid, error) {
+	if err := finite(slope, center); err != nil {
+		return nil, err
+	}
+	if slope == 0 {
+		return nil, fmt.Errorf("sigmoid needs a non-zero slope")
+	}
+	return &Sigmoid{Slope: slope, Center: center}, nil
+}
+
+func (s *Sigmoid) Degree(x float64) float64 {
+	return 1 / (1 + math.Exp(-s.Slope*(x-s.Center)))
+}
+
+func (s *Sigmoid) Kind() string { return KindSigmoid }
+
+func (s *Sigmoid) Params() []float64 { return []float64{s.Slope, s.Center} }
+
+// Point adalah titik (x, μ) pada fungsi piecewise-linear
+type Point struct {
+	X, Y float64
+}
+
+// PiecewiseLinear adalah fungsi linear sepotong-sepotong melalui Points.
+// Di luar titik pertama dan terakhir nilainya tetap (bahu), sehingga fungsi
+// monoton turun LinearMembershipDown(x, a, b) sama dengan titik (a, 1), (b, 0).
+// Dua titik berurutan dengan X sama membentuk lompatan; pada X tersebut
+// derajatnya adalah yang terkecil dari keduanya, seperti fungsi lama yang
+// bernilai 0 di batas rentangnya.
+type PiecewiseLinear struct {
+	Points []Point
+}
+
+// NewPiecewiseLinear membuat fungsi piecewise-linear; syarat minimal dua titik,
+// X tidak menurun dengan paling banyak dua titik pada X yang sama, dan Y dalam
+// [0, 1]
+func NewPiecewiseLinear(points []Point) (*PiecewiseLinear, error) {
+	if len(points) < 2 {
+		return nil, fmt.Errorf("piecewise needs at least 2 points, got %d", len(points))
+	}
+	for i, p := range points {
+		if err := finite(p.X, p.Y); err != nil {
+			return nil, err
+		}
+		if p.Y < 0 || p.Y > 1 {
+			return nil, fmt.Errorf("piecewise point %d has degree %v outside [0, 1]", i+1, p.Y)
+		}
+		if i > 0 && p.X < points[i-1].X {
+			return nil, fmt.Errorf("piecewise points must have non-decreasing x")
+		}
+		if i > 1 && p.X == points[i-2].X {
+			return nil, fmt.Errorf("piecewise allows at most 2 points at x = %v", p.X)
+		}
+	}
+	if points[0].X == points[len(points)-1].X {
+		return nil, fmt.Errorf("piecewise points must span a range of x")
+	}
+	return &PiecewiseLinear{Points: append([]Point(nil), points...)}, nil
+}
+
+func (p *PiecewiseLinear) Degree(x float64) float64 {
+	first, last := p.Points[0], p.Points[len(p.Points)-1]
+	if x < first.X {
+		return first.Y
+	}
+	if x > last.X {
+		return last.Y
+	}
+	i := sort.Search(len(p.Points), func(i int) bool { return p.Points[i].X >= x })
+	if p.Points[i].X == x {
+		degree := p.Points[i].Y
+		if i+1 < len(p.Points) && p.Points[i+1].X == x {
+			degree = math.Min(degree, p.Points[i+1].Y)
+		}
+		return degree
+	}
+	left, right := p.Points[i-1], p.Points[i]
+	return left.Y + (x-left.X)*(right.Y-left.Y)/(right.X-left.X)
+}
+
+func (p *PiecewiseLinear) Kind() string { return KindPiecewise }
+
+func (p *PiecewiseLinear) Params() []float64 {
+	params := make([]float64, 0, 2*len(p.Points))
+	for _, point := range p.Points {
+		params = append(params, point.X, point.Y)
+	}
+	return params
+}
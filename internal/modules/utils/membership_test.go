@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembershipDegree(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		params   []float64
+		x        float64
+		expected float64
+	}{
+		{"Segitiga kaki kiri", KindTriangular, []float64{0, 2, 4}, 0, 0},
+		{"Segitiga naik", KindTriangular, []float64{0, 2, 4}, 1, 0.5},
+		{"Segitiga puncak", KindTriangular, []float64{0, 2, 4}, 2, 1},
+		{"Segitiga turun", KindTriangular, []float64{0, 2, 4}, 3.5, 0.25},
+		{"Segitiga tegak di puncak", KindTriangular, []float64{2, 2, 4}, 2, 1},
+		{"Trapesium naik", KindTrapezoidal, []float64{0, 1, 3, 4}, 0.5, 0.5},
+		{"Trapesium datar", KindTrapezoidal, []float64{0, 1, 3, 4}, 2, 1},
+		{"Trapesium turun", KindTrapezoidal, []float64{0, 1, 3, 4}, 3.75, 0.25},
+		{"Trapesium luar", KindTrapezoidal, []float64{0, 1, 3, 4}, 5, 0},
+		{"Gauss pusat", KindGaussian, []float64{3, 0.5}, 3, 1},
+		{"Gauss satu sigma", KindGaussian, []float64{3, 0.5}, 3.5, 0.6065306597},
+		{"Lonceng pusat", KindBell, []float64{1, 2, 3}, 3, 1},
+		{"Lonceng titik silang", KindBell, []float64{1, 2, 3}, 4, 0.5},
+		{"Sigmoid pusat", KindSigmoid, []float64{4, 3}, 3, 0.5},
+		{"Sigmoid turun", KindSigmoid, []float64{-4, 3}, 4, 0.0179862100},
+		{"Piecewise bahu kiri", KindPiecewise, []float64{0, 1, 2, 0}, -1, 1},
+		{"Piecewise tengah", KindPiecewise, []float64{0, 1, 2, 0}, 0.5, 0.75},
+		{"Piecewise bahu kanan", KindPiecewise, []float64{0, 0, 1, 1, 2, 0.5}, 3, 0.5},
+		{"Piecewise sebelum lompatan", KindPiecewise, []float64{3, 0, 3.75, 1, 3.75, 0}, 3.7, 0.9333333333},
+		{"Piecewise pada lompatan", KindPiecewise, []float64{3, 0, 3.75, 1, 3.75, 0}, 3.75, 0},
+		{"Piecewise lompatan di awal", KindPiecewise, []float64{1.5, 0, 1.5, 1, 2.75, 0}, 1.5, 0},
+		{"Piecewise setelah lompatan", KindPiecewise, []float64{1.5, 0, 1.5, 1, 2.75, 0}, 2, 0.6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mf, err := NewMembershipFunction(tt.kind, tt.params)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, mf.Degree(tt.x), 1e-9)
+			assert.Equal(t, tt.kind, mf.Kind())
+			assert.Equal(t, tt.params, mf.Params())
+		})
+	}
+}
+
+func TestMembershipValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   string
+		params []float64
+	}{
+		{"Jenis tidak dikenal", "cosine", []float64{1}},
+		{"Jumlah parameter salah", KindTriangular, []float64{0, 1}},
+		{"Segitiga tidak terurut", KindTriangular, []float64{2, 1, 3}},
+		{"Segitiga titik tunggal", KindTriangular, []float64{1, 1, 1}},
+		{"Trapesium tidak terurut", KindTrapezoidal, []float64{0, 3, 2, 4}},
+		{"Gauss sigma nol", KindGaussian, []float64{1, 0}},
+		{"Lonceng lebar negatif", KindBell, []float64{-1, 2, 0}},
+		{"Sigmoid datar", KindSigmoid, []float64{0, 1}},
+		{"Piecewise ganjil", KindPiecewise, []float64{0, 1, 2}},
+		{"Piecewise x menurun", KindPiecewise, []float64{2, 1, 1, 0}},
+		{"Piecewise tiga titik pada x sama", KindPiecewise, []float64{0, 0, 1, 1, 1, 0, 1, 1}},
+		{"Piecewise tanpa rentang", KindPiecewise, []float64{1, 0, 1, 1}},
+		{"Piecewise derajat di luar [0, 1]", KindPiecewise, []float64{0, 0, 1, 1.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMembershipFunction(tt.kind, tt.params)
+			assert.Error(t, err)
+		})
+	}
+}
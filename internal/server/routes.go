@@ -64,6 +64,25 @@ func (s *Server) RegisterRoutes() http.Handler {
 	// Fuzzy route
 	fuzzyHandler := handlers.NewFuzzyHandler(s.fuzzyService)
 	router.HandleFunc("/fuzzy", fuzzyHandler.CalculateFuzzy).Methods("POST")
+	router.HandleFunc("/fuzzy/what-if", fuzzyHandler.WhatIf).Methods("POST")
+	router.HandleFunc("/fuzzy/sensitivity", fuzzyHandler.Sensitivity).Methods("POST")
+	router.HandleFunc("/fuzzy/counterfactual", fuzzyHandler.Counterfactuals).Methods("POST")
+
+	// Fuzzy model routes
+	fuzzyModelHandler := handlers.NewFuzzyModelHandler(s.fuzzyModelService)
+	router.HandleFunc("/fuzzy/models", fuzzyModelHandler.GetModels).Methods("GET")
+	router.HandleFunc("/fuzzy/models", fuzzyModelHandler.CreateDraft).Methods("POST")
+	router.HandleFunc("/fuzzy/models/rollback", fuzzyModelHandler.RollbackModel).Methods("POST")
+	router.HandleFunc("/fuzzy/models/fcl", fuzzyModelHandler.ImportFCL).Methods("POST")
+	router.HandleFunc("/fuzzy/models/train", fuzzyHandler.Train).Methods("POST")
+	router.HandleFunc("/fuzzy/models/{version}", fuzzyModelHandler.GetModel).Methods("GET")
+	router.HandleFunc("/fuzzy/models/{version}", fuzzyModelHandler.UpdateDraft).Methods("PUT")
+	router.HandleFunc("/fuzzy/models/{version}", fuzzyModelHandler.DeleteDraft).Methods("DELETE")
+	router.HandleFunc("/fuzzy/models/{version}/validate", fuzzyModelHandler.ValidateModel).Methods("POST")
+	router.HandleFunc("/fuzzy/models/{version}/check", fuzzyModelHandler.CheckRules).Methods("POST")
+	router.HandleFunc("/fuzzy/models/{version}/fcl", fuzzyModelHandler.ExportFCL).Methods("GET")
+	router.HandleFunc("/fuzzy/models/{version}/shadow", fuzzyHandler.Shadow).Methods("POST")
+	router.HandleFunc("/fuzzy/models/{version}/publish", fuzzyModelHandler.PublishModel).Methods("POST")
 
 	// Course routes
 	courseHandler := handlers.NewCourseHandler(s.courseService)
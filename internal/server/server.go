@@ -14,6 +14,7 @@ import (
 	"go-tsukamoto/internal/app/service/activity"
 	"go-tsukamoto/internal/app/service/course"
 	fuzzy "go-tsukamoto/internal/app/service/fuzzy"
+	"go-tsukamoto/internal/app/service/fuzzymodel"
 	"go-tsukamoto/internal/app/service/thesis"
 	"go-tsukamoto/internal/app/service/user"
 
@@ -30,11 +31,13 @@ type Server struct {
 	activityService    activity.ActivityService
 	thesisService      thesis.ThesisService
 	fuzzyService       fuzzy.FuzzyServiceInterface
+	fuzzyModelService  fuzzymodel.FuzzyModelServiceInterface
 	courseService      course.CourseServiceInterface
 }
 
 func NewServer(db *gorm.DB) *http.Server {
 	port, _ := strconv.Atoi(os.Getenv("PORT"))
+	fuzzyModelService := fuzzymodel.NewService(db)
 	NewServer := &Server{
 		port:               port,
 		db:                 database.New(),
@@ -43,7 +46,8 @@ func NewServer(db *gorm.DB) *http.Server {
 		academicService:    academic.NewService(db),
 		activityService:    activity.NewService(db),
 		thesisService:      thesis.NewService(db),
-		fuzzyService:       fuzzy.NewService(db),
+		fuzzyService:       fuzzy.NewService(db, fuzzyModelService),
+		fuzzyModelService:  fuzzyModelService,
 		courseService:      course.NewService(db),
 	}
 